@@ -0,0 +1,121 @@
+package main
+
+import (
+	"CRUD_Go_Backend/internal/auth"
+	"CRUD_Go_Backend/internal/config"
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/repository"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+func runAPIKey(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing apikey command, expected: create, list or revoke")
+	}
+
+	command, args := args[0], args[1:]
+
+	fs := flag.NewFlagSet("apikey "+command, flag.ExitOnError)
+	name := fs.String("name", "", "name of the key owner (create only)")
+	loader := config.NewLoader(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	database, err := connection.NewDB(ctx, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to connect Database %w", err)
+	}
+
+	defer database.GetPool(ctx).Close()
+
+	apiKeyStorage := repository.NewAPIKeyStorage(database)
+
+	switch command {
+	case "create":
+		return createAPIKey(ctx, &apiKeyStorage, *name)
+	case "list":
+		return listAPIKeys(ctx, &apiKeyStorage)
+	case "revoke":
+		if fs.NArg() != 1 {
+			return errors.New("usage: crud apikey revoke ID")
+		}
+
+		id, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid api key id %q", fs.Arg(0))
+		}
+
+		if err := apiKeyStorage.Revoke(ctx, id); err != nil {
+			return fmt.Errorf("could not revoke api key %d: %w", id, err)
+		}
+
+		fmt.Printf("Revoked api key %d\n", id)
+
+		return nil
+	default:
+		return fmt.Errorf("unknown apikey command %q, expected: create, list or revoke", command)
+	}
+}
+
+func createAPIKey(ctx context.Context, apiKeyStorage repository.APIKeyPgRepo, name string) error {
+	if name == "" {
+		return errors.New("usage: crud apikey create --name NAME")
+	}
+
+	key, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		return err
+	}
+
+	id, err := apiKeyStorage.Add(ctx, models.APIKey{Name: name, Prefix: prefix}, auth.HashAPIKey(key))
+	if err != nil {
+		return fmt.Errorf("could not store api key: %w", err)
+	}
+
+	fmt.Printf("Created api key %d for %q. Store it now, it cannot be shown again:\n%s\n", id, name, key)
+
+	return nil
+}
+
+func listAPIKeys(ctx context.Context, apiKeyStorage repository.APIKeyPgRepo) error {
+	apiKeys, err := apiKeyStorage.List(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list api keys: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPREFIX\tCREATED\tLAST USED\tREVOKED")
+
+	for _, apiKey := range apiKeys {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			apiKey.ID, apiKey.Name, apiKey.Prefix,
+			apiKey.CreatedAt.Format(time.RFC3339), formatOptionalTime(apiKey.LastUsedAt), formatOptionalTime(apiKey.RevokedAt))
+	}
+
+	return w.Flush()
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"CRUD_Go_Backend/internal/config"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func runConfig(args []string) error {
+	if len(args) == 0 || args[0] != "print" {
+		return fmt.Errorf("unknown config command, expected: crud config print [--redacted]")
+	}
+
+	fs := flag.NewFlagSet("config print", flag.ExitOnError)
+	redacted := fs.Bool("redacted", false, "replace secrets with a placeholder")
+	loader := config.NewLoader(fs)
+
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return err
+	}
+
+	if *redacted {
+		cfg = cfg.Redacted()
+	}
+
+	return cfg.Print(os.Stdout)
+}
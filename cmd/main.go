@@ -1,74 +1,61 @@
+// Command crud runs the CRUD API server and its maintenance commands.
+//
+//	crud [serve] [flags]            start the HTTP server (default)
+//	crud config print [--redacted]  print the effective configuration
+//	crud apikey create|list|revoke  manage API keys
 package main
 
 import (
-	"CRUD_Go_Backend/internal/config"
-	"CRUD_Go_Backend/internal/handlers"
-	"CRUD_Go_Backend/internal/pkg/connection"
-	"CRUD_Go_Backend/internal/repository"
-	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"log"
-	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
 
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
 
-func main() {
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
-	defer cancel()
+const usage = `usage:
+  crud [serve] [flags]            start the HTTP server
+  crud config print [--redacted]  print the effective configuration
+  crud apikey create --name NAME  create an API key and print it once
+  crud apikey list                list API keys
+  crud apikey revoke ID           revoke an API key
+
+Run "crud serve -h" to list the configuration flags.
+`
 
-	if err := godotenv.Load(".env"); err != nil {
+func main() {
+	// The .env file is optional; values already present in the environment take precedence over it.
+	if err := godotenv.Load(".env"); err != nil && !errors.Is(err, fs.ErrNotExist) {
 		log.Fatalf("Could not set up environment variable: %s", err)
 	}
 
-	queryParamKey := os.Getenv("QUERY_PARAM_KEY")
-	port := os.Getenv("PORT")
+	args := os.Args[1:]
 
-	dbConfig, err := config.FromEnv()
-	if err != nil {
-		log.Fatalf("Could not get environment variable: %v", err)
+	command := "serve"
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		command, args = args[0], args[1:]
 	}
 
-	database, err := connection.NewDB(ctx, dbConfig)
-	if err != nil {
-		log.Fatalf("Failed to connect Database %s", err)
+	var err error
+
+	switch command {
+	case "serve":
+		err = runServe(args)
+	case "config":
+		err = runConfig(args)
+	case "apikey":
+		err = runAPIKey(args)
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
 	}
 
-	defer database.GetPool(ctx).Close()
-
-	err = connection.MigrationUp(dbConfig)
 	if err != nil {
-		log.Fatalf("Failed to %v", err)
-	}
-
-	quit := make(chan os.Signal, 1)
-
-	signal.Notify(quit, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
-	defer signal.Stop(quit)
-
-	go func() {
-		<-quit
-		log.Printf("Graceful Shut down")
-		// Perform graceful shut down
-		if err := connection.MigrationDownAndCloseSql(dbConfig); err != nil {
-			log.Printf("Error during migration down and closing SQL: %v", err)
-		}
-
-		// Close the database pool
-		database.GetPool(ctx).Close()
-
-		os.Exit(0)
-	}()
-
-	studentStorage := repository.NewStudentStorage(database)
-	classInfoStorage := repository.NewClassInfoStorage(database)
-
-	router := handlers.NewRouter(&studentStorage, &classInfoStorage, queryParamKey)
-
-	if err := http.ListenAndServe(port, router); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		log.Fatal(err)
 	}
 }
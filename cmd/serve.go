@@ -0,0 +1,360 @@
+package main
+
+import (
+	"CRUD_Go_Backend/internal/auth"
+	"CRUD_Go_Backend/internal/config"
+	"CRUD_Go_Backend/internal/handlers"
+	"CRUD_Go_Backend/internal/health"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/cors"
+	"CRUD_Go_Backend/internal/pkg/grading"
+	"CRUD_Go_Backend/internal/pkg/logger"
+	"CRUD_Go_Backend/internal/pkg/ratelimit"
+	"CRUD_Go_Backend/internal/pkg/security"
+	"CRUD_Go_Backend/internal/pkg/tracing"
+	"CRUD_Go_Backend/internal/repository"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	loader := config.NewLoader(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return err
+	}
+
+	logger.Setup(os.Stderr, cfg.Log)
+
+	var jwtVerifier *auth.JWTVerifier
+	if cfg.Auth.Enabled {
+		if jwtVerifier, err = auth.NewJWTVerifier(cfg.Auth); err != nil {
+			return fmt.Errorf("failed to set up authentication: %w", err)
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	shutdownTracing, err := tracing.Init(ctx, cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("Error flushing traces: %v", err)
+		}
+	}()
+
+	// Listen before the database is reachable, so that probes see "not ready" rather than a refused connection.
+	startupHandler := handlers.NewStartupHandler()
+
+	var current atomic.Value
+	current.Store(http.Handler(startupHandler))
+
+	server := &http.Server{
+		Addr: cfg.HTTP.Addr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			current.Load().(http.Handler).ServeHTTP(w, req) //nolint:forcetypeassert // only http.Handler is stored
+		}),
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+	}
+
+	listen := server.ListenAndServe
+
+	var redirectServer *http.Server
+
+	if cfg.HTTP.TLS.Enabled {
+		if server.TLSConfig, err = startTLS(ctx, cfg.HTTP.TLS); err != nil {
+			return fmt.Errorf("failed to set up TLS: %w", err)
+		}
+
+		listen = func() error { return server.ListenAndServeTLS("", "") }
+
+		if cfg.HTTP.TLS.RedirectAddr != "" {
+			redirectServer = newRedirectServer(cfg.HTTP)
+		}
+	}
+
+	serverErr := make(chan error, 2)
+
+	go func() {
+		if err := listen(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	if redirectServer != nil {
+		go func() {
+			if err := redirectServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErr <- fmt.Errorf("redirect listener: %w", err)
+			}
+		}()
+
+		defer shutdownServer(redirectServer, cfg.HTTP.ShutdownTimeout)
+	}
+
+	// Stop waiting for the database when a listener fails, for example because its address is in use.
+	startupCtx, cancelStartup := context.WithCancel(ctx)
+	listenerErr := make(chan error, 1)
+	watchDone := make(chan struct{})
+
+	go func() {
+		defer close(watchDone)
+
+		select {
+		case err := <-serverErr:
+			listenerErr <- err
+			cancelStartup()
+		case <-startupCtx.Done():
+		}
+	}()
+
+	database, checker, closeDatabase, err := startDatabase(startupCtx, cfg, startupHandler)
+	cancelStartup()
+	<-watchDone
+
+	select {
+	case listenErr := <-listenerErr:
+		if err == nil {
+			closeDatabase()
+		}
+
+		shutdownServer(server, cfg.HTTP.ShutdownTimeout)
+
+		return fmt.Errorf("server forced to shutdown: %w", listenErr)
+	default:
+	}
+
+	if err != nil {
+		shutdownServer(server, cfg.HTTP.ShutdownTimeout)
+		return err
+	}
+
+	defer closeDatabase()
+
+	studentStorage := repository.NewStudentStorage(database)
+	classInfoStorage := repository.NewClassInfoStorage(database)
+	teacherStorage := repository.NewTeacherStorage(database)
+	termStorage := repository.NewAcademicTermStorage(database)
+	gradeStorage := repository.NewGradeStorage(database)
+	classStorage := repository.NewClassStorage(database)
+	prerequisiteStorage := repository.NewPrerequisiteStorage(database)
+	auditStorage := repository.NewAuditStorage(database)
+	attendanceStorage := repository.NewAttendanceStorage(database)
+	roomStorage := repository.NewRoomStorage(database)
+	scheduleStorage := repository.NewScheduleStorage(database)
+	contactStorage := repository.NewContactStorage(database)
+	assignmentStorage := repository.NewAssignmentStorage(database)
+
+	routerOpts := []handlers.RouterOption{
+		handlers.WithTeachers(&teacherStorage),
+		handlers.WithTerms(&termStorage),
+		handlers.WithGrades(&gradeStorage, &classStorage, newGradingScales(cfg.Grading)),
+		handlers.WithPrerequisites(&prerequisiteStorage, &auditStorage),
+		handlers.WithAttendance(&attendanceStorage),
+		handlers.WithSchedule(&roomStorage, &scheduleStorage),
+		handlers.WithContacts(&contactStorage),
+		handlers.WithAssignments(&assignmentStorage),
+		handlers.WithMaxBodyBytes(cfg.HTTP.MaxBodyBytes),
+		handlers.WithSecurityHeaders(security.Options{
+			HSTSMaxAge:            cfg.Security.HSTSMaxAge,
+			ContentSecurityPolicy: cfg.Security.ContentSecurityPolicy,
+		}),
+	}
+
+	deprecations, err := cfg.API.Deprecations()
+	if err != nil {
+		return fmt.Errorf("invalid api config: %w", err)
+	}
+
+	for _, deprecation := range deprecations {
+		routerOpts = append(routerOpts, handlers.WithDeprecation(deprecation.Version, handlers.Deprecation{
+			Since:  deprecation.Since,
+			Sunset: deprecation.Sunset,
+			Link:   cfg.API.DeprecationLink,
+		}))
+	}
+
+	if cfg.CORS.Enabled {
+		routerOpts = append(routerOpts, handlers.WithCORS(cors.New(cors.Options{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			ExposedHeaders:   cfg.CORS.ExposedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           cfg.CORS.MaxAge,
+		})))
+	}
+
+	if cfg.Metrics.Enabled {
+		routerOpts = append(routerOpts, handlers.WithMetrics(cfg.Metrics.Path))
+	}
+
+	if cfg.Auth.Enabled {
+		apiKeyStorage := repository.NewAPIKeyStorage(database)
+		authenticator := auth.NewAuthenticator(jwtVerifier, &apiKeyStorage, cfg.Auth.PublicPaths)
+		routerOpts = append(routerOpts, handlers.WithAuthentication(authenticator.Middleware))
+
+		roleStorage := repository.NewRoleAssignmentStorage(database)
+		authorizer := auth.NewAuthorizer(&roleStorage, cfg.Auth.AdminSubjects)
+		routerOpts = append(routerOpts, handlers.WithAuthorization(authorizer, &roleStorage))
+	}
+
+	if cfg.RateLimit.Enabled {
+		ipLimit := ratelimit.Limit{RequestsPerSecond: cfg.RateLimit.IPRequestsPerSecond, Burst: cfg.RateLimit.IPBurst}
+		routerOpts = append(routerOpts,
+			handlers.WithIPRateLimit(ratelimit.NewPerIP(ipLimit).Middleware),
+			handlers.WithRateLimit(newRateLimiter(cfg.RateLimit).Middleware),
+		)
+	}
+
+	healthHandler := handlers.NewHealthHandler(checker, cfg.Health.DetailsToken)
+	router := handlers.NewRouter(&studentStorage, &classInfoStorage, healthHandler, cfg.HTTP.QueryParamKey, routerOpts...)
+	current.Store(http.Handler(router))
+	slog.Info("server ready", "addr", cfg.HTTP.Addr)
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("server forced to shutdown: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Printf("Graceful Shut down")
+
+	// Fail readiness first so that load balancers stop routing before connections are closed
+	checker.SetDraining()
+	time.Sleep(cfg.Health.DrainDelay)
+
+	shutdownServer(server, cfg.HTTP.ShutdownTimeout)
+
+	if cfg.Features.MigrateDownOnShutdown {
+		if err := connection.MigrationDownAndCloseSql(cfg.Database); err != nil {
+			log.Printf("Error during migration down and closing SQL: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// startDatabase connects to Postgres and brings the schema to the embedded version, retrying both
+// with backoff until database.connect_deadline. Each failed attempt is logged with its category.
+func startDatabase(
+	ctx context.Context,
+	cfg config.Config,
+	startupHandler *handlers.StartupHandler,
+) (*connection.Database, *health.Checker, func(), error) {
+	policy := connection.RetryPolicy{
+		Deadline:       cfg.Database.ConnectDeadline,
+		InitialBackoff: cfg.Database.ConnectInitialBackoff,
+		MaxBackoff:     cfg.Database.ConnectMaxBackoff,
+	}
+
+	notify := func(step string) func(connection.Attempt) {
+		return func(attempt connection.Attempt) {
+			startupHandler.SetReason(fmt.Sprintf("waiting for %s (%s)", step, attempt.Category))
+			slog.Warn("startup step failed, retrying",
+				"step", step,
+				"attempt", attempt.Number,
+				"reason", attempt.Category,
+				"retry_in", attempt.Wait.Round(time.Millisecond),
+				"error", attempt.Err,
+			)
+		}
+	}
+
+	startupHandler.SetReason("waiting for database")
+
+	database, err := connection.NewDBWithRetry(ctx, cfg.Database, policy, notify("database"))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to connect Database %w", err)
+	}
+
+	migrationCheck, closeMigrationCheck, err := connection.MigrationCheck(cfg.Database)
+	if err != nil {
+		database.GetPool(ctx).Close()
+		return nil, nil, nil, fmt.Errorf("failed to set up migration check: %w", err)
+	}
+
+	// Without migrate_on_startup another job owns the schema, so wait for it to reach the expected version.
+	migrate := migrationCheck.Run
+	if cfg.Features.MigrateOnStartup {
+		migrate = func(ctx context.Context) error { return connection.MigrationUp(ctx, cfg.Database) }
+	}
+
+	startupHandler.SetReason("waiting for migrations")
+
+	closeDatabase := func() {
+		if err := closeMigrationCheck(); err != nil {
+			log.Printf("Error closing migration check connection: %v", err)
+		}
+
+		database.GetPool(context.Background()).Close()
+	}
+
+	if err := connection.Retry(ctx, policy, migrate, notify("migrations")); err != nil {
+		closeDatabase()
+		return nil, nil, nil, fmt.Errorf("failed to %w", err)
+	}
+
+	return database, health.NewChecker(connection.PingCheck(database), migrationCheck), closeDatabase, nil
+}
+
+// newRateLimiter converts the validated rate limit configuration into a limiter.
+func newRateLimiter(cfg config.RateLimitConfig) *ratelimit.Limiter {
+	routeLimits, _ := cfg.RouteLimits() // validated by the config loader
+
+	routes := make([]ratelimit.RouteLimit, 0, len(routeLimits))
+	for _, route := range routeLimits {
+		routes = append(routes, ratelimit.RouteLimit{
+			Method: route.Method,
+			Path:   route.Path,
+			Limit:  ratelimit.Limit{RequestsPerSecond: route.RequestsPerSecond, Burst: route.Burst},
+		})
+	}
+
+	return ratelimit.New(ratelimit.Limit{RequestsPerSecond: cfg.RequestsPerSecond, Burst: cfg.Burst}, routes)
+}
+
+// newGradingScales converts the validated grading configuration into grading scales.
+func newGradingScales(cfg config.GradingConfig) grading.Scales {
+	configured, _ := cfg.GradingScales() // validated by the config loader
+
+	scales := make([]grading.Scale, 0, len(configured))
+	for _, scale := range configured {
+		bands := make([]grading.Band, 0, len(scale.Bands))
+		for _, band := range scale.Bands {
+			bands = append(bands, grading.Band{Letter: band.Letter, MinScore: band.MinScore, Points: band.Points})
+		}
+
+		scales = append(scales, grading.Scale{Name: scale.Name, Bands: bands})
+	}
+
+	return grading.NewScales(cfg.DefaultScale, scales...)
+}
+
+func shutdownServer(server *http.Server, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Error during server shutdown: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"CRUD_Go_Backend/internal/config"
+	"CRUD_Go_Backend/internal/pkg/tlsreload"
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	config.ClientAuthNone:    tls.NoClientCert,
+	config.ClientAuthRequest: tls.VerifyClientCertIfGiven,
+	config.ClientAuthRequire: tls.RequireAndVerifyClientCert,
+}
+
+// startTLS loads the certificate and keeps it current: on SIGHUP, and when the files change
+// if tls.reload_interval is set. Both stop with ctx.
+func startTLS(ctx context.Context, cfg config.TLSConfig) (*tls.Config, error) {
+	reloader, err := tlsreload.New(tlsreload.Options{
+		CertFile:     cfg.CertFile,
+		KeyFile:      cfg.KeyFile,
+		ClientCAFile: cfg.ClientCAFile,
+		ClientAuth:   clientAuthTypes[cfg.ClientAuth],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.ReloadInterval > 0 {
+		go reloader.Watch(ctx, cfg.ReloadInterval)
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(hangup)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hangup:
+				if err := reloader.Reload(); err != nil {
+					slog.Error("TLS reload on SIGHUP failed, keeping the previous certificate", "error", err)
+					continue
+				}
+
+				slog.Info("TLS certificate reloaded on SIGHUP", "cert_file", cfg.CertFile)
+			}
+		}
+	}()
+
+	return reloader.TLSConfig(), nil
+}
+
+// newRedirectServer answers plain HTTP on tls.redirect_addr with a permanent redirect to the HTTPS listener.
+func newRedirectServer(cfg config.HTTPConfig) *http.Server {
+	_, httpsPort, _ := net.SplitHostPort(cfg.Addr)
+
+	return &http.Server{
+		Addr: cfg.TLS.RedirectAddr,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			host, _, err := net.SplitHostPort(req.Host)
+			if err != nil {
+				host = req.Host
+			}
+
+			if httpsPort != "" && httpsPort != "443" {
+				host = net.JoinHostPort(host, httpsPort)
+			}
+
+			target := "https://" + host + req.URL.RequestURI()
+			http.Redirect(w, req, target, http.StatusPermanentRedirect)
+		}),
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+	}
+}
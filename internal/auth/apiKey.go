@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// APIKeyPrefix marks bearer tokens that are API keys rather than JWTs.
+const APIKeyPrefix = "crud_"
+
+const (
+	apiKeyIDBytes     = 6
+	apiKeySecretBytes = 32
+)
+
+// GenerateAPIKey returns a new random key and its public prefix, which is safe to store and display.
+// Only the hash of the full key is persisted.
+func GenerateAPIKey() (key string, prefix string, err error) {
+	id := make([]byte, apiKeyIDBytes)
+	secret := make([]byte, apiKeySecretBytes)
+
+	if _, err := rand.Read(id); err != nil {
+		return "", "", fmt.Errorf("could not generate api key: %w", err)
+	}
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("could not generate api key: %w", err)
+	}
+
+	prefix = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(id)
+
+	return prefix + "_" + base64.RawURLEncoding.EncodeToString(secret), prefix, nil
+}
+
+// HashAPIKey returns the SHA-256 digest under which a key is stored.
+// Keys carry 256 bits of entropy, so a slow password hash is not needed.
+func HashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// IsAPIKey reports whether a bearer token looks like an API key.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
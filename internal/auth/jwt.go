@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"CRUD_Go_Backend/internal/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// ErrJWTDisabled is returned when a JWT is presented but no verification key is configured.
+	ErrJWTDisabled = errors.New("jwt authentication is not configured")
+	ErrUnknownKey  = errors.New("unknown jwt key id")
+)
+
+// JWTVerifier validates HS256 or RS256 tokens.
+type JWTVerifier struct {
+	hmacSecret []byte
+	rsaKey     *rsa.PublicKey
+	jwks       map[string]*rsa.PublicKey
+	parser     *jwt.Parser
+}
+
+// NewJWTVerifier loads the keys configured in cfg. It returns nil when no key source is configured.
+func NewJWTVerifier(cfg config.AuthConfig) (*JWTVerifier, error) {
+	verifier := &JWTVerifier{}
+
+	var methods []string
+
+	if cfg.JWTSecret != "" {
+		verifier.hmacSecret = []byte(cfg.JWTSecret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if cfg.JWTPublicKeyFile != "" {
+		pemBytes, err := os.ReadFile(cfg.JWTPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read jwt public key: %w", err)
+		}
+
+		verifier.rsaKey, err = jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse jwt public key: %w", err)
+		}
+	}
+
+	if cfg.JWKSFile != "" {
+		var err error
+
+		verifier.jwks, err = loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if verifier.rsaKey != nil || verifier.jwks != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	if len(methods) == 0 {
+		return nil, nil //nolint:nilnil // no verifier means JWTs are not accepted
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if cfg.JWTIssuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.JWTIssuer))
+	}
+
+	if cfg.JWTAudience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.JWTAudience))
+	}
+
+	verifier.parser = jwt.NewParser(opts...)
+
+	return verifier, nil
+}
+
+// Verify checks the signature and registered claims and returns the token claims.
+func (v *JWTVerifier) Verify(token string) (jwt.MapClaims, error) {
+	if v == nil {
+		return nil, ErrJWTDisabled
+	}
+
+	claims := jwt.MapClaims{}
+
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (v *JWTVerifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.hmacSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		if v.rsaKey != nil {
+			return v.rsaKey, nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+
+		key, ok := v.jwks[kid]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+		}
+
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// loadJWKS reads the RSA signing keys of a JSON Web Key Set, indexed by key id.
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read jwks file: %w", err)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := json.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("could not parse jwks file: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %q: invalid modulus: %w", jwk.Kid, err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %q: invalid exponent: %w", jwk.Kid, err)
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("jwks file %s contains no RSA signing keys", path)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+)
+
+// APIKeyHeader is an alternative to "Authorization: Bearer <key>" for API keys.
+const APIKeyHeader = "X-API-Key"
+
+// ErrInvalidCredentials wraps every failure caused by the presented credentials, as opposed to lookup failures.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Authenticator resolves the caller of each request from a JWT or an API key.
+type Authenticator struct {
+	jwtVerifier *JWTVerifier
+	apiKeys     repository.APIKeyPgRepo
+	publicPaths map[string]bool
+}
+
+// NewAuthenticator creates a new Authenticator. jwtVerifier may be nil to accept API keys only.
+func NewAuthenticator(jwtVerifier *JWTVerifier, apiKeys repository.APIKeyPgRepo, publicPaths []string) *Authenticator {
+	paths := make(map[string]bool, len(publicPaths))
+	for _, path := range publicPaths {
+		paths[path] = true
+	}
+
+	return &Authenticator{
+		jwtVerifier: jwtVerifier,
+		apiKeys:     apiKeys,
+		publicPaths: paths,
+	}
+}
+
+// Middleware rejects unauthenticated requests to non-public paths with 401 and
+// stores the Principal on the context of authenticated ones.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if a.publicPaths[req.URL.Path] {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		token := bearerToken(req)
+		if token == "" {
+			unauthorized(w, req, "missing credentials: send Authorization: Bearer <token> or "+APIKeyHeader)
+			return
+		}
+
+		principal, err := a.authenticate(req, token)
+		if err != nil {
+			if errors.Is(err, ErrInvalidCredentials) {
+				unauthorized(w, req, err.Error())
+				return
+			}
+
+			problem.Write(w, req, http.StatusInternalServerError, err.Error())
+
+			return
+		}
+
+		next.ServeHTTP(w, req.WithContext(WithPrincipal(req.Context(), principal)))
+	})
+}
+
+func (a *Authenticator) authenticate(req *http.Request, token string) (Principal, error) {
+	if IsAPIKey(token) {
+		apiKey, err := a.apiKeys.GetActiveByHash(req.Context(), HashAPIKey(token))
+		if err != nil {
+			if errors.Is(err, pkgErrors.ErrNotFound) {
+				return Principal{}, fmt.Errorf("%w: unknown or revoked api key", ErrInvalidCredentials)
+			}
+
+			return Principal{}, fmt.Errorf("could not verify api key: %w", err)
+		}
+
+		return Principal{
+			Subject: fmt.Sprintf("apikey:%d", apiKey.ID),
+			Name:    apiKey.Name,
+			Method:  MethodAPIKey,
+		}, nil
+	}
+
+	claims, err := a.jwtVerifier.Verify(token)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return Principal{}, fmt.Errorf("%w: token has no sub claim", ErrInvalidCredentials)
+	}
+
+	name, _ := claims["name"].(string)
+
+	return Principal{Subject: subject, Name: name, Method: MethodJWT}, nil
+}
+
+func bearerToken(req *http.Request) string {
+	if key := req.Header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+
+	scheme, token, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
+
+func unauthorized(w http.ResponseWriter, req *http.Request, detail string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="crud-go-backend"`)
+	problem.Write(w, req, http.StatusUnauthorized, detail)
+}
@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"CRUD_Go_Backend/internal/config"
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testSecret = "test-secret"
+
+func signHS256(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	return token
+}
+
+func writeJWKS(t *testing.T, kid string, key *rsa.PublicKey) string {
+	t.Helper()
+
+	set := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+
+	content, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	return path
+}
+
+func TestAuthenticator_Middleware(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	rsToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "teacher-7",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	rsToken.Header["kid"] = "key-1"
+	rsSigned, err := rsToken.SignedString(rsaKey)
+	require.NoError(t, err)
+
+	validAPIKey, _, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	revokedAPIKey, _, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		description       string
+		path              string
+		headers           map[string]string
+		expectedCode      int
+		expectedPrincipal Principal
+	}{
+		{
+			description:  "Public path",
+			path:         "/healthz",
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "Missing credentials",
+			path:         "/student/1",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			description: "Valid HS256 token",
+			path:        "/student/1",
+			headers: map[string]string{"Authorization": "Bearer " + signHS256(t, jwt.MapClaims{
+				"sub":  "student-1",
+				"name": "Alice",
+				"exp":  time.Now().Add(time.Hour).Unix(),
+			})},
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: Principal{Subject: "student-1", Name: "Alice", Method: MethodJWT},
+		},
+		{
+			description: "Expired HS256 token",
+			path:        "/student/1",
+			headers: map[string]string{"Authorization": "Bearer " + signHS256(t, jwt.MapClaims{
+				"sub": "student-1",
+				"exp": time.Now().Add(-time.Hour).Unix(),
+			})},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			description:       "Valid RS256 token from JWKS",
+			path:              "/student/1",
+			headers:           map[string]string{"Authorization": "Bearer " + rsSigned},
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: Principal{Subject: "teacher-7", Method: MethodJWT},
+		},
+		{
+			description:       "Valid API key",
+			path:              "/class_info/1",
+			headers:           map[string]string{APIKeyHeader: validAPIKey},
+			expectedCode:      http.StatusOK,
+			expectedPrincipal: Principal{Subject: "apikey:3", Name: "reporting", Method: MethodAPIKey},
+		},
+		{
+			description:  "Revoked API key",
+			path:         "/class_info/1",
+			headers:      map[string]string{"Authorization": "Bearer " + revokedAPIKey},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockAPIKeyPgRepo(ctrl)
+			mockRepo.EXPECT().GetActiveByHash(gomock.Any(), HashAPIKey(validAPIKey)).
+				Return(models.APIKey{ID: 3, Name: "reporting"}, nil).AnyTimes()
+			mockRepo.EXPECT().GetActiveByHash(gomock.Any(), HashAPIKey(revokedAPIKey)).
+				Return(models.APIKey{}, pkgErrors.ErrNotFound).AnyTimes()
+
+			verifier, err := NewJWTVerifier(config.AuthConfig{
+				JWTSecret: testSecret,
+				JWKSFile:  writeJWKS(t, "key-1", &rsaKey.PublicKey),
+			})
+			require.NoError(t, err)
+
+			authenticator := NewAuthenticator(verifier, mockRepo, []string{"/healthz"})
+
+			var principal Principal
+
+			handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				principal, _ = PrincipalFromContext(req.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+
+			rr := httptest.NewRecorder()
+			// act
+			handler.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			assert.Equal(t, tc.expectedPrincipal, principal)
+
+			if rr.Code == http.StatusUnauthorized {
+				assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+				assert.NotEmpty(t, rr.Header().Get("WWW-Authenticate"))
+
+				var body map[string]interface{}
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				assert.Equal(t, float64(http.StatusUnauthorized), body["status"])
+				assert.Equal(t, tc.path, body["instance"])
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package auth authenticates requests with JWT bearer tokens or API keys
+// and carries the resulting Principal on the request context.
+package auth
+
+import "context"
+
+// Authentication methods recorded on a Principal.
+const (
+	MethodJWT    = "jwt"
+	MethodAPIKey = "api_key"
+)
+
+// Principal is the authenticated caller.
+type Principal struct {
+	// Subject identifies the caller: the JWT "sub" claim, or "apikey:<id>" for API keys.
+	Subject string
+	// Name is a human readable label, the key name for API keys.
+	Name   string
+	Method string
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored by the authentication middleware.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+
+	"github.com/gorilla/mux"
+)
+
+// Roles that can be assigned to a subject.
+const (
+	RoleAdmin    = "admin"
+	RoleTeacher  = "teacher"
+	RoleStudent  = "student"
+	RoleReadOnly = "read_only"
+)
+
+// Permission names an action on a resource, such as "student:read".
+type Permission string
+
+const (
+	PermStudentRead  Permission = "student:read"
+	PermStudentWrite Permission = "student:write"
+	PermClassRead    Permission = "class:read"
+	PermClassWrite   Permission = "class:write"
+	PermTeacherRead  Permission = "teacher:read"
+	PermTeacherWrite Permission = "teacher:write"
+	PermTermRead     Permission = "term:read"
+	PermTermWrite    Permission = "term:write"
+	PermGradeRead    Permission = "grade:read"
+	PermGradeWrite   Permission = "grade:write"
+	PermRoleManage   Permission = "role:manage"
+	// PermAttendanceRead covers class sessions, their attendance and attendance rate reports.
+	PermAttendanceRead  Permission = "attendance:read"
+	PermAttendanceWrite Permission = "attendance:write"
+	// PermContactRead covers the personal data of the parents and guardians of students.
+	PermContactRead  Permission = "contact:read"
+	PermContactWrite Permission = "contact:write"
+	// PermPrerequisiteOverride allows enrolling students who miss the prerequisites of a class.
+	PermPrerequisiteOverride Permission = "prerequisite:override"
+	PermAuditRead            Permission = "audit:read"
+)
+
+// Scope limits which resources a granted permission applies to.
+type Scope int
+
+const (
+	// ScopeOwn grants the permission only on the student the assignment is bound to.
+	ScopeOwn Scope = iota + 1
+	// ScopeAll grants the permission on every resource.
+	ScopeAll
+)
+
+var rolePermissions = map[string]map[Permission]Scope{
+	RoleAdmin: {
+		PermStudentRead:          ScopeAll,
+		PermStudentWrite:         ScopeAll,
+		PermClassRead:            ScopeAll,
+		PermClassWrite:           ScopeAll,
+		PermTeacherRead:          ScopeAll,
+		PermTeacherWrite:         ScopeAll,
+		PermTermRead:             ScopeAll,
+		PermTermWrite:            ScopeAll,
+		PermGradeRead:            ScopeAll,
+		PermGradeWrite:           ScopeAll,
+		PermRoleManage:           ScopeAll,
+		PermAttendanceRead:       ScopeAll,
+		PermAttendanceWrite:      ScopeAll,
+		PermContactRead:          ScopeAll,
+		PermContactWrite:         ScopeAll,
+		PermPrerequisiteOverride: ScopeAll,
+		PermAuditRead:            ScopeAll,
+	},
+	RoleTeacher: {
+		PermStudentRead:     ScopeAll,
+		PermStudentWrite:    ScopeAll,
+		PermClassRead:       ScopeAll,
+		PermClassWrite:      ScopeAll,
+		PermTeacherRead:     ScopeAll,
+		PermTermRead:        ScopeAll,
+		PermGradeRead:       ScopeAll,
+		PermGradeWrite:      ScopeAll,
+		PermAttendanceRead:  ScopeAll,
+		PermAttendanceWrite: ScopeAll,
+		PermContactRead:     ScopeAll,
+	},
+	RoleStudent: {
+		PermStudentRead:    ScopeOwn,
+		PermClassRead:      ScopeOwn,
+		PermTermRead:       ScopeAll,
+		PermGradeRead:      ScopeOwn,
+		PermAttendanceRead: ScopeOwn,
+		PermContactRead:    ScopeOwn,
+	},
+	RoleReadOnly: {
+		PermStudentRead:    ScopeAll,
+		PermClassRead:      ScopeAll,
+		PermTeacherRead:    ScopeAll,
+		PermTermRead:       ScopeAll,
+		PermGradeRead:      ScopeAll,
+		PermAttendanceRead: ScopeAll,
+	},
+}
+
+// IsValidRole reports whether role is one of the Role* constants.
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// OwnerFunc extracts the student a request is about. ok is false when the request does not target a single student.
+type OwnerFunc func(req *http.Request) (studentID int64, ok bool)
+
+// StudentFromPath reads the owning student from the mux path variable key.
+func StudentFromPath(key string) OwnerFunc {
+	return func(req *http.Request) (int64, bool) {
+		id, err := strconv.ParseInt(mux.Vars(req)[key], 10, 64)
+		return id, err == nil
+	}
+}
+
+// ErrForbidden is returned when the principal lacks the permission required by a route.
+var ErrForbidden = errors.New("forbidden")
+
+// Authorizer enforces role permissions using the role assignments stored in the database.
+type Authorizer struct {
+	assignments   repository.RoleAssignmentPgRepo
+	adminSubjects map[string]bool
+}
+
+// NewAuthorizer creates a new Authorizer. Subjects in adminSubjects are admins without an assignment,
+// which bootstraps the first admin of a fresh database.
+func NewAuthorizer(assignments repository.RoleAssignmentPgRepo, adminSubjects []string) *Authorizer {
+	subjects := make(map[string]bool, len(adminSubjects))
+	for _, subject := range adminSubjects {
+		subjects[subject] = true
+	}
+
+	return &Authorizer{
+		assignments:   assignments,
+		adminSubjects: subjects,
+	}
+}
+
+// Require returns a middleware that lets a request through only when its principal holds permission.
+// owner may be nil for routes that are not about a single student; ScopeOwn grants never match those.
+func (a *Authorizer) Require(permission Permission, owner OwnerFunc) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			principal, ok := PrincipalFromContext(req.Context())
+			if !ok {
+				unauthorized(w, req, "missing credentials")
+				return
+			}
+
+			if err := a.authorize(req, principal, permission, owner); err != nil {
+				if errors.Is(err, ErrForbidden) {
+					problem.Write(w, req, http.StatusForbidden, err.Error())
+					return
+				}
+
+				problem.Write(w, req, http.StatusInternalServerError, err.Error())
+
+				return
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+func (a *Authorizer) authorize(req *http.Request, principal Principal, permission Permission, owner OwnerFunc) error {
+	if a.adminSubjects[principal.Subject] {
+		return nil
+	}
+
+	assignments, err := a.assignments.GetBySubject(req.Context(), principal.Subject)
+	if err != nil {
+		return fmt.Errorf("could not load role assignments: %w", err)
+	}
+
+	for _, assignment := range assignments {
+		switch rolePermissions[assignment.Role][permission] {
+		case ScopeAll:
+			return nil
+		case ScopeOwn:
+			if owner == nil || assignment.StudentID == nil {
+				continue
+			}
+
+			if studentID, ok := owner(req); ok && studentID == *assignment.StudentID {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %s is not granted %s on this resource", ErrForbidden, principal.Subject, permission)
+}
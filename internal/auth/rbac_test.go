@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthorizer_Require(t *testing.T) {
+	t.Parallel()
+
+	ownStudentID := int64(7)
+
+	tests := []struct {
+		description  string
+		subject      string
+		assignments  []models.RoleAssignment
+		lookupErr    error
+		permission   Permission
+		pathID       string
+		expectedCode int
+	}{
+		{
+			description:  "teacher may write students",
+			subject:      "alice",
+			assignments:  []models.RoleAssignment{{Subject: "alice", Role: RoleTeacher}},
+			permission:   PermStudentWrite,
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "read only may not write students",
+			subject:      "alice",
+			assignments:  []models.RoleAssignment{{Subject: "alice", Role: RoleReadOnly}},
+			permission:   PermStudentWrite,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:  "teacher may not manage roles",
+			subject:      "alice",
+			assignments:  []models.RoleAssignment{{Subject: "alice", Role: RoleTeacher}},
+			permission:   PermRoleManage,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:  "teacher may not override prerequisites",
+			subject:      "alice",
+			assignments:  []models.RoleAssignment{{Subject: "alice", Role: RoleTeacher}},
+			permission:   PermPrerequisiteOverride,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:  "admin may override prerequisites",
+			subject:      "alice",
+			assignments:  []models.RoleAssignment{{Subject: "alice", Role: RoleAdmin}},
+			permission:   PermPrerequisiteOverride,
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "student reads own attendance",
+			subject:      "bob",
+			assignments:  []models.RoleAssignment{{Subject: "bob", Role: RoleStudent, StudentID: &ownStudentID}},
+			permission:   PermAttendanceRead,
+			pathID:       "7",
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "read only may not mark attendance",
+			subject:      "alice",
+			assignments:  []models.RoleAssignment{{Subject: "alice", Role: RoleReadOnly}},
+			permission:   PermAttendanceWrite,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:  "read only may not read contacts",
+			subject:      "alice",
+			assignments:  []models.RoleAssignment{{Subject: "alice", Role: RoleReadOnly}},
+			permission:   PermContactRead,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:  "student reads own contacts",
+			subject:      "bob",
+			assignments:  []models.RoleAssignment{{Subject: "bob", Role: RoleStudent, StudentID: &ownStudentID}},
+			permission:   PermContactRead,
+			pathID:       "7",
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "student reads own record",
+			subject:      "bob",
+			assignments:  []models.RoleAssignment{{Subject: "bob", Role: RoleStudent, StudentID: &ownStudentID}},
+			permission:   PermStudentRead,
+			pathID:       "7",
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "student may not read other records",
+			subject:      "bob",
+			assignments:  []models.RoleAssignment{{Subject: "bob", Role: RoleStudent, StudentID: &ownStudentID}},
+			permission:   PermClassRead,
+			pathID:       "8",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:  "no assignments",
+			subject:      "carol",
+			permission:   PermStudentRead,
+			pathID:       "7",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:  "assignment lookup fails",
+			subject:      "carol",
+			lookupErr:    errors.New("connection reset"),
+			permission:   PermStudentRead,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// arrange
+			mockRepo := mock_repository.NewMockRoleAssignmentPgRepo(ctrl)
+			mockRepo.EXPECT().GetBySubject(gomock.Any(), tc.subject).Return(tc.assignments, tc.lookupErr)
+			authorizer := NewAuthorizer(mockRepo, nil)
+
+			handler := authorizer.Require(tc.permission, StudentFromPath("id"))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/student/"+tc.pathID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.pathID})
+			req = req.WithContext(WithPrincipal(req.Context(), Principal{Subject: tc.subject, Method: MethodJWT}))
+			rr := httptest.NewRecorder()
+			// act
+			handler.ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestAuthorizer_AdminSubjects(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// arrange
+	authorizer := NewAuthorizer(mock_repository.NewMockRoleAssignmentPgRepo(ctrl), []string{"root"})
+	handler := authorizer.Require(PermRoleManage, nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/roles", nil)
+	req = req.WithContext(WithPrincipal(req.Context(), Principal{Subject: "root", Method: MethodJWT}))
+	rr := httptest.NewRecorder()
+	// act
+	handler.ServeHTTP(rr, req)
+	// assert
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
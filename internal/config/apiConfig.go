@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// apiDateLayout is the layout of the dates in APIConfig.Deprecated.
+const apiDateLayout = "2006-01-02"
+
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// APIConfig controls the lifecycle of API versions.
+type APIConfig struct {
+	// Deprecated lists the versions that answer with Deprecation and Sunset headers, each as
+	// "<version>=<deprecation date>[/<sunset date>]", for example "v1=2026-10-19/2027-06-30".
+	Deprecated []string `yaml:"deprecated" toml:"deprecated" env:"API_DEPRECATED"`
+	// DeprecationLink points clients at migration notes, sent as Link: <url>; rel="deprecation".
+	DeprecationLink string `yaml:"deprecation_link" toml:"deprecation_link" env:"API_DEPRECATION_LINK"`
+}
+
+// VersionDeprecation is a parsed entry of APIConfig.Deprecated. Sunset is zero when no removal date is planned.
+type VersionDeprecation struct {
+	Version string
+	Since   time.Time
+	Sunset  time.Time
+}
+
+// Deprecations parses Deprecated.
+func (c APIConfig) Deprecations() ([]VersionDeprecation, error) {
+	deprecations := make([]VersionDeprecation, 0, len(c.Deprecated))
+
+	for _, entry := range c.Deprecated {
+		deprecation, err := parseVersionDeprecation(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		deprecations = append(deprecations, deprecation)
+	}
+
+	return deprecations, nil
+}
+
+func parseVersionDeprecation(entry string) (VersionDeprecation, error) {
+	invalid := fmt.Errorf("%q must look like \"v1=2026-10-19/2027-06-30\"", entry)
+
+	version, dates, ok := strings.Cut(strings.TrimSpace(entry), "=")
+	if !ok || !apiVersionPattern.MatchString(version) {
+		return VersionDeprecation{}, invalid
+	}
+
+	rawSince, rawSunset, hasSunset := strings.Cut(dates, "/")
+
+	since, err := time.Parse(apiDateLayout, rawSince)
+	if err != nil {
+		return VersionDeprecation{}, invalid
+	}
+
+	deprecation := VersionDeprecation{Version: version, Since: since}
+
+	if hasSunset {
+		if deprecation.Sunset, err = time.Parse(apiDateLayout, rawSunset); err != nil {
+			return VersionDeprecation{}, invalid
+		}
+
+		if !deprecation.Sunset.After(since) {
+			return VersionDeprecation{}, fmt.Errorf("%q: sunset must be after the deprecation date", entry)
+		}
+	}
+
+	return deprecation, nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIConfig_Deprecations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		entry    string
+		expected VersionDeprecation
+		wantErr  bool
+	}{
+		{
+			entry: "v1=2026-10-19/2027-06-30",
+			expected: VersionDeprecation{
+				Version: "v1",
+				Since:   time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+				Sunset:  time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			entry:    "v2=2027-01-01",
+			expected: VersionDeprecation{Version: "v2", Since: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{entry: "v1", wantErr: true},
+		{entry: "one=2026-10-19", wantErr: true},
+		{entry: "v1=19.10.2026", wantErr: true},
+		{entry: "v1=2026-10-19/2026-01-01", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.entry, func(t *testing.T) {
+			t.Parallel()
+
+			deprecations, err := APIConfig{Deprecated: []string{tc.entry}}.Deprecations()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []VersionDeprecation{tc.expected}, deprecations)
+		})
+	}
+}
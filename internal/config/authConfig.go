@@ -0,0 +1,19 @@
+package config
+
+// AuthConfig configures request authentication. API keys are always accepted when auth is enabled;
+// JWTs are accepted when one of the key sources is configured.
+type AuthConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled" env:"AUTH_ENABLED"`
+	// JWTSecret is the shared HS256 key.
+	JWTSecret string `yaml:"jwt_secret" toml:"jwt_secret" env:"AUTH_JWT_SECRET" secret:"true"`
+	// JWTPublicKeyFile is a PEM encoded RSA public key for RS256.
+	JWTPublicKeyFile string `yaml:"jwt_public_key_file" toml:"jwt_public_key_file" env:"AUTH_JWT_PUBLIC_KEY_FILE"`
+	// JWKSFile is a local JSON Web Key Set with RS256 keys selected by the token "kid".
+	JWKSFile    string   `yaml:"jwks_file" toml:"jwks_file" env:"AUTH_JWKS_FILE"`
+	JWTIssuer   string   `yaml:"jwt_issuer" toml:"jwt_issuer" env:"AUTH_JWT_ISSUER"`
+	JWTAudience string   `yaml:"jwt_audience" toml:"jwt_audience" env:"AUTH_JWT_AUDIENCE"`
+	PublicPaths []string `yaml:"public_paths" toml:"public_paths" env:"AUTH_PUBLIC_PATHS"`
+	// AdminSubjects are treated as admins regardless of the stored role assignments,
+	// so that a fresh database can be bootstrapped through the /admin/roles endpoints.
+	AdminSubjects []string `yaml:"admin_subjects" toml:"admin_subjects" env:"AUTH_ADMIN_SUBJECTS"`
+}
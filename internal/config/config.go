@@ -0,0 +1,138 @@
+package config
+
+import "time"
+
+// Config is the root configuration of the service. Values are layered as
+// defaults, then the config file, then environment variables, then command line flags.
+//
+// Every leaf field has a dotted key derived from its yaml tags (for example "database.max_conns"),
+// which is also the name of its command line flag. The env tag names the environment variable.
+type Config struct {
+	HTTP      HTTPConfig      `yaml:"http" toml:"http"`
+	API       APIConfig       `yaml:"api" toml:"api"`
+	Database  DatabaseConfig  `yaml:"database" toml:"database"`
+	Log       LogConfig       `yaml:"log" toml:"log"`
+	Metrics   MetricsConfig   `yaml:"metrics" toml:"metrics"`
+	Tracing   TracingConfig   `yaml:"tracing" toml:"tracing"`
+	Health    HealthConfig    `yaml:"health" toml:"health"`
+	Auth      AuthConfig      `yaml:"auth" toml:"auth"`
+	RateLimit RateLimitConfig `yaml:"rate_limit" toml:"rate_limit"`
+	CORS      CORSConfig      `yaml:"cors" toml:"cors"`
+	Security  SecurityConfig  `yaml:"security" toml:"security"`
+	Grading   GradingConfig   `yaml:"grading" toml:"grading"`
+	Features  FeatureFlags    `yaml:"features" toml:"features"`
+}
+
+type HTTPConfig struct {
+	Addr              string        `yaml:"addr" toml:"addr" env:"PORT"`
+	QueryParamKey     string        `yaml:"query_param_key" toml:"query_param_key" env:"QUERY_PARAM_KEY"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" toml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT"`
+	// MaxBodyBytes caps request bodies; larger requests get 413.
+	MaxBodyBytes int64     `yaml:"max_body_bytes" toml:"max_body_bytes" env:"HTTP_MAX_BODY_BYTES"`
+	TLS          TLSConfig `yaml:"tls" toml:"tls"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT"`
+}
+
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled" env:"METRICS_ENABLED"`
+	Path    string `yaml:"path" toml:"path" env:"METRICS_PATH"`
+}
+
+type HealthConfig struct {
+	DetailsToken string        `yaml:"details_token" toml:"details_token" env:"HEALTH_DETAILS_TOKEN" secret:"true"`
+	DrainDelay   time.Duration `yaml:"drain_delay" toml:"drain_delay" env:"HEALTH_DRAIN_DELAY"`
+}
+
+// FeatureFlags toggle behaviour that differs between deployments.
+type FeatureFlags struct {
+	MigrateOnStartup bool `yaml:"migrate_on_startup" toml:"migrate_on_startup" env:"FEATURE_MIGRATE_ON_STARTUP"`
+	// MigrateDownOnShutdown rolls back every migration, dropping all tables and their data, on graceful
+	// shutdown. It is meant for throwaway development databases only.
+	MigrateDownOnShutdown bool `yaml:"migrate_down_on_shutdown" toml:"migrate_down_on_shutdown" env:"FEATURE_MIGRATE_DOWN_ON_SHUTDOWN"`
+}
+
+// Default returns the configuration used when nothing overrides it.
+func Default() Config {
+	return Config{
+		HTTP: HTTPConfig{
+			Addr:              ":9000",
+			QueryParamKey:     "id",
+			ReadHeaderTimeout: 10 * time.Second,
+			ShutdownTimeout:   15 * time.Second,
+			MaxBodyBytes:      1 << 20,
+			TLS: TLSConfig{
+				ClientAuth:     ClientAuthNone,
+				ReloadInterval: time.Minute,
+			},
+		},
+		Database: DatabaseConfig{
+			Port:              5432,
+			SSLMode:           "disable",
+			ApplicationName:   "crud-go-backend",
+			StatementTimeout:  30 * time.Second,
+			MaxConns:          10,
+			MinConns:          0,
+			MaxConnLifetime:   time.Hour,
+			MaxConnIdleTime:   30 * time.Minute,
+			HealthCheckPeriod: time.Minute,
+
+			ConnectDeadline:       time.Minute,
+			ConnectInitialBackoff: 500 * time.Millisecond,
+			ConnectMaxBackoff:     10 * time.Second,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			ServiceName: "crud-go-backend",
+		},
+		Health: HealthConfig{
+			DrainDelay: 5 * time.Second,
+		},
+		Auth: AuthConfig{
+			Enabled:     true,
+			PublicPaths: []string{"/", "/healthz", "/readyz", "/health/details", "/metrics"},
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:             true,
+			RequestsPerSecond:   10,
+			Burst:               20,
+			IPRequestsPerSecond: 20,
+			IPBurst:             40,
+		},
+		CORS: CORSConfig{
+			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+			AllowedHeaders: []string{"Authorization", "Content-Type", "X-API-Key"},
+			ExposedHeaders: []string{
+				"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After", "Deprecation", "Sunset", "Link",
+			},
+			MaxAge: 10 * time.Minute,
+		},
+		Security: SecurityConfig{
+			HSTSMaxAge:            365 * 24 * time.Hour,
+			ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+		},
+		Grading: GradingConfig{
+			Scales: []string{
+				"standard=A:93:4.0 A-:90:3.7 B+:87:3.3 B:83:3.0 B-:80:2.7 C+:77:2.3 C:73:2.0 C-:70:1.7 D+:67:1.3 D:60:1.0 F:0:0",
+				"simple=A:90:4 B:80:3 C:70:2 D:60:1 F:0:0",
+			},
+			DefaultScale: "standard",
+		},
+		Features: FeatureFlags{
+			MigrateOnStartup:      true,
+			MigrateDownOnShutdown: false,
+		},
+	}
+}
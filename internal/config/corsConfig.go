@@ -0,0 +1,22 @@
+package config
+
+import "time"
+
+// CORSConfig configures cross-origin requests from browsers.
+type CORSConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled" env:"CORS_ENABLED"`
+	// AllowedOrigins are exact origins, "*", or patterns such as "https://*.example.com".
+	AllowedOrigins   []string      `yaml:"allowed_origins" toml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods   []string      `yaml:"allowed_methods" toml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" toml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" toml:"exposed_headers" env:"CORS_EXPOSED_HEADERS"`
+	AllowCredentials bool          `yaml:"allow_credentials" toml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+	MaxAge           time.Duration `yaml:"max_age" toml:"max_age" env:"CORS_MAX_AGE"`
+}
+
+// SecurityConfig configures the security headers sent with every response.
+type SecurityConfig struct {
+	// HSTSMaxAge is sent in Strict-Transport-Security on TLS connections; zero disables the header.
+	HSTSMaxAge            time.Duration `yaml:"hsts_max_age" toml:"hsts_max_age" env:"SECURITY_HSTS_MAX_AGE"`
+	ContentSecurityPolicy string        `yaml:"content_security_policy" toml:"content_security_policy" env:"SECURITY_CONTENT_SECURITY_POLICY"`
+}
@@ -4,23 +4,46 @@ import (
 	"CRUD_Go_Backend/internal/pkg/pkgErrors"
 	"os"
 	"strconv"
+	"time"
 )
 
 type DatabaseConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	DBName   string
+	// URL is a postgres:// connection URL. When set it replaces host, port, user, password and name;
+	// the remaining keys are only added to it when the URL does not already carry them.
+	URL             string `yaml:"url" toml:"url" env:"DATABASE_URL" secret:"url"`
+	Host            string `yaml:"host" toml:"host" env:"DB_HOST"`
+	Port            int    `yaml:"port" toml:"port" env:"DB_PORT"`
+	User            string `yaml:"user" toml:"user" env:"DB_USER"`
+	Password        string `yaml:"password" toml:"password" env:"DB_PASSWORD" secret:"true"`
+	DBName          string `yaml:"name" toml:"name" env:"DB_NAME"`
+	SSLMode         string `yaml:"sslmode" toml:"sslmode" env:"DB_SSLMODE"`
+	SSLRootCert     string `yaml:"sslrootcert" toml:"sslrootcert" env:"DB_SSLROOTCERT"`
+	SSLCert         string `yaml:"sslcert" toml:"sslcert" env:"DB_SSLCERT"`
+	SSLKey          string `yaml:"sslkey" toml:"sslkey" env:"DB_SSLKEY"`
+	ApplicationName string `yaml:"application_name" toml:"application_name" env:"DB_APPLICATION_NAME"`
+	// StatementTimeout is sent as the statement_timeout session parameter, so Postgres cancels any
+	// single statement running longer. Zero disables it.
+	StatementTimeout  time.Duration `yaml:"statement_timeout" toml:"statement_timeout" env:"DB_STATEMENT_TIMEOUT"`
+	MaxConns          int           `yaml:"max_conns" toml:"max_conns" env:"DB_MAX_CONNS"`
+	MinConns          int           `yaml:"min_conns" toml:"min_conns" env:"DB_MIN_CONNS"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" toml:"max_conn_lifetime" env:"DB_MAX_CONN_LIFETIME"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" toml:"max_conn_idle_time" env:"DB_MAX_CONN_IDLE_TIME"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period" toml:"health_check_period" env:"DB_HEALTH_CHECK_PERIOD"`
+	// ConnectDeadline bounds how long startup keeps retrying the connection and the initial migration step.
+	ConnectDeadline       time.Duration `yaml:"connect_deadline" toml:"connect_deadline" env:"DB_CONNECT_DEADLINE"`
+	ConnectInitialBackoff time.Duration `yaml:"connect_initial_backoff" toml:"connect_initial_backoff" env:"DB_CONNECT_INITIAL_BACKOFF"`
+	ConnectMaxBackoff     time.Duration `yaml:"connect_max_backoff" toml:"connect_max_backoff" env:"DB_CONNECT_MAX_BACKOFF"`
 }
 
+// FromEnv reads only the database section from the environment, on top of the defaults.
+// It is used by the integration tests; the server loads the full Config with Loader.
 func FromEnv() (DatabaseConfig, error) {
-	dbConfig := DatabaseConfig{
-		Host:     os.Getenv("DB_HOST"),
-		User:     os.Getenv("DB_USER"),
-		Password: os.Getenv("DB_PASSWORD"),
-		DBName:   os.Getenv("DB_NAME"),
-	}
+	dbConfig := Default().Database
+	dbConfig.Host = os.Getenv("DB_HOST")
+	dbConfig.User = os.Getenv("DB_USER")
+	dbConfig.Password = os.Getenv("DB_PASSWORD")
+	dbConfig.DBName = os.Getenv("DB_NAME")
+
 	var err error
 
 	dbConfig.Port, err = strconv.Atoi(os.Getenv("DB_PORT"))
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GradingConfig defines the scales that turn numeric scores into letter grades.
+type GradingConfig struct {
+	// Scales lists the grading scales, each as "<name>=<letter>:<min score>:<points> ...", with bands separated
+	// by spaces and ordered by descending minimum score, for example "simple=A:90:4 B:80:3 C:70:2 D:60:1 F:0:0".
+	// The last band must start at 0.
+	Scales []string `yaml:"scales" toml:"scales" env:"GRADING_SCALES"`
+	// DefaultScale names the scale used for grades that do not name one.
+	DefaultScale string `yaml:"default_scale" toml:"default_scale" env:"GRADING_DEFAULT_SCALE"`
+}
+
+// GradingScale is a parsed entry of GradingConfig.Scales.
+type GradingScale struct {
+	Name  string
+	Bands []GradeBand
+}
+
+// GradeBand maps the scores of at least MinScore to Letter, worth Points grade points.
+type GradeBand struct {
+	Letter   string
+	MinScore float64
+	Points   float64
+}
+
+// GradingScales parses Scales.
+func (c GradingConfig) GradingScales() ([]GradingScale, error) {
+	scales := make([]GradingScale, 0, len(c.Scales))
+
+	for _, entry := range c.Scales {
+		scale, err := parseGradingScale(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		scales = append(scales, scale)
+	}
+
+	return scales, nil
+}
+
+func parseGradingScale(entry string) (GradingScale, error) {
+	invalid := fmt.Errorf("%q must look like \"simple=A:90:4 B:80:3 F:0:0\"", entry)
+
+	name, rawBands, ok := strings.Cut(strings.TrimSpace(entry), "=")
+	if !ok || name == "" {
+		return GradingScale{}, invalid
+	}
+
+	scale := GradingScale{Name: name}
+	letters := make(map[string]bool)
+
+	for _, rawBand := range strings.Fields(rawBands) {
+		parts := strings.Split(rawBand, ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return GradingScale{}, invalid
+		}
+
+		minScore, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || minScore < 0 || minScore > 100 {
+			return GradingScale{}, fmt.Errorf("%q: minimum score of %s must be a number between 0 and 100", entry, parts[0])
+		}
+
+		points, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil || points < 0 {
+			return GradingScale{}, fmt.Errorf("%q: points of %s must be a non-negative number", entry, parts[0])
+		}
+
+		if letters[parts[0]] {
+			return GradingScale{}, fmt.Errorf("%q: letter %s appears twice", entry, parts[0])
+		}
+
+		if n := len(scale.Bands); n > 0 && minScore >= scale.Bands[n-1].MinScore {
+			return GradingScale{}, fmt.Errorf("%q: bands must be ordered by descending minimum score", entry)
+		}
+
+		letters[parts[0]] = true
+		scale.Bands = append(scale.Bands, GradeBand{Letter: parts[0], MinScore: minScore, Points: points})
+	}
+
+	if len(scale.Bands) == 0 || scale.Bands[len(scale.Bands)-1].MinScore != 0 {
+		return GradingScale{}, fmt.Errorf("%q: the last band must start at 0", entry)
+	}
+
+	return scale, nil
+}
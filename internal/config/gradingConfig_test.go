@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGradingConfig_GradingScales(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		entry    string
+		expected GradingScale
+		wantErr  bool
+	}{
+		{
+			entry: "simple=A:90:4 B:80:3 F:0:0",
+			expected: GradingScale{Name: "simple", Bands: []GradeBand{
+				{Letter: "A", MinScore: 90, Points: 4},
+				{Letter: "B", MinScore: 80, Points: 3},
+				{Letter: "F", MinScore: 0, Points: 0},
+			}},
+		},
+		{
+			entry: "pass= P:50.5:1  F:0:0 ",
+			expected: GradingScale{Name: "pass", Bands: []GradeBand{
+				{Letter: "P", MinScore: 50.5, Points: 1},
+				{Letter: "F", MinScore: 0, Points: 0},
+			}},
+		},
+		{entry: "A:90:4 F:0:0", wantErr: true},
+		{entry: "simple=", wantErr: true},
+		{entry: "simple=A:90 F:0:0", wantErr: true},
+		{entry: "simple=A:101:4 F:0:0", wantErr: true},
+		{entry: "simple=A:90:-1 F:0:0", wantErr: true},
+		{entry: "simple=A:80:4 B:90:3 F:0:0", wantErr: true},
+		{entry: "simple=A:90:4 A:80:3 F:0:0", wantErr: true},
+		{entry: "simple=A:90:4 B:80:3", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.entry, func(t *testing.T) {
+			t.Parallel()
+
+			scales, err := GradingConfig{Scales: []string{tc.entry}}.GradingScales()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []GradingScale{tc.expected}, scales)
+		})
+	}
+}
+
+func TestGradingConfig_DefaultsAreValid(t *testing.T) {
+	t.Parallel()
+
+	scales, err := Default().Grading.GradingScales()
+
+	require.NoError(t, err)
+	assert.Len(t, scales, 2)
+	assert.Equal(t, "standard", scales[0].Name)
+}
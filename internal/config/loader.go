@@ -0,0 +1,239 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv names the environment variable that points to the config file when --config is not given.
+const ConfigFileEnv = "CONFIG_FILE"
+
+// Loader layers defaults, the config file, environment variables and command line flags into a Config.
+type Loader struct {
+	configFile string
+	flags      map[string]string
+	lookupEnv  func(string) (string, bool)
+}
+
+// NewLoader registers --config and one flag per configuration key on fs.
+// Call Load after fs.Parse.
+func NewLoader(fs *flag.FlagSet) *Loader {
+	loader := &Loader{
+		flags:     make(map[string]string),
+		lookupEnv: os.LookupEnv,
+	}
+
+	fs.StringVar(&loader.configFile, "config", "", "path to a YAML or TOML config file (env "+ConfigFileEnv+")")
+
+	defaults := Default()
+	for _, f := range fields(&defaults) {
+		key := f.key
+		usage := fmt.Sprintf("sets %s (env %s)", key, f.env)
+		fs.Func(key, usage, func(value string) error {
+			loader.flags[key] = value
+			return nil
+		})
+	}
+
+	return loader
+}
+
+// Load builds the configuration and validates it. The returned error lists every invalid key.
+func (l *Loader) Load() (Config, error) {
+	cfg := Default()
+
+	var problems ValidationError
+
+	configFile := l.configFile
+	if configFile == "" {
+		configFile, _ = l.lookupEnv(ConfigFileEnv)
+	}
+
+	if configFile != "" {
+		if err := decodeFile(configFile, &cfg); err != nil {
+			var fileProblems ValidationError
+			if errors.As(err, &fileProblems) {
+				problems = append(problems, fileProblems...)
+			} else {
+				return Config{}, err
+			}
+		}
+	}
+
+	for _, f := range fields(&cfg) {
+		if f.env == "" {
+			continue
+		}
+
+		if raw, ok := l.lookupEnv(f.env); ok && raw != "" {
+			if err := setValue(f.value, raw); err != nil {
+				problems = append(problems, FieldError{Key: f.key, Message: fmt.Sprintf("%v (from env %s)", err, f.env)})
+			}
+		}
+	}
+
+	for _, f := range fields(&cfg) {
+		if raw, ok := l.flags[f.key]; ok {
+			if err := setValue(f.value, raw); err != nil {
+				problems = append(problems, FieldError{Key: f.key, Message: fmt.Sprintf("%v (from flag --%s)", err, f.key)})
+			}
+		}
+	}
+
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return Config{}, problems
+	}
+
+	return cfg, nil
+}
+
+func decodeFile(path string, cfg *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("could not parse config file %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(content), cfg)
+		if err != nil {
+			return fmt.Errorf("could not parse config file %s: %w", path, err)
+		}
+
+		var problems ValidationError
+		for _, key := range meta.Undecoded() {
+			problems = append(problems, FieldError{Key: key.String(), Message: "unknown key in " + path})
+		}
+
+		if len(problems) > 0 {
+			return problems
+		}
+	default:
+		return fmt.Errorf("unsupported config file extension %q, expected .yaml, .yml or .toml", filepath.Ext(path))
+	}
+
+	return nil
+}
+
+// field is a leaf of Config addressed by its dotted key.
+type field struct {
+	key    string
+	env    string
+	secret string
+	value  reflect.Value
+}
+
+func fields(cfg *Config) []field {
+	return collectFields(reflect.ValueOf(cfg).Elem(), "")
+}
+
+func collectFields(v reflect.Value, prefix string) []field {
+	var result []field
+
+	for i := 0; i < v.NumField(); i++ {
+		structField := v.Type().Field(i)
+
+		name, _, _ := strings.Cut(structField.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		value := v.Field(i)
+		if value.Kind() == reflect.Struct {
+			result = append(result, collectFields(value, key)...)
+			continue
+		}
+
+		result = append(result, field{
+			key:    key,
+			env:    structField.Tag.Get("env"),
+			secret: structField.Tag.Get("secret"),
+			value:  value,
+		})
+	}
+
+	return result
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setValue(v reflect.Value, raw string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+
+		v.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+
+		v.SetBool(b)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported list type %s", v.Type())
+		}
+
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
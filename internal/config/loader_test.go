@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLoader(t *testing.T, env map[string]string, args ...string) *Loader {
+	t.Helper()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader := NewLoader(fs)
+	loader.lookupEnv = func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+
+	require.NoError(t, fs.Parse(args))
+
+	return loader
+}
+
+func TestLoader_Layering(t *testing.T) {
+	t.Parallel()
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+http:
+  addr: ":8000"
+database:
+  host: file-host
+  user: file-user
+  password: file-password
+  name: file-db
+  max_conns: 20
+log:
+  level: debug
+`), 0o600))
+
+	env := map[string]string{
+		"DB_HOST":      "env-host",
+		"DB_MAX_CONNS": "30",
+	}
+
+	loader := newTestLoader(t, env, "--config", configFile, "--database.max_conns=40", "--http.shutdown_timeout=1m")
+
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, ":8000", cfg.HTTP.Addr)
+	assert.Equal(t, "env-host", cfg.Database.Host)
+	assert.Equal(t, "file-user", cfg.Database.User)
+	assert.Equal(t, 40, cfg.Database.MaxConns)
+	assert.Equal(t, time.Minute, cfg.HTTP.ShutdownTimeout)
+	assert.Equal(t, "debug", cfg.Log.Level)
+	assert.Equal(t, Default().Database.MaxConnLifetime, cfg.Database.MaxConnLifetime)
+	// rolling back migrations drops every table, so it must be asked for
+	assert.False(t, cfg.Features.MigrateDownOnShutdown)
+}
+
+func TestLoader_ValidationNamesEveryKey(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"DB_HOST":     "localhost",
+		"DB_USER":     "postgres",
+		"DB_PORT":     "not-a-port",
+		"DB_SSLMODE":  "sometimes",
+		"LOG_FORMAT":  "xml",
+		"DB_PASSWORD": "",
+	}
+
+	loader := newTestLoader(t, env, "--database.min_conns=50")
+
+	_, err := loader.Load()
+	require.Error(t, err)
+
+	var validationErr ValidationError
+	require.True(t, errors.As(err, &validationErr))
+
+	keys := make([]string, 0, len(validationErr))
+	for _, fieldErr := range validationErr {
+		keys = append(keys, fieldErr.Key)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"database.port",
+		"database.password",
+		"database.name",
+		"database.sslmode",
+		"database.min_conns",
+		"log.format",
+	}, keys)
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	cfg.Database.Password = "secret"
+	cfg.Health.DetailsToken = "token"
+
+	redacted := cfg.Redacted()
+
+	assert.Equal(t, redactedValue, redacted.Database.Password)
+	assert.Equal(t, redactedValue, redacted.Health.DetailsToken)
+	assert.Equal(t, "secret", cfg.Database.Password)
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RateLimitConfig configures per-client token buckets. Clients are identified by their principal
+// (which covers API keys), or by IP address on public routes. Every IP address also has a bucket
+// checked before authentication, so that requests with invalid credentials are throttled.
+type RateLimitConfig struct {
+	Enabled             bool    `yaml:"enabled" toml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	RequestsPerSecond   float64 `yaml:"requests_per_second" toml:"requests_per_second" env:"RATE_LIMIT_RPS"`
+	Burst               int     `yaml:"burst" toml:"burst" env:"RATE_LIMIT_BURST"`
+	IPRequestsPerSecond float64 `yaml:"ip_requests_per_second" toml:"ip_requests_per_second" env:"RATE_LIMIT_IP_RPS"`
+	IPBurst             int     `yaml:"ip_burst" toml:"ip_burst" env:"RATE_LIMIT_IP_BURST"`
+	// Routes override the default for single routes, each as "<METHOD> <route template>=<rps>:<burst>",
+	// for example "POST /student=1:5". The method may be "*".
+	Routes []string `yaml:"routes" toml:"routes" env:"RATE_LIMIT_ROUTES"`
+}
+
+// RouteRateLimit is a parsed entry of RateLimitConfig.Routes.
+type RouteRateLimit struct {
+	Method            string
+	Path              string
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// RouteLimits parses Routes.
+func (c RateLimitConfig) RouteLimits() ([]RouteRateLimit, error) {
+	limits := make([]RouteRateLimit, 0, len(c.Routes))
+
+	for _, entry := range c.Routes {
+		limit, err := parseRouteRateLimit(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		limits = append(limits, limit)
+	}
+
+	return limits, nil
+}
+
+func parseRouteRateLimit(entry string) (RouteRateLimit, error) {
+	invalid := fmt.Errorf("%q must look like \"POST /student=1:5\"", entry)
+
+	separator := strings.LastIndex(entry, "=")
+	if separator < 0 {
+		return RouteRateLimit{}, invalid
+	}
+
+	method, path, ok := strings.Cut(strings.TrimSpace(entry[:separator]), " ")
+	if !ok || !strings.HasPrefix(path, "/") {
+		return RouteRateLimit{}, invalid
+	}
+
+	rawRPS, rawBurst, ok := strings.Cut(entry[separator+1:], ":")
+	if !ok {
+		return RouteRateLimit{}, invalid
+	}
+
+	rps, err := strconv.ParseFloat(rawRPS, 64)
+	if err != nil || rps <= 0 {
+		return RouteRateLimit{}, fmt.Errorf("%q: requests per second must be a positive number", entry)
+	}
+
+	burst, err := strconv.Atoi(rawBurst)
+	if err != nil || burst < 1 {
+		return RouteRateLimit{}, fmt.Errorf("%q: burst must be a positive integer", entry)
+	}
+
+	return RouteRateLimit{
+		Method:            strings.ToUpper(method),
+		Path:              path,
+		RequestsPerSecond: rps,
+		Burst:             burst,
+	}, nil
+}
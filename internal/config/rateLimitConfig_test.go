@@ -0,0 +1,47 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitConfig_RouteLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		entry    string
+		expected RouteRateLimit
+		wantErr  bool
+	}{
+		{
+			entry:    "post /student=0.5:5",
+			expected: RouteRateLimit{Method: "POST", Path: "/student", RequestsPerSecond: 0.5, Burst: 5},
+		},
+		{
+			entry:    "* /student/{id:[0-9]+}=2:10",
+			expected: RouteRateLimit{Method: "*", Path: "/student/{id:[0-9]+}", RequestsPerSecond: 2, Burst: 10},
+		},
+		{entry: "/student=1:5", wantErr: true},
+		{entry: "POST /student=1", wantErr: true},
+		{entry: "POST /student=0:5", wantErr: true},
+		{entry: "POST /student=1:0", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.entry, func(t *testing.T) {
+			t.Parallel()
+
+			limits, err := RateLimitConfig{Routes: []string{tc.entry}}.RouteLimits()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []RouteRateLimit{tc.expected}, limits)
+		})
+	}
+}
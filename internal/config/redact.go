@@ -0,0 +1,50 @@
+package config
+
+import (
+	"io"
+	"net/url"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redactedValue = "******"
+
+// Redacted returns a copy of the configuration with every non-empty secret replaced by a placeholder.
+func (c Config) Redacted() Config {
+	for _, f := range fields(&c) {
+		if f.secret == "" || f.value.String() == "" {
+			continue
+		}
+
+		if f.secret == "url" {
+			f.value.SetString(redactURL(f.value.String()))
+			continue
+		}
+
+		f.value.SetString(redactedValue)
+	}
+
+	return c
+}
+
+// Print writes the configuration as YAML, in the same layout the config file uses.
+func (c Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(c); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// redactURL keeps everything but the password so that the connection target stays visible.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redactedValue
+	}
+
+	return u.Redacted()
+}
@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+// Client certificate policies for TLSConfig.ClientAuth.
+const (
+	ClientAuthNone    = "none"
+	ClientAuthRequest = "request"
+	ClientAuthRequire = "require"
+)
+
+// TLSConfig enables HTTPS on http.addr. Certificates are reloaded on SIGHUP and when the files change.
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled" toml:"enabled" env:"TLS_ENABLED"`
+	CertFile string `yaml:"cert_file" toml:"cert_file" env:"TLS_CERT_FILE"`
+	KeyFile  string `yaml:"key_file" toml:"key_file" env:"TLS_KEY_FILE"`
+	// ClientAuth is "none", "request" (verify a certificate if one is sent) or "require" (mTLS).
+	ClientAuth   string `yaml:"client_auth" toml:"client_auth" env:"TLS_CLIENT_AUTH"`
+	ClientCAFile string `yaml:"client_ca_file" toml:"client_ca_file" env:"TLS_CLIENT_CA_FILE"`
+	// ReloadInterval is how often the files are checked for changes; zero reloads on SIGHUP only.
+	ReloadInterval time.Duration `yaml:"reload_interval" toml:"reload_interval" env:"TLS_RELOAD_INTERVAL"`
+	// RedirectAddr, when set, serves plain HTTP there and redirects every request to HTTPS.
+	RedirectAddr string `yaml:"redirect_addr" toml:"redirect_addr" env:"TLS_REDIRECT_ADDR"`
+}
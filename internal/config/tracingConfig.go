@@ -0,0 +1,17 @@
+package config
+
+// Supported values of tracing.exporter.
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file"
+	TracingExporterOTLP   = "otlp"
+)
+
+// TracingConfig selects the span exporter.
+// The OTLP exporter reads its endpoint and headers from the standard OTEL_EXPORTER_OTLP_* variables.
+type TracingConfig struct {
+	Exporter    string `yaml:"exporter" toml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+	ServiceName string `yaml:"service_name" toml:"service_name" env:"OTEL_SERVICE_NAME"`
+	FilePath    string `yaml:"file" toml:"file" env:"OTEL_TRACES_FILE"`
+}
@@ -0,0 +1,326 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// FieldError describes a single invalid configuration key.
+type FieldError struct {
+	Key     string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Key + ": " + e.Message
+}
+
+// ValidationError collects every invalid key so that they can be fixed in one go.
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Error())
+	}
+
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(messages, "; "))
+}
+
+var (
+	queryParamKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	sslModes       = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	logLevels      = []string{"debug", "info", "warn", "error"}
+	logFormats     = []string{"text", "json"}
+	clientAuths    = []string{ClientAuthNone, ClientAuthRequest, ClientAuthRequire}
+	traceExporters = []string{TracingExporterNone, TracingExporterStdout, TracingExporterFile, TracingExporterOTLP}
+)
+
+const maxPort = 65535
+
+func (c Config) validate() ValidationError {
+	var problems ValidationError
+
+	add := func(key, format string, args ...interface{}) {
+		problems = append(problems, FieldError{Key: key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if c.HTTP.Addr == "" {
+		add("http.addr", "must not be empty")
+	}
+
+	if !queryParamKeyPattern.MatchString(c.HTTP.QueryParamKey) {
+		add("http.query_param_key", "%q is not a valid route variable name", c.HTTP.QueryParamKey)
+	}
+
+	if c.HTTP.ReadHeaderTimeout <= 0 {
+		add("http.read_header_timeout", "must be positive")
+	}
+
+	if c.HTTP.ShutdownTimeout <= 0 {
+		add("http.shutdown_timeout", "must be positive")
+	}
+
+	if c.HTTP.MaxBodyBytes <= 0 {
+		add("http.max_body_bytes", "must be positive")
+	}
+
+	if !contains(clientAuths, c.HTTP.TLS.ClientAuth) {
+		add("http.tls.client_auth", "%q must be one of %s", c.HTTP.TLS.ClientAuth, strings.Join(clientAuths, ", "))
+	}
+
+	if c.HTTP.TLS.Enabled {
+		for _, file := range []struct{ key, path string }{
+			{"http.tls.cert_file", c.HTTP.TLS.CertFile},
+			{"http.tls.key_file", c.HTTP.TLS.KeyFile},
+		} {
+			if file.path == "" {
+				add(file.key, "is required when http.tls.enabled is set")
+			}
+		}
+
+		if c.HTTP.TLS.ClientAuth != ClientAuthNone && c.HTTP.TLS.ClientCAFile == "" {
+			add("http.tls.client_ca_file", "is required for client_auth %q", c.HTTP.TLS.ClientAuth)
+		}
+
+		if c.HTTP.TLS.ReloadInterval < 0 {
+			add("http.tls.reload_interval", "must not be negative")
+		}
+
+		if c.HTTP.TLS.RedirectAddr != "" && c.HTTP.TLS.RedirectAddr == c.HTTP.Addr {
+			add("http.tls.redirect_addr", "must differ from http.addr")
+		}
+	} else if c.HTTP.TLS.RedirectAddr != "" {
+		add("http.tls.redirect_addr", "requires http.tls.enabled")
+	}
+
+	if c.Database.URL != "" {
+		if u, err := url.Parse(c.Database.URL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+			add("database.url", "must be a postgres:// or postgresql:// URL")
+		}
+	} else {
+		for _, required := range []struct{ key, value string }{
+			{"database.host", c.Database.Host},
+			{"database.user", c.Database.User},
+			{"database.password", c.Database.Password},
+			{"database.name", c.Database.DBName},
+		} {
+			if required.value == "" {
+				add(required.key, "must not be empty")
+			}
+		}
+
+		if c.Database.Port <= 0 || c.Database.Port > maxPort {
+			add("database.port", "%d is not a valid port", c.Database.Port)
+		}
+	}
+
+	if !contains(sslModes, c.Database.SSLMode) {
+		add("database.sslmode", "%q must be one of %s", c.Database.SSLMode, strings.Join(sslModes, ", "))
+	}
+
+	if (c.Database.SSLCert == "") != (c.Database.SSLKey == "") {
+		add("database.sslcert", "database.sslcert and database.sslkey must be set together")
+	}
+
+	for _, file := range []struct{ key, path string }{
+		{"http.tls.cert_file", c.HTTP.TLS.CertFile},
+		{"http.tls.key_file", c.HTTP.TLS.KeyFile},
+		{"http.tls.client_ca_file", c.HTTP.TLS.ClientCAFile},
+		{"database.sslrootcert", c.Database.SSLRootCert},
+		{"database.sslcert", c.Database.SSLCert},
+		{"database.sslkey", c.Database.SSLKey},
+	} {
+		if file.path == "" {
+			continue
+		}
+
+		if _, err := os.Stat(file.path); err != nil {
+			add(file.key, "cannot read %q: %v", file.path, err)
+		}
+	}
+
+	if c.Database.StatementTimeout < 0 {
+		add("database.statement_timeout", "must not be negative")
+	}
+
+	if c.Database.HealthCheckPeriod < 0 {
+		add("database.health_check_period", "must not be negative")
+	}
+
+	if c.Database.ConnectDeadline <= 0 {
+		add("database.connect_deadline", "must be positive")
+	}
+
+	if c.Database.ConnectInitialBackoff <= 0 {
+		add("database.connect_initial_backoff", "must be positive")
+	}
+
+	if c.Database.ConnectMaxBackoff < c.Database.ConnectInitialBackoff {
+		add("database.connect_max_backoff", "must not be less than database.connect_initial_backoff")
+	}
+
+	if c.Database.MaxConns < 0 {
+		add("database.max_conns", "must not be negative")
+	}
+
+	if c.Database.MinConns < 0 {
+		add("database.min_conns", "must not be negative")
+	}
+
+	if c.Database.MaxConns > 0 && c.Database.MinConns > c.Database.MaxConns {
+		add("database.min_conns", "%d exceeds database.max_conns %d", c.Database.MinConns, c.Database.MaxConns)
+	}
+
+	if c.Database.MaxConnLifetime < 0 {
+		add("database.max_conn_lifetime", "must not be negative")
+	}
+
+	if c.Database.MaxConnIdleTime < 0 {
+		add("database.max_conn_idle_time", "must not be negative")
+	}
+
+	if !contains(logLevels, c.Log.Level) {
+		add("log.level", "%q must be one of %s", c.Log.Level, strings.Join(logLevels, ", "))
+	}
+
+	if !contains(logFormats, c.Log.Format) {
+		add("log.format", "%q must be one of %s", c.Log.Format, strings.Join(logFormats, ", "))
+	}
+
+	if c.Metrics.Enabled && !strings.HasPrefix(c.Metrics.Path, "/") {
+		add("metrics.path", "%q must start with /", c.Metrics.Path)
+	}
+
+	if !contains(traceExporters, c.Tracing.Exporter) {
+		add("tracing.exporter", "%q must be one of %s", c.Tracing.Exporter, strings.Join(traceExporters, ", "))
+	}
+
+	if c.Tracing.Exporter == TracingExporterFile && c.Tracing.FilePath == "" {
+		add("tracing.file", "is required for the %q exporter", TracingExporterFile)
+	}
+
+	if c.Auth.JWTPublicKeyFile != "" && c.Auth.JWKSFile != "" {
+		add("auth.jwks_file", "cannot be combined with auth.jwt_public_key_file")
+	}
+
+	for _, file := range []struct{ key, path string }{
+		{"auth.jwt_public_key_file", c.Auth.JWTPublicKeyFile},
+		{"auth.jwks_file", c.Auth.JWKSFile},
+	} {
+		if file.path == "" {
+			continue
+		}
+
+		if _, err := os.Stat(file.path); err != nil {
+			add(file.key, "cannot read %q: %v", file.path, err)
+		}
+	}
+
+	for _, path := range c.Auth.PublicPaths {
+		if !strings.HasPrefix(path, "/") {
+			add("auth.public_paths", "%q must start with /", path)
+		}
+	}
+
+	if c.RateLimit.Enabled {
+		if c.RateLimit.RequestsPerSecond <= 0 {
+			add("rate_limit.requests_per_second", "must be positive")
+		}
+
+		if c.RateLimit.Burst < 1 {
+			add("rate_limit.burst", "must be at least 1")
+		}
+
+		if c.RateLimit.IPRequestsPerSecond <= 0 {
+			add("rate_limit.ip_requests_per_second", "must be positive")
+		}
+
+		if c.RateLimit.IPBurst < 1 {
+			add("rate_limit.ip_burst", "must be at least 1")
+		}
+
+		for _, entry := range c.RateLimit.Routes {
+			if _, err := parseRouteRateLimit(entry); err != nil {
+				add("rate_limit.routes", "%v", err)
+			}
+		}
+	}
+
+	for _, entry := range c.API.Deprecated {
+		if _, err := parseVersionDeprecation(entry); err != nil {
+			add("api.deprecated", "%v", err)
+		}
+	}
+
+	if c.API.DeprecationLink != "" {
+		if u, err := url.Parse(c.API.DeprecationLink); err != nil || !u.IsAbs() {
+			add("api.deprecation_link", "%q must be an absolute URL", c.API.DeprecationLink)
+		}
+	}
+
+	if c.CORS.Enabled {
+		if len(c.CORS.AllowedOrigins) == 0 {
+			add("cors.allowed_origins", "must not be empty when cors is enabled")
+		}
+
+		for _, origin := range c.CORS.AllowedOrigins {
+			if _, err := path.Match(origin, ""); err != nil {
+				add("cors.allowed_origins", "%q is not a valid pattern", origin)
+			}
+
+			if origin == "*" && c.CORS.AllowCredentials {
+				add("cors.allow_credentials", "cannot be combined with the \"*\" origin")
+			}
+		}
+
+		if c.CORS.MaxAge < 0 {
+			add("cors.max_age", "must not be negative")
+		}
+	}
+
+	if c.Security.HSTSMaxAge < 0 {
+		add("security.hsts_max_age", "must not be negative")
+	}
+
+	scaleNames := make([]string, 0, len(c.Grading.Scales))
+
+	for _, entry := range c.Grading.Scales {
+		scale, err := parseGradingScale(entry)
+		if err != nil {
+			add("grading.scales", "%v", err)
+			continue
+		}
+
+		if contains(scaleNames, scale.Name) {
+			add("grading.scales", "scale %q is defined twice", scale.Name)
+		}
+
+		scaleNames = append(scaleNames, scale.Name)
+	}
+
+	if !contains(scaleNames, c.Grading.DefaultScale) {
+		add("grading.default_scale", "%q must be one of the grading.scales", c.Grading.DefaultScale)
+	}
+
+	if c.Health.DrainDelay < 0 {
+		add("health.drain_delay", "must not be negative")
+	}
+
+	return problems
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
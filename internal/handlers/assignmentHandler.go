@@ -0,0 +1,608 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/codec"
+	"CRUD_Go_Backend/internal/pkg/grading"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+)
+
+// studentParamKey is the path variable of the student in submission routes.
+const studentParamKey = "student_id"
+
+// Limits of assignment and submission fields, in characters and points.
+const (
+	maxAssignmentTitle       = 200
+	maxAssignmentDescription = 5000
+	maxAssignmentPoints      = 99999.99
+	maxAssignmentWeight      = 999.99
+	maxSubmissionFeedback    = 2000
+)
+
+var submissionStatuses = map[string]bool{
+	models.SubmissionSubmitted: true,
+	models.SubmissionMissing:   true,
+	models.SubmissionExcused:   true,
+}
+
+// AssignmentHandler serves the v2 assignment, submission and gradebook endpoints. With grade storage,
+// the assignment scores of a gradebook can be rolled up into the grades of the class.
+type AssignmentHandler struct {
+	assignmentStorage repository.AssignmentPgRepo
+	gradeStorage      repository.GradePgRepo
+	scales            grading.Scales
+	queryParamKey     string
+	links             *links
+}
+
+// NewAssignmentHandler creates a new AssignmentHandler. gradeStorage may be nil, in which case
+// RollUp is not available; roll-ups convert scores to letters with scales.
+// Links are built from the named routes of router.
+func NewAssignmentHandler(
+	assignmentStorage repository.AssignmentPgRepo,
+	gradeStorage repository.GradePgRepo,
+	scales grading.Scales,
+	queryParamKey string,
+	router *mux.Router,
+) *AssignmentHandler {
+	return &AssignmentHandler{
+		assignmentStorage: assignmentStorage,
+		gradeStorage:      gradeStorage,
+		scales:            scales,
+		queryParamKey:     queryParamKey,
+		links:             newLinks(router, queryParamKey),
+	}
+}
+
+// List lists the assignments of the class in the path, in the term given by the term query parameter
+// or in the current term.
+func (h *AssignmentHandler) List(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	termID, ok := parseTerm(w, req)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.assignmentStorage.ListByClass(req.Context(), classID, termID)
+	if err != nil {
+		writeTermScopedError(w, req, err, "class or term")
+		return
+	}
+
+	if assignments == nil {
+		assignments = []models.Assignment{}
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: assignments})
+}
+
+// Create publishes an assignment for the class in the path, in the term given by term_id or in the current term.
+func (h *AssignmentHandler) Create(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var assignment models.Assignment
+	if !decodeBody(w, req, &assignment) {
+		return
+	}
+
+	if detail := validateAssignment(assignment); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	assignment.ClassID = classID
+
+	id, err := h.assignmentStorage.Add(req.Context(), assignment)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrReferenceNotFound) {
+			problem.Write(w, req, http.StatusUnprocessableEntity, "the term of the assignment does not exist")
+			return
+		}
+
+		writeStorageError(w, req, err, "class")
+
+		return
+	}
+
+	created, err := h.assignmentStorage.GetByID(req.Context(), id)
+	if err != nil {
+		writeStorageError(w, req, err, "assignment")
+		return
+	}
+
+	w.Header().Set("Location", h.links.assignment(id))
+	writeResponse(w, responseCodec, http.StatusCreated, models.Envelope{Data: created})
+}
+
+func (h *AssignmentHandler) Get(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	assignmentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	assignment, err := h.assignmentStorage.GetByID(req.Context(), assignmentID)
+	if err != nil {
+		writeStorageError(w, req, err, "assignment")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: assignment})
+}
+
+// Update changes the assignment in the path. Its class and term cannot be changed, and its maximum points
+// cannot go below a recorded score. The weight is kept when it is left out.
+func (h *AssignmentHandler) Update(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	assignmentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var assignment models.Assignment
+	if !decodeBody(w, req, &assignment) {
+		return
+	}
+
+	if detail := validateAssignment(assignment); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	if err := h.assignmentStorage.Update(req.Context(), assignmentID, assignment); err != nil {
+		if errors.Is(err, pkgErrors.ErrScoreAboveMax) {
+			problem.Write(w, req, http.StatusUnprocessableEntity, "a recorded score is above max_points")
+			return
+		}
+
+		writeStorageError(w, req, err, "assignment")
+
+		return
+	}
+
+	updated, err := h.assignmentStorage.GetByID(req.Context(), assignmentID)
+	if err != nil {
+		writeStorageError(w, req, err, "assignment")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: updated})
+}
+
+// Delete removes the assignment in the path with its submissions.
+func (h *AssignmentHandler) Delete(w http.ResponseWriter, req *http.Request) {
+	assignmentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	if err := h.assignmentStorage.Delete(req.Context(), assignmentID); err != nil {
+		writeStorageError(w, req, err, "assignment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSubmissions lists the submission of every student enrolled in the class and term of the assignment in the path.
+func (h *AssignmentHandler) ListSubmissions(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	assignmentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	submissions, err := h.assignmentStorage.ListSubmissions(req.Context(), assignmentID)
+	if err != nil {
+		writeStorageError(w, req, err, "assignment")
+		return
+	}
+
+	if submissions == nil {
+		submissions = []models.Submission{}
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: submissions})
+}
+
+func (h *AssignmentHandler) GetSubmission(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	assignmentID, studentID, ok := h.submissionPath(w, req)
+	if !ok {
+		return
+	}
+
+	h.writeSubmission(w, req, responseCodec, assignmentID, studentID)
+}
+
+// SetSubmission records the submission of the student in the path, replacing the previous one.
+// submitted_at defaults to now for submitted work.
+func (h *AssignmentHandler) SetSubmission(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	assignmentID, studentID, ok := h.submissionPath(w, req)
+	if !ok {
+		return
+	}
+
+	var submission models.Submission
+	if !decodeBody(w, req, &submission) {
+		return
+	}
+
+	if detail := validateSubmission(submission); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	submission.AssignmentID = assignmentID
+	submission.StudentID = studentID
+
+	if submission.Status == models.SubmissionSubmitted && submission.SubmittedAt == nil {
+		now := time.Now()
+		submission.SubmittedAt = &now
+	}
+
+	if submission.Score != nil {
+		// Scores are stored with two decimals.
+		score := math.Round(*submission.Score*100) / 100
+		submission.Score = &score
+	}
+
+	if err := h.assignmentStorage.SetSubmission(req.Context(), submission); err != nil {
+		writeSubmissionError(w, req, err)
+		return
+	}
+
+	h.writeSubmission(w, req, responseCodec, assignmentID, studentID)
+}
+
+// DeleteSubmission removes the submission of the student in the path, which becomes pending again.
+func (h *AssignmentHandler) DeleteSubmission(w http.ResponseWriter, req *http.Request) {
+	assignmentID, studentID, ok := h.submissionPath(w, req)
+	if !ok {
+		return
+	}
+
+	if err := h.assignmentStorage.DeleteSubmission(req.Context(), assignmentID, studentID); err != nil {
+		writeStorageError(w, req, err, "submission")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Gradebook returns the students × assignments matrix of the class in the path, in the term given by
+// the term query parameter or in the current term, with the assignment score of each student.
+func (h *AssignmentHandler) Gradebook(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	gradebook, ok := h.gradebook(w, req)
+	if !ok {
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: gradebook})
+}
+
+// RollUp sets the grade of every student of the gradebook who has an assignment score to that score,
+// converted with the scale query parameter or the default scale. Students without a score keep their grade.
+func (h *AssignmentHandler) RollUp(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	scale, err := h.scales.Lookup(req.URL.Query().Get("scale"))
+	if err != nil {
+		problem.Write(w, req, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	gradebook, ok := h.gradebook(w, req)
+	if !ok {
+		return
+	}
+
+	grades := make([]models.Grade, 0, len(gradebook.Students))
+
+	for _, row := range gradebook.Students {
+		if row.Score == nil {
+			continue
+		}
+
+		band := scale.Grade(*row.Score)
+		grades = append(grades, models.Grade{
+			ClassInfoID: row.ClassInfoID,
+			Score:       *row.Score,
+			Letter:      band.Letter,
+			GradePoints: band.Points,
+			Scale:       scale.Name,
+		})
+	}
+
+	stored, err := h.gradeStorage.SetAll(req.Context(), grades)
+	if err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return
+	}
+
+	if stored == nil {
+		stored = []models.Grade{}
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: stored})
+}
+
+func (h *AssignmentHandler) gradebook(w http.ResponseWriter, req *http.Request) (models.Gradebook, bool) {
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return models.Gradebook{}, false
+	}
+
+	termID, ok := parseTerm(w, req)
+	if !ok {
+		return models.Gradebook{}, false
+	}
+
+	term, assignments, submissions, err := h.assignmentStorage.GetGradebook(req.Context(), classID, termID)
+	if err != nil {
+		writeTermScopedError(w, req, err, "class or term")
+		return models.Gradebook{}, false
+	}
+
+	return buildGradebook(classID, term, assignments, submissions), true
+}
+
+func (h *AssignmentHandler) writeSubmission(
+	w http.ResponseWriter,
+	req *http.Request,
+	responseCodec codec.Codec,
+	assignmentID, studentID int64,
+) {
+	submission, err := h.assignmentStorage.GetSubmission(req.Context(), assignmentID, studentID)
+	if err != nil {
+		writeSubmissionError(w, req, err)
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: submission})
+}
+
+func (h *AssignmentHandler) submissionPath(w http.ResponseWriter, req *http.Request) (assignmentID, studentID int64, ok bool) {
+	assignmentID, ok = pathID(w, req, h.queryParamKey)
+	if !ok {
+		return 0, 0, false
+	}
+
+	studentID, ok = pathID(w, req, studentParamKey)
+
+	return assignmentID, studentID, ok
+}
+
+// buildGradebook lays submissions, which are ordered by student, out in one row per student with one
+// submission per assignment, in the order of assignments.
+func buildGradebook(
+	classID int64,
+	term models.AcademicTerm,
+	assignments []models.Assignment,
+	submissions []models.Submission,
+) models.Gradebook {
+	gradebook := models.Gradebook{
+		ClassID:     classID,
+		Term:        term,
+		Assignments: assignments,
+		Students:    []models.GradebookRow{},
+	}
+
+	if gradebook.Assignments == nil {
+		gradebook.Assignments = []models.Assignment{}
+	}
+
+	byAssignment := make(map[int64]models.Submission, len(assignments))
+
+	flush := func(row models.GradebookRow) {
+		row.Submissions = make([]models.Submission, 0, len(assignments))
+
+		for _, assignment := range assignments {
+			submission, ok := byAssignment[assignment.AssignmentID]
+			if !ok {
+				submission = models.Submission{
+					AssignmentID: assignment.AssignmentID,
+					StudentID:    row.StudentID,
+					ClassInfoID:  row.ClassInfoID,
+					Status:       models.SubmissionPending,
+				}
+			}
+
+			submission.StudentName = ""
+			row.Submissions = append(row.Submissions, submission)
+		}
+
+		row.Score = assignmentScore(assignments, row.Submissions)
+		gradebook.Students = append(gradebook.Students, row)
+	}
+
+	var row *models.GradebookRow
+
+	for _, submission := range submissions {
+		if row == nil || row.ClassInfoID != submission.ClassInfoID {
+			if row != nil {
+				flush(*row)
+			}
+
+			row = &models.GradebookRow{
+				StudentID:   submission.StudentID,
+				StudentName: submission.StudentName,
+				ClassInfoID: submission.ClassInfoID,
+			}
+			byAssignment = make(map[int64]models.Submission, len(assignments))
+		}
+
+		if submission.AssignmentID != 0 {
+			byAssignment[submission.AssignmentID] = submission
+		}
+	}
+
+	if row != nil {
+		flush(*row)
+	}
+
+	return gradebook
+}
+
+// assignmentScore returns the weighted percentage of the counted submissions, rounded to two decimals,
+// or nil when none counts. Scored submitted work counts, and missing work counts as its score or 0.
+// Pending, excused and unscored work does not count.
+func assignmentScore(assignments []models.Assignment, submissions []models.Submission) *float64 {
+	var earned, weights float64
+
+	for i, submission := range submissions {
+		assignment := assignments[i]
+
+		weight := 1.0
+		if assignment.Weight != nil {
+			weight = *assignment.Weight
+		}
+
+		var score float64
+
+		switch {
+		case submission.Status == models.SubmissionSubmitted && submission.Score != nil:
+			score = *submission.Score
+		case submission.Status == models.SubmissionMissing:
+			if submission.Score != nil {
+				score = *submission.Score
+			}
+		default:
+			continue
+		}
+
+		earned += weight * score / assignment.MaxPoints
+		weights += weight
+	}
+
+	if weights == 0 {
+		return nil
+	}
+
+	percentage := math.Round(earned/weights*10000) / 100
+
+	return &percentage
+}
+
+// writeTermScopedError writes the error of reading a class in a term.
+func writeTermScopedError(w http.ResponseWriter, req *http.Request, err error, resource string) {
+	if errors.Is(err, pkgErrors.ErrNoCurrentTerm) {
+		problem.Write(w, req, http.StatusNotFound, "no academic term covers the current date, set term")
+		return
+	}
+
+	writeStorageError(w, req, err, resource)
+}
+
+// writeSubmissionError writes the error of reading or recording a submission: 422 for students who are not
+// enrolled in the class and term of the assignment, and for scores above its maximum points.
+func writeSubmissionError(w http.ResponseWriter, req *http.Request, err error) {
+	switch {
+	case errors.Is(err, pkgErrors.ErrNotEnrolled):
+		problem.Write(w, req, http.StatusUnprocessableEntity, "the student is not enrolled in the class and term of the assignment")
+	case errors.Is(err, pkgErrors.ErrScoreAboveMax):
+		problem.Write(w, req, http.StatusUnprocessableEntity, "score must not be above the max_points of the assignment")
+	default:
+		writeStorageError(w, req, err, "assignment")
+	}
+}
+
+// validateAssignment returns why assignment cannot be stored, or "" when it can.
+func validateAssignment(assignment models.Assignment) string {
+	switch {
+	case strings.TrimSpace(assignment.Title) == "":
+		return "title is required"
+	case utf8.RuneCountInString(assignment.Title) > maxAssignmentTitle:
+		return fmt.Sprintf("title must be at most %d characters", maxAssignmentTitle)
+	case utf8.RuneCountInString(assignment.Description) > maxAssignmentDescription:
+		return fmt.Sprintf("description must be at most %d characters", maxAssignmentDescription)
+	case assignment.DueAt.IsZero():
+		return "due_at is required"
+	case assignment.MaxPoints <= 0 || assignment.MaxPoints > maxAssignmentPoints:
+		return fmt.Sprintf("max_points must be above 0 and at most %g", maxAssignmentPoints)
+	case assignment.Weight != nil && (*assignment.Weight < 0 || *assignment.Weight > maxAssignmentWeight):
+		return fmt.Sprintf("weight must be between 0 and %g", maxAssignmentWeight)
+	case assignment.TermID < 0:
+		return "term_id must be a term id"
+	}
+
+	return ""
+}
+
+// validateSubmission returns why submission cannot be recorded, or "" when it can.
+func validateSubmission(submission models.Submission) string {
+	switch {
+	case !submissionStatuses[submission.Status]:
+		return fmt.Sprintf(
+			"status must be %s, %s or %s",
+			models.SubmissionSubmitted, models.SubmissionMissing, models.SubmissionExcused,
+		)
+	case submission.SubmittedAt != nil && submission.Status != models.SubmissionSubmitted:
+		return "submitted_at is only kept for submitted work"
+	case submission.Score != nil && submission.Status == models.SubmissionExcused:
+		return "excused work has no score"
+	case submission.Score != nil && *submission.Score < 0:
+		return "score must not be negative"
+	case utf8.RuneCountInString(submission.Feedback) > maxSubmissionFeedback:
+		return fmt.Sprintf("feedback must be at most %d characters", maxSubmissionFeedback)
+	}
+
+	return ""
+}
@@ -0,0 +1,431 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newAssignmentRouter(
+	assignmentStorage *mock_repository.MockAssignmentPgRepo,
+	gradeStorage *mock_repository.MockGradePgRepo,
+) http.Handler {
+	return NewRouter(
+		nil, nil, NewHealthHandler(nil, ""), "id",
+		WithGrades(gradeStorage, nil, testScales),
+		WithAssignments(assignmentStorage),
+	)
+}
+
+func TestAssignmentHandler_Create(t *testing.T) {
+	t.Parallel()
+	dueAt := time.Date(2026, 10, 26, 23, 59, 0, 0, time.UTC)
+	tests := []struct {
+		description      string
+		body             string
+		mock             func(m *mock_repository.MockAssignmentPgRepo)
+		expectedCode     int
+		expectedLocation string
+	}{
+		{
+			description: "Created",
+			body:        `{"title": "Essay", "due_at": "2026-10-26T23:59:00Z", "max_points": 20, "weight": 2}`,
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().Add(gomock.Any(), models.Assignment{
+					ClassID: 3, Title: "Essay", DueAt: dueAt, MaxPoints: 20, Weight: share(2),
+				}).Return(int64(11), nil)
+				m.EXPECT().GetByID(gomock.Any(), int64(11)).Return(models.Assignment{
+					AssignmentID: 11, ClassID: 3, TermID: 2, Title: "Essay", DueAt: dueAt, MaxPoints: 20, Weight: share(2),
+				}, nil)
+			},
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/v2/assignment/11",
+		},
+		{
+			description:  "Title missing",
+			body:         `{"title": " ", "due_at": "2026-10-26T23:59:00Z", "max_points": 20}`,
+			mock:         func(m *mock_repository.MockAssignmentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Due date missing",
+			body:         `{"title": "Essay", "max_points": 20}`,
+			mock:         func(m *mock_repository.MockAssignmentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "No points",
+			body:         `{"title": "Essay", "due_at": "2026-10-26T23:59:00Z", "max_points": 0}`,
+			mock:         func(m *mock_repository.MockAssignmentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Negative weight",
+			body:         `{"title": "Essay", "due_at": "2026-10-26T23:59:00Z", "max_points": 20, "weight": -1}`,
+			mock:         func(m *mock_repository.MockAssignmentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Class not found",
+			body:        `{"title": "Essay", "due_at": "2026-10-26T23:59:00Z", "max_points": 20}`,
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().Add(gomock.Any(), gomock.Any()).Return(int64(-1), pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			description: "Term not found",
+			body:        `{"term_id": 9, "title": "Essay", "due_at": "2026-10-26T23:59:00Z", "max_points": 20}`,
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().Add(gomock.Any(), gomock.Any()).Return(int64(-1), pkgErrors.ErrReferenceNotFound)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			description: "No current term",
+			body:        `{"title": "Essay", "due_at": "2026-10-26T23:59:00Z", "max_points": 20}`,
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().Add(gomock.Any(), gomock.Any()).Return(int64(-1), pkgErrors.ErrNoCurrentTerm)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockAssignmentPgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(http.MethodPost, "/v2/class/3/assignments", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			newAssignmentRouter(mockRepo, mock_repository.NewMockGradePgRepo(ctrl)).ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			assert.Equal(t, tc.expectedLocation, rr.Header().Get("Location"))
+		})
+	}
+}
+
+func TestAssignmentHandler_SetSubmission(t *testing.T) {
+	t.Parallel()
+	submittedAt := time.Date(2026, 10, 27, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		description  string
+		body         string
+		mock         func(m *mock_repository.MockAssignmentPgRepo)
+		expectedCode int
+	}{
+		{
+			description: "Recorded",
+			body:        `{"status": "submitted", "submitted_at": "2026-10-27T08:00:00Z", "score": 17.255, "feedback": "Good"}`,
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().SetSubmission(gomock.Any(), models.Submission{
+					AssignmentID: 11, StudentID: 7, Status: models.SubmissionSubmitted,
+					SubmittedAt: &submittedAt, Score: share(17.26), Feedback: "Good",
+				}).Return(nil)
+				m.EXPECT().GetSubmission(gomock.Any(), int64(11), int64(7)).Return(models.Submission{
+					AssignmentID: 11, StudentID: 7, ClassInfoID: 5, Status: models.SubmissionSubmitted,
+					SubmittedAt: &submittedAt, Late: true, Score: share(17.26), Feedback: "Good",
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description: "Submitted now",
+			body:        `{"status": "submitted"}`,
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().SetSubmission(gomock.Any(), gomock.Any()).Return(nil)
+				m.EXPECT().GetSubmission(gomock.Any(), int64(11), int64(7)).Return(models.Submission{}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "Unknown status",
+			body:         `{"status": "lost"}`,
+			mock:         func(m *mock_repository.MockAssignmentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Missing work with a submission time",
+			body:         `{"status": "missing", "submitted_at": "2026-10-27T08:00:00Z"}`,
+			mock:         func(m *mock_repository.MockAssignmentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Excused work with a score",
+			body:         `{"status": "excused", "score": 3}`,
+			mock:         func(m *mock_repository.MockAssignmentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Negative score",
+			body:         `{"status": "submitted", "score": -1}`,
+			mock:         func(m *mock_repository.MockAssignmentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Feedback too long",
+			body:         `{"status": "missing", "feedback": "` + strings.Repeat("a", maxSubmissionFeedback+1) + `"}`,
+			mock:         func(m *mock_repository.MockAssignmentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Student not enrolled",
+			body:        `{"status": "missing"}`,
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().SetSubmission(gomock.Any(), gomock.Any()).Return(pkgErrors.ErrNotEnrolled)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			description: "Score above the maximum",
+			body:        `{"status": "submitted", "score": 21}`,
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().SetSubmission(gomock.Any(), gomock.Any()).Return(pkgErrors.ErrScoreAboveMax)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			description: "Assignment not found",
+			body:        `{"status": "missing"}`,
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().SetSubmission(gomock.Any(), gomock.Any()).Return(pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			description: "Term closed",
+			body:        `{"status": "missing"}`,
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().SetSubmission(gomock.Any(), gomock.Any()).Return(pkgErrors.ErrTermClosed)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockAssignmentPgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(http.MethodPut, "/v2/assignment/11/submissions/7", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			newAssignmentRouter(mockRepo, mock_repository.NewMockGradePgRepo(ctrl)).ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+// gradebookFixture is a class with two assignments, the second counting twice, and three students:
+// one who did both, one excused from the first who missed the second, and one with nothing recorded.
+func gradebookFixture() (models.AcademicTerm, []models.Assignment, []models.Submission) {
+	term := models.AcademicTerm{TermID: 2, Name: "Fall 2026"}
+	assignments := []models.Assignment{
+		{AssignmentID: 11, ClassID: 3, TermID: 2, Title: "Essay", MaxPoints: 20, Weight: share(1)},
+		{AssignmentID: 12, ClassID: 3, TermID: 2, Title: "Exam", MaxPoints: 50, Weight: share(2)},
+	}
+	submissions := []models.Submission{
+		{AssignmentID: 11, StudentID: 7, StudentName: "Ann", ClassInfoID: 5, Status: models.SubmissionSubmitted, Score: share(18)},
+		{AssignmentID: 12, StudentID: 7, StudentName: "Ann", ClassInfoID: 5, Status: models.SubmissionSubmitted, Score: share(40)},
+		{AssignmentID: 11, StudentID: 8, StudentName: "Bob", ClassInfoID: 6, Status: models.SubmissionExcused},
+		{AssignmentID: 12, StudentID: 8, StudentName: "Bob", ClassInfoID: 6, Status: models.SubmissionMissing},
+		{AssignmentID: 11, StudentID: 9, StudentName: "Cid", ClassInfoID: 7, Status: models.SubmissionPending},
+	}
+
+	return term, assignments, submissions
+}
+
+func TestAssignmentHandler_Gradebook(t *testing.T) {
+	t.Parallel()
+	term, assignments, submissions := gradebookFixture()
+	tests := []struct {
+		description    string
+		url            string
+		mock           func(m *mock_repository.MockAssignmentPgRepo)
+		expectedCode   int
+		expectedScores []*float64
+	}{
+		{
+			description: "Current term",
+			url:         "/v2/class/3/gradebook",
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().GetGradebook(gomock.Any(), int64(3), nil).Return(term, assignments, submissions, nil)
+			},
+			expectedCode: http.StatusOK,
+			// Ann: (18/20 + 2·40/50) / 3; Bob: only the missing exam counts; Cid: nothing counts.
+			expectedScores: []*float64{share(83.33), share(0), nil},
+		},
+		{
+			description: "No assignments",
+			url:         "/v2/class/3/gradebook?term=2",
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				termID := int64(2)
+				m.EXPECT().GetGradebook(gomock.Any(), int64(3), &termID).Return(term, nil, []models.Submission{
+					{StudentID: 7, StudentName: "Ann", ClassInfoID: 5, Status: models.SubmissionPending},
+				}, nil)
+			},
+			expectedCode:   http.StatusOK,
+			expectedScores: []*float64{nil},
+		},
+		{
+			description:  "Malformed term",
+			url:          "/v2/class/3/gradebook?term=fall",
+			mock:         func(m *mock_repository.MockAssignmentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "No current term",
+			url:         "/v2/class/3/gradebook",
+			mock: func(m *mock_repository.MockAssignmentPgRepo) {
+				m.EXPECT().GetGradebook(gomock.Any(), int64(3), nil).
+					Return(models.AcademicTerm{}, nil, nil, pkgErrors.ErrNoCurrentTerm)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockAssignmentPgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			rr := httptest.NewRecorder()
+			// act
+			newAssignmentRouter(mockRepo, mock_repository.NewMockGradePgRepo(ctrl)).ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var actual struct {
+				Data models.Gradebook `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			require.Len(t, actual.Data.Students, len(tc.expectedScores))
+			for i, row := range actual.Data.Students {
+				assert.Equal(t, tc.expectedScores[i], row.Score)
+				assert.Len(t, row.Submissions, len(actual.Data.Assignments))
+			}
+		})
+	}
+}
+
+func TestAssignmentHandler_GradebookMatrix(t *testing.T) {
+	t.Parallel()
+	// arrange
+	term, assignments, submissions := gradebookFixture()
+	// act
+	gradebook := buildGradebook(3, term, assignments, submissions)
+	// assert
+	require.Len(t, gradebook.Students, 3)
+	cid := gradebook.Students[2]
+	assert.Equal(t, "Cid", cid.StudentName)
+	assert.Equal(t, []models.Submission{
+		{AssignmentID: 11, StudentID: 9, ClassInfoID: 7, Status: models.SubmissionPending},
+		{AssignmentID: 12, StudentID: 9, ClassInfoID: 7, Status: models.SubmissionPending},
+	}, cid.Submissions)
+}
+
+func TestAssignmentHandler_RollUp(t *testing.T) {
+	t.Parallel()
+	term, assignments, submissions := gradebookFixture()
+	tests := []struct {
+		description  string
+		url          string
+		mock         func(a *mock_repository.MockAssignmentPgRepo, g *mock_repository.MockGradePgRepo)
+		expectedCode int
+	}{
+		{
+			description: "Rolled up",
+			url:         "/v2/class/3/gradebook/grades",
+			mock: func(a *mock_repository.MockAssignmentPgRepo, g *mock_repository.MockGradePgRepo) {
+				a.EXPECT().GetGradebook(gomock.Any(), int64(3), nil).Return(term, assignments, submissions, nil)
+				grades := []models.Grade{
+					{ClassInfoID: 5, Score: 83.33, Letter: "B", GradePoints: 3, Scale: "simple"},
+					{ClassInfoID: 6, Score: 0, Letter: "F", GradePoints: 0, Scale: "simple"},
+				}
+				g.EXPECT().SetAll(gomock.Any(), grades).Return(grades, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description: "Other scale",
+			url:         "/v2/class/3/gradebook/grades?scale=pass",
+			mock: func(a *mock_repository.MockAssignmentPgRepo, g *mock_repository.MockGradePgRepo) {
+				a.EXPECT().GetGradebook(gomock.Any(), int64(3), nil).Return(term, assignments, submissions[:2], nil)
+				grades := []models.Grade{{ClassInfoID: 5, Score: 83.33, Letter: "P", GradePoints: 1, Scale: "pass"}}
+				g.EXPECT().SetAll(gomock.Any(), grades).Return(grades, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "Unknown scale",
+			url:          "/v2/class/3/gradebook/grades?scale=ib",
+			mock:         func(a *mock_repository.MockAssignmentPgRepo, g *mock_repository.MockGradePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Term closed",
+			url:         "/v2/class/3/gradebook/grades",
+			mock: func(a *mock_repository.MockAssignmentPgRepo, g *mock_repository.MockGradePgRepo) {
+				a.EXPECT().GetGradebook(gomock.Any(), int64(3), nil).Return(term, assignments, submissions, nil)
+				g.EXPECT().SetAll(gomock.Any(), gomock.Any()).Return(nil, pkgErrors.ErrTermClosed)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			assignmentRepo := mock_repository.NewMockAssignmentPgRepo(ctrl)
+			gradeRepo := mock_repository.NewMockGradePgRepo(ctrl)
+			tc.mock(assignmentRepo, gradeRepo)
+			req := httptest.NewRequest(http.MethodPost, tc.url, nil)
+			rr := httptest.NewRecorder()
+			// act
+			newAssignmentRouter(assignmentRepo, gradeRepo).ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestAssignmentHandler_RollUpNeedsGrades(t *testing.T) {
+	t.Parallel()
+	// arrange
+	ctrl := gomock.NewController(t)
+	router := NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithAssignments(mock_repository.NewMockAssignmentPgRepo(ctrl)))
+	req := httptest.NewRequest(http.MethodPost, "/v2/class/3/gradebook/grades", nil)
+	rr := httptest.NewRecorder()
+	// act
+	router.ServeHTTP(rr, req)
+	// assert
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/codec"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+)
+
+// maxAttendanceNote is the longest note, in characters, that can be kept with an attendance record.
+const maxAttendanceNote = 500
+
+var attendanceStatuses = map[string]bool{
+	models.AttendancePresent: true,
+	models.AttendanceAbsent:  true,
+	models.AttendanceLate:    true,
+	models.AttendanceExcused: true,
+}
+
+// AttendanceHandler serves the v2 class session and attendance endpoints, and attendance rate reports.
+type AttendanceHandler struct {
+	attendanceStorage repository.AttendancePgRepo
+	queryParamKey     string
+	links             *links
+}
+
+// NewAttendanceHandler creates a new AttendanceHandler with the given attendance storage.
+// Links are built from the named routes of router.
+func NewAttendanceHandler(attendanceStorage repository.AttendancePgRepo, queryParamKey string, router *mux.Router) *AttendanceHandler {
+	return &AttendanceHandler{
+		attendanceStorage: attendanceStorage,
+		queryParamKey:     queryParamKey,
+		links:             newLinks(router, queryParamKey),
+	}
+}
+
+// CreateSession schedules a session of the class in the path.
+func (h *AttendanceHandler) CreateSession(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var session models.ClassSession
+	if !decodeBody(w, req, &session) {
+		return
+	}
+
+	if session.StartsAt.IsZero() || session.EndsAt.IsZero() {
+		problem.Write(w, req, http.StatusBadRequest, "starts_at and ends_at are required")
+		return
+	}
+
+	if !session.EndsAt.After(session.StartsAt) {
+		problem.Write(w, req, http.StatusBadRequest, "ends_at must be after starts_at")
+		return
+	}
+
+	session.ClassID = classID
+
+	id, err := h.attendanceStorage.AddSession(req.Context(), session)
+	if err != nil {
+		writeStorageError(w, req, err, "class_session")
+		return
+	}
+
+	created, err := h.attendanceStorage.GetSession(req.Context(), id)
+	if err != nil {
+		writeStorageError(w, req, err, "class_session")
+		return
+	}
+
+	w.Header().Set("Location", h.links.session(id))
+	writeResponse(w, responseCodec, http.StatusCreated, models.Envelope{Data: created})
+}
+
+// ListSessions lists the sessions of the class in the path, between the optional from and to dates.
+func (h *AttendanceHandler) ListSessions(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseDateRange(w, req)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.attendanceStorage.ListSessions(req.Context(), classID, from, to)
+	if err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	if sessions == nil {
+		sessions = []models.ClassSession{}
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: sessions})
+}
+
+func (h *AttendanceHandler) GetSession(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	session, err := h.attendanceStorage.GetSession(req.Context(), sessionID)
+	if err != nil {
+		writeStorageError(w, req, err, "class_session")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: session})
+}
+
+func (h *AttendanceHandler) DeleteSession(w http.ResponseWriter, req *http.Request) {
+	sessionID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	if err := h.attendanceStorage.DeleteSession(req.Context(), sessionID); err != nil {
+		writeStorageError(w, req, err, "class_session")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAttendance lists the roster of the session in the path with the attendance of each student.
+func (h *AttendanceHandler) GetAttendance(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	h.writeAttendance(w, req, responseCodec, sessionID)
+}
+
+// MarkAttendance marks the session in the path in bulk and returns its attendance. Records set the
+// attendance of their students; default_status marks every other student who has not been marked yet.
+func (h *AttendanceHandler) MarkAttendance(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var marking models.AttendanceMarking
+	if !decodeBody(w, req, &marking) {
+		return
+	}
+
+	if detail := validateMarking(marking); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	if err := h.attendanceStorage.Mark(req.Context(), sessionID, marking); err != nil {
+		if errors.Is(err, pkgErrors.ErrNotEnrolled) {
+			problem.Write(w, req, http.StatusUnprocessableEntity, "every marked student must be enrolled in the class of the session")
+			return
+		}
+
+		writeStorageError(w, req, err, "class_session")
+
+		return
+	}
+
+	h.writeAttendance(w, req, responseCodec, sessionID)
+}
+
+// StudentReport reports the attendance rate of the student in the path in each of their classes,
+// over the sessions between the optional from and to dates.
+func (h *AttendanceHandler) StudentReport(w http.ResponseWriter, req *http.Request) {
+	h.report(w, req, "student", h.attendanceStorage.GetStudentRates)
+}
+
+// ClassReport reports the attendance rate of each student enrolled in the class in the path,
+// over the sessions between the optional from and to dates.
+func (h *AttendanceHandler) ClassReport(w http.ResponseWriter, req *http.Request) {
+	h.report(w, req, "class", h.attendanceStorage.GetClassRates)
+}
+
+func (h *AttendanceHandler) report(
+	w http.ResponseWriter,
+	req *http.Request,
+	resource string,
+	rates func(ctx context.Context, id int64, from, to *time.Time) ([]models.AttendanceRate, error),
+) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	id, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseDateRange(w, req)
+	if !ok {
+		return
+	}
+
+	counts, err := rates(req.Context(), id, from, to)
+	if err != nil {
+		writeStorageError(w, req, err, resource)
+		return
+	}
+
+	report := models.AttendanceReport{
+		From:  req.URL.Query().Get("from"),
+		To:    req.URL.Query().Get("to"),
+		Rates: make([]models.AttendanceRate, 0, len(counts)),
+	}
+
+	for _, count := range counts {
+		count.Rate = attendanceRate(count)
+		report.Rates = append(report.Rates, count)
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: report})
+}
+
+func (h *AttendanceHandler) writeAttendance(w http.ResponseWriter, req *http.Request, responseCodec codec.Codec, sessionID int64) {
+	records, err := h.attendanceStorage.GetAttendance(req.Context(), sessionID)
+	if err != nil {
+		writeStorageError(w, req, err, "class_session")
+		return
+	}
+
+	if records == nil {
+		records = []models.AttendanceRecord{}
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: records})
+}
+
+// validateMarking returns why marking cannot be recorded, or "" when it can.
+func validateMarking(marking models.AttendanceMarking) string {
+	statuses := fmt.Sprintf(
+		"%s, %s, %s or %s",
+		models.AttendancePresent, models.AttendanceAbsent, models.AttendanceLate, models.AttendanceExcused,
+	)
+
+	if marking.DefaultStatus != "" && !attendanceStatuses[marking.DefaultStatus] {
+		return "default_status must be " + statuses
+	}
+
+	if marking.DefaultStatus == "" && len(marking.Records) == 0 {
+		return "records or default_status is required"
+	}
+
+	seen := make(map[int64]bool, len(marking.Records))
+	for _, record := range marking.Records {
+		switch {
+		case record.StudentID <= 0:
+			return "every record needs a student_id"
+		case seen[record.StudentID]:
+			return fmt.Sprintf("student %d is marked twice", record.StudentID)
+		case !attendanceStatuses[record.Status]:
+			return "status must be " + statuses
+		case utf8.RuneCountInString(record.Note) > maxAttendanceNote:
+			return fmt.Sprintf("note must be at most %d characters", maxAttendanceNote)
+		}
+
+		seen[record.StudentID] = true
+	}
+
+	return ""
+}
+
+// attendanceRate returns the share of present and late among the present, late and absent marks,
+// rounded to four decimals, or nil when there are none.
+func attendanceRate(rate models.AttendanceRate) *float64 {
+	counted := rate.Present + rate.Late + rate.Absent
+	if counted == 0 {
+		return nil
+	}
+
+	share := math.Round(float64(rate.Present+rate.Late)/float64(counted)*10000) / 10000
+
+	return &share
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newAttendanceRouter(attendanceStorage *mock_repository.MockAttendancePgRepo) http.Handler {
+	return NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithAttendance(attendanceStorage))
+}
+
+func dateOf(value string) *time.Time {
+	parsed, _ := time.Parse(models.DateLayout, value)
+	return &parsed
+}
+
+func share(value float64) *float64 {
+	return &value
+}
+
+func TestAttendanceHandler_CreateSession(t *testing.T) {
+	t.Parallel()
+	startsAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(90 * time.Minute)
+	tests := []struct {
+		description      string
+		body             string
+		mock             func(m *mock_repository.MockAttendancePgRepo)
+		expectedCode     int
+		expectedLocation string
+	}{
+		{
+			description: "Created",
+			body:        `{"starts_at": "2026-10-19T09:00:00Z", "ends_at": "2026-10-19T10:30:00Z"}`,
+			mock: func(m *mock_repository.MockAttendancePgRepo) {
+				m.EXPECT().AddSession(gomock.Any(), models.ClassSession{ClassID: 3, StartsAt: startsAt, EndsAt: endsAt}).
+					Return(int64(11), nil)
+				m.EXPECT().GetSession(gomock.Any(), int64(11)).Return(models.ClassSession{
+					SessionID: 11, ClassID: 3, ClassName: "Math", StartsAt: startsAt, EndsAt: endsAt,
+				}, nil)
+			},
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/v2/session/11",
+		},
+		{
+			description:  "Ends before it starts",
+			body:         `{"starts_at": "2026-10-19T09:00:00Z", "ends_at": "2026-10-19T08:00:00Z"}`,
+			mock:         func(m *mock_repository.MockAttendancePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Times missing",
+			body:         `{}`,
+			mock:         func(m *mock_repository.MockAttendancePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Class not found",
+			body:        `{"starts_at": "2026-10-19T09:00:00Z", "ends_at": "2026-10-19T10:30:00Z"}`,
+			mock: func(m *mock_repository.MockAttendancePgRepo) {
+				m.EXPECT().AddSession(gomock.Any(), gomock.Any()).Return(int64(-1), pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			description: "Same start as another session",
+			body:        `{"starts_at": "2026-10-19T09:00:00Z", "ends_at": "2026-10-19T10:30:00Z"}`,
+			mock: func(m *mock_repository.MockAttendancePgRepo) {
+				m.EXPECT().AddSession(gomock.Any(), gomock.Any()).Return(int64(-1), pkgErrors.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockAttendancePgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(http.MethodPost, "/v2/class/3/sessions", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			newAttendanceRouter(mockRepo).ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			assert.Equal(t, tc.expectedLocation, rr.Header().Get("Location"))
+		})
+	}
+}
+
+func TestAttendanceHandler_MarkAttendance(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description  string
+		body         string
+		mock         func(m *mock_repository.MockAttendancePgRepo)
+		expectedCode int
+	}{
+		{
+			description: "Marked in bulk",
+			body:        `{"default_status": "present", "records": [{"student_id": 7, "status": "late", "note": "bus"}]}`,
+			mock: func(m *mock_repository.MockAttendancePgRepo) {
+				m.EXPECT().Mark(gomock.Any(), int64(11), models.AttendanceMarking{
+					DefaultStatus: models.AttendancePresent,
+					Records:       []models.AttendanceRecord{{StudentID: 7, Status: models.AttendanceLate, Note: "bus"}},
+				}).Return(nil)
+				m.EXPECT().GetAttendance(gomock.Any(), int64(11)).Return([]models.AttendanceRecord{
+					{StudentID: 7, Status: models.AttendanceLate, Note: "bus"},
+					{StudentID: 8, Status: models.AttendancePresent},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "Nothing to mark",
+			body:         `{"records": []}`,
+			mock:         func(m *mock_repository.MockAttendancePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Unknown status",
+			body:         `{"records": [{"student_id": 7, "status": "asleep"}]}`,
+			mock:         func(m *mock_repository.MockAttendancePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Unknown default status",
+			body:         `{"default_status": "asleep"}`,
+			mock:         func(m *mock_repository.MockAttendancePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Student marked twice",
+			body:         `{"records": [{"student_id": 7, "status": "late"}, {"student_id": 7, "status": "absent"}]}`,
+			mock:         func(m *mock_repository.MockAttendancePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Note too long",
+			body:         `{"records": [{"student_id": 7, "status": "excused", "note": "` + strings.Repeat("a", maxAttendanceNote+1) + `"}]}`,
+			mock:         func(m *mock_repository.MockAttendancePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Student not enrolled",
+			body:        `{"records": [{"student_id": 9, "status": "absent"}]}`,
+			mock: func(m *mock_repository.MockAttendancePgRepo) {
+				m.EXPECT().Mark(gomock.Any(), int64(11), gomock.Any()).Return(pkgErrors.ErrNotEnrolled)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			description: "Session not found",
+			body:        `{"default_status": "absent"}`,
+			mock: func(m *mock_repository.MockAttendancePgRepo) {
+				m.EXPECT().Mark(gomock.Any(), int64(11), gomock.Any()).Return(pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockAttendancePgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(http.MethodPut, "/v2/session/11/attendance", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			newAttendanceRouter(mockRepo).ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestAttendanceHandler_Reports(t *testing.T) {
+	t.Parallel()
+	counts := []models.AttendanceRate{
+		{StudentID: 7, ClassID: 3, ClassName: "Math", Sessions: 10, Present: 6, Late: 1, Absent: 2, Excused: 1},
+		{StudentID: 7, ClassID: 4, ClassName: "Art", Sessions: 2, Excused: 1, Unmarked: 1},
+	}
+	tests := []struct {
+		description   string
+		url           string
+		mock          func(m *mock_repository.MockAttendancePgRepo)
+		expectedCode  int
+		expectedRates []*float64
+	}{
+		{
+			description: "Student over a date range",
+			url:         "/v2/student/7/attendance?from=2026-09-01&to=2026-10-31",
+			mock: func(m *mock_repository.MockAttendancePgRepo) {
+				m.EXPECT().GetStudentRates(gomock.Any(), int64(7), dateOf("2026-09-01"), dateOf("2026-10-31")).Return(counts, nil)
+			},
+			expectedCode:  http.StatusOK,
+			expectedRates: []*float64{share(0.7778), nil},
+		},
+		{
+			description: "Class without range",
+			url:         "/v2/class/3/attendance",
+			mock: func(m *mock_repository.MockAttendancePgRepo) {
+				m.EXPECT().GetClassRates(gomock.Any(), int64(3), nil, nil).Return(counts[:1], nil)
+			},
+			expectedCode:  http.StatusOK,
+			expectedRates: []*float64{share(0.7778)},
+		},
+		{
+			description:  "Malformed date",
+			url:          "/v2/student/7/attendance?from=09/01/2026",
+			mock:         func(m *mock_repository.MockAttendancePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Range ends before it starts",
+			url:          "/v2/class/3/attendance?from=2026-10-31&to=2026-09-01",
+			mock:         func(m *mock_repository.MockAttendancePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Student not found",
+			url:         "/v2/student/7/attendance",
+			mock: func(m *mock_repository.MockAttendancePgRepo) {
+				m.EXPECT().GetStudentRates(gomock.Any(), int64(7), nil, nil).Return(nil, pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockAttendancePgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			rr := httptest.NewRecorder()
+			// act
+			newAttendanceRouter(mockRepo).ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var actual struct {
+				Data models.AttendanceReport `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			require.Len(t, actual.Data.Rates, len(tc.expectedRates))
+			for i, rate := range actual.Data.Rates {
+				assert.Equal(t, tc.expectedRates[i], rate.Rate)
+			}
+		})
+	}
+}
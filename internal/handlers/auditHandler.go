@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/repository"
+	"fmt"
+	"net/http"
+)
+
+// AuditHandler serves the audit trail to admins.
+type AuditHandler struct {
+	auditStorage repository.AuditPgRepo
+}
+
+// NewAuditHandler creates a new AuditHandler with the given audit storage.
+func NewAuditHandler(auditStorage repository.AuditPgRepo) *AuditHandler {
+	return &AuditHandler{
+		auditStorage: auditStorage,
+	}
+}
+
+func (h *AuditHandler) List(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	events, err := h.auditStorage.List(req.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list audit events: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, events)
+}
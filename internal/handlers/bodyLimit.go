@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// limitBody caps request bodies at maxBytes. Requests that announce a larger Content-Length are
+// rejected up front; others fail in readBody once they cross the limit.
+func limitBody(maxBytes int64) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.ContentLength > maxBytes {
+				writeBodyTooLarge(w, req, maxBytes)
+				return
+			}
+
+			req.Body = http.MaxBytesReader(w, req.Body, maxBytes)
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// readBody reads the whole request body. On failure it writes the response and returns false.
+func readBody(w http.ResponseWriter, req *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeBodyTooLarge(w, req, maxBytesErr.Limit)
+			return nil, false
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to read request body: %v", err), http.StatusInternalServerError)
+
+		return nil, false
+	}
+
+	return body, true
+}
+
+func writeBodyTooLarge(w http.ResponseWriter, req *http.Request, maxBytes int64) {
+	problem.Write(w, req, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", maxBytes))
+}
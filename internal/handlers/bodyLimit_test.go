@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/pkg/problem"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLimitBody(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description   string
+		body          string
+		contentLength int64
+		expectedCode  int
+	}{
+		{
+			description:   "Body within limit",
+			body:          `{"student_name": 1}`,
+			contentLength: -1,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			description:   "Declared length over limit",
+			body:          strings.Repeat("a", 64),
+			contentLength: 64,
+			expectedCode:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			description:   "Streamed body over limit",
+			body:          strings.Repeat("a", 64),
+			contentLength: -1,
+			expectedCode:  http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// arrange
+			studentHandler := NewStudentHandler(mock_repository.NewMockStudentPgRepo(ctrl), "id")
+			handler := limitBody(32)(http.HandlerFunc(studentHandler.Create))
+			req := httptest.NewRequest(http.MethodPost, "/student", strings.NewReader(tc.body))
+			req.ContentLength = tc.contentLength
+			rr := httptest.NewRecorder()
+			// act
+			handler.ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			if tc.expectedCode == http.StatusRequestEntityTooLarge {
+				assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+	"math"
+	"net/http"
+)
+
+const (
+	// maxCredits is the largest number of credits a class can carry, the limit of its NUMERIC(4, 1) column.
+	maxCredits = 999.9
+	// maxCapacity is the largest capacity of a class, the limit of its INT column.
+	maxCapacity = math.MaxInt32
+)
+
+// ClassHandler serves the v2 class catalog, which sets the credits that weight grades and the capacity
+// that caps enrollments.
+type ClassHandler struct {
+	classStorage  repository.ClassPgRepo
+	queryParamKey string
+}
+
+// NewClassHandler creates a new ClassHandler with the given class storage.
+func NewClassHandler(classStorage repository.ClassPgRepo, queryParamKey string) *ClassHandler {
+	return &ClassHandler{
+		classStorage:  classStorage,
+		queryParamKey: queryParamKey,
+	}
+}
+
+func (h *ClassHandler) List(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classes, err := h.classStorage.List(req.Context())
+	if err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: classes})
+}
+
+func (h *ClassHandler) Get(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	class, err := h.classStorage.GetByID(req.Context(), classID)
+	if err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: class})
+}
+
+// Update sets the credits and capacity of the class in the path; a class without capacity is unlimited.
+// The name identifies enrollments and cannot be changed.
+func (h *ClassHandler) Update(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var class models.Class
+	if !decodeBody(w, req, &class) {
+		return
+	}
+
+	if class.Credits < 0 || class.Credits > maxCredits {
+		problem.Write(w, req, http.StatusBadRequest, "credits must be between 0 and 999.9")
+		return
+	}
+
+	if class.Capacity != nil && (*class.Capacity < 0 || *class.Capacity > maxCapacity) {
+		problem.Write(w, req, http.StatusBadRequest, "capacity must be between 0 and 2147483647")
+		return
+	}
+
+	// Credits are stored with one decimal.
+	class.Credits = math.Round(class.Credits*10) / 10
+
+	current, err := h.classStorage.GetByID(req.Context(), classID)
+	if err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	if class.ClassName != "" && class.ClassName != current.ClassName {
+		problem.Write(w, req, http.StatusBadRequest, "class_name cannot be changed")
+		return
+	}
+
+	if err := h.classStorage.Update(req.Context(), classID, class); err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	current.Credits, current.Capacity = class.Credits, class.Capacity
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: current})
+}
+
+// Events lists the enrollment events of the class in the path: enrollments, waitlistings, promotions and drops.
+func (h *ClassHandler) Events(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	events, err := h.classStorage.ListEvents(req.Context(), classID)
+	if err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	if events == nil {
+		events = []models.EnrollmentEvent{}
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: events})
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClassHandler_Update(t *testing.T) {
+	t.Parallel()
+	mathClass := models.Class{ClassID: 3, ClassName: "Math", Credits: 1}
+	capacity := int64(25)
+	tests := []struct {
+		description      string
+		body             string
+		mock             func(m *mock_repository.MockClassPgRepo)
+		expectedCode     int
+		expectedCredits  float64
+		expectedCapacity *int64
+	}{
+		{
+			description: "Credits set",
+			body:        `{"credits": 3.25}`,
+			mock: func(m *mock_repository.MockClassPgRepo) {
+				m.EXPECT().GetByID(gomock.Any(), int64(3)).Return(mathClass, nil)
+				m.EXPECT().Update(gomock.Any(), int64(3), models.Class{Credits: 3.3}).Return(nil)
+			},
+			expectedCode:    http.StatusOK,
+			expectedCredits: 3.3,
+		},
+		{
+			description: "Capacity set",
+			body:        `{"credits": 1, "capacity": 25}`,
+			mock: func(m *mock_repository.MockClassPgRepo) {
+				m.EXPECT().GetByID(gomock.Any(), int64(3)).Return(mathClass, nil)
+				m.EXPECT().Update(gomock.Any(), int64(3), models.Class{Credits: 1, Capacity: &capacity}).Return(nil)
+			},
+			expectedCode:     http.StatusOK,
+			expectedCredits:  1,
+			expectedCapacity: &capacity,
+		},
+		{
+			description:  "Negative credits",
+			body:         `{"credits": -1}`,
+			mock:         func(m *mock_repository.MockClassPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Negative capacity",
+			body:         `{"credits": 1, "capacity": -1}`,
+			mock:         func(m *mock_repository.MockClassPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Renamed",
+			body:        `{"class_name": "Maths", "credits": 3}`,
+			mock: func(m *mock_repository.MockClassPgRepo) {
+				m.EXPECT().GetByID(gomock.Any(), int64(3)).Return(mathClass, nil)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Class not found",
+			body:        `{"credits": 3}`,
+			mock: func(m *mock_repository.MockClassPgRepo) {
+				m.EXPECT().GetByID(gomock.Any(), int64(3)).Return(models.Class{}, pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockClassPgRepo(ctrl)
+			tc.mock(mockRepo)
+			router := newGradeRouter(mock_repository.NewMockGradePgRepo(ctrl), mockRepo)
+			req := httptest.NewRequest(http.MethodPut, "/v2/class/3", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var actual struct {
+				Data models.Class `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, tc.expectedCredits, actual.Data.Credits)
+			assert.Equal(t, tc.expectedCapacity, actual.Data.Capacity)
+			assert.Equal(t, "Math", actual.Data.ClassName)
+		})
+	}
+}
+
+func TestClassHandler_Events(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description    string
+		mockEvents     []models.EnrollmentEvent
+		mockError      error
+		expectedCode   int
+		expectedEvents []string
+	}{
+		{
+			description: "Success",
+			mockEvents: []models.EnrollmentEvent{
+				{EventID: 1, ClassInfoID: 7, StudentID: 1, ClassName: "Math", TermID: 1, Event: models.EnrollmentEventWaitlisted},
+				{EventID: 2, ClassInfoID: 6, StudentID: 2, ClassName: "Math", TermID: 1, Event: models.EnrollmentEventDropped},
+				{EventID: 3, ClassInfoID: 7, StudentID: 1, ClassName: "Math", TermID: 1, Event: models.EnrollmentEventPromoted},
+			},
+			expectedCode:   http.StatusOK,
+			expectedEvents: []string{"waitlisted", "dropped", "promoted"},
+		},
+		{
+			description:    "No events",
+			expectedCode:   http.StatusOK,
+			expectedEvents: []string{},
+		},
+		{
+			description:  "Class not found",
+			mockError:    pkgErrors.ErrNotFound,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockClassPgRepo(ctrl)
+			mockRepo.EXPECT().ListEvents(gomock.Any(), int64(3)).Return(tc.mockEvents, tc.mockError)
+			router := newGradeRouter(mock_repository.NewMockGradePgRepo(ctrl), mockRepo)
+			req := httptest.NewRequest(http.MethodGet, "/v2/class/3/events", nil)
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var actual struct {
+				Data []models.EnrollmentEvent `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			events := make([]string, 0, len(actual.Data))
+			for _, event := range actual.Data {
+				events = append(events, event.Event)
+			}
+			assert.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
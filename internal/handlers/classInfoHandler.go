@@ -4,10 +4,8 @@ import (
 	"CRUD_Go_Backend/internal/handlers/models"
 	"CRUD_Go_Backend/internal/pkg/pkgErrors"
 	"CRUD_Go_Backend/internal/repository"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 
@@ -17,6 +15,7 @@ import (
 // ClassInfoHandler handles class information-related HTTP requests.
 type ClassInfoHandler struct {
 	classInfoStorage repository.ClassInfoPgRepo
+	prerequisites    *prerequisiteGate // nil unless the router has WithPrerequisites
 	queryParamKey    string
 }
 
@@ -29,54 +28,84 @@ func NewClassInfoHandler(classInfoStorage repository.ClassInfoPgRepo, queryParam
 }
 
 func (h *ClassInfoHandler) AddClass(w http.ResponseWriter, req *http.Request) {
-	body, err := io.ReadAll(req.Body)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to read request body: %v", err), http.StatusInternalServerError)
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
 		return
 	}
 
 	var classInfo models.ClassInfo
-	if err := json.Unmarshal(body, &classInfo); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to unmarshal JSON: %v", err), http.StatusBadRequest)
+	if !decodeBody(w, req, &classInfo) {
 		return
 	}
 
-	classInfo.ID, err = h.classInfoStorage.Add(req.Context(), classInfo)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to add class_info: %v", err), http.StatusInternalServerError)
+	override, ok := h.checkPrerequisites(w, req, classInfo.StudentID, classInfo.ClassName)
+	if !ok {
 		return
 	}
 
-	classInfoJSON, err := json.Marshal(classInfo)
+	var err error
+
+	// v1 clients predate terms, so they keep enrolling between terms in the term that ended last.
+	if classInfo.TermID == 0 {
+		classInfo.TermID, err = h.classInfoStorage.LegacyTermID(req.Context())
+	}
+
+	if err == nil {
+		classInfo.ID, err = h.classInfoStorage.Add(req.Context(), classInfo)
+	}
+
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to marshal JSON response: %v", err), http.StatusInternalServerError)
+		if errors.Is(err, pkgErrors.ErrNoCurrentTerm) || errors.Is(err, pkgErrors.ErrTermClosed) ||
+			errors.Is(err, pkgErrors.ErrStudentBooked) {
+			http.Error(w, fmt.Sprintf("Failed to add class_info: %v", err), http.StatusConflict)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to add class_info: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	h.prerequisites.recordOverride(req.Context(), override)
 
-	_, err = w.Write(classInfoJSON)
+	// respond with the stored enrollment, which has its term and status
+	stored, err := h.classInfoStorage.GetByID(req.Context(), classInfo.ID)
 	if err != nil {
-		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Failed to get class_info: %v", err), http.StatusInternalServerError)
 		return
 	}
+
+	writeResponse(w, responseCodec, http.StatusOK, stored)
 }
 
 func (h *ClassInfoHandler) UpdateClass(w http.ResponseWriter, req *http.Request) {
-	body, err := io.ReadAll(req.Body)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to read request body: %v", err), http.StatusInternalServerError)
+	var classInfo models.ClassInfo // 1
+	if !decodeBody(w, req, &classInfo) {
 		return
 	}
 
-	var classInfo models.ClassInfo // 1
-	if err := json.Unmarshal(body, &classInfo); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to unmarshal JSON: %v", err), http.StatusBadRequest)
+	override, ok := h.checkPrerequisites(w, req, classInfo.StudentID, classInfo.ClassName)
+	if !ok {
 		return
 	}
 
-	err = h.classInfoStorage.Update(req.Context(), classInfo.StudentID, classInfo)
+	var err error
+
+	// like AddClass, only the enrollments of the term v1 clients enroll into are moved
+	if classInfo.TermID == 0 {
+		classInfo.TermID, err = h.classInfoStorage.LegacyTermID(req.Context())
+	}
+
+	if err == nil {
+		err = h.classInfoStorage.Update(req.Context(), classInfo.StudentID, classInfo)
+	}
+
 	if err != nil {
+		if errors.Is(err, pkgErrors.ErrNoCurrentTerm) || errors.Is(err, pkgErrors.ErrTermClosed) ||
+			errors.Is(err, pkgErrors.ErrStudentBooked) {
+			http.Error(w, fmt.Sprintf("Failed to update class_info: %v", err), http.StatusConflict)
+			return
+		}
+
 		if errors.Is(err, pkgErrors.ErrNotFound) {
 			http.Error(
 				w,
@@ -92,6 +121,8 @@ func (h *ClassInfoHandler) UpdateClass(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
+	h.prerequisites.recordOverride(req.Context(), override)
+
 	w.WriteHeader(http.StatusOK)
 
 	message := "Successfully Updated Class Info"
@@ -116,8 +147,18 @@ func (h *ClassInfoHandler) DeleteClassByStudent(w http.ResponseWriter, req *http
 		return
 	}
 
-	err = h.classInfoStorage.DeleteClassByStudentID(req.Context(), keyInt)
+	// like AddClass, only the enrollments of the term v1 clients enroll into are dropped
+	termID, err := h.classInfoStorage.LegacyTermID(req.Context())
+	if err == nil {
+		err = h.classInfoStorage.DeleteClassByStudentID(req.Context(), keyInt, &termID)
+	}
+
 	if err != nil {
+		if errors.Is(err, pkgErrors.ErrNoCurrentTerm) || errors.Is(err, pkgErrors.ErrTermClosed) {
+			http.Error(w, fmt.Sprintf("Failed to delete class_info: %v", err), http.StatusConflict)
+			return
+		}
+
 		if errors.Is(err, pkgErrors.ErrNotFound) {
 			http.Error(
 				w,
@@ -149,6 +190,11 @@ func (h *ClassInfoHandler) DeleteClassByStudent(w http.ResponseWriter, req *http
 }
 
 func (h *ClassInfoHandler) GetAllClassesByStudent(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
 	key, ok := mux.Vars(req)[h.queryParamKey]
 	if !ok {
 		http.Error(w, "Invalid request. Missing query parameter.", http.StatusBadRequest)
@@ -178,17 +224,27 @@ func (h *ClassInfoHandler) GetAllClassesByStudent(w http.ResponseWriter, req *ht
 		return
 	}
 
-	userInfoJSON, err := json.Marshal(classesInfo)
+	writeResponse(w, responseCodec, http.StatusOK, classesInfo)
+}
+
+// checkPrerequisites answers 422 with the missing prerequisites when the student cannot enroll in className,
+// and reports whether the enrollment may go ahead, with the override to record once it succeeded.
+func (h *ClassInfoHandler) checkPrerequisites(
+	w http.ResponseWriter,
+	req *http.Request,
+	studentID int64,
+	className string,
+) (*models.AuditEvent, bool) {
+	missing, override, err := h.prerequisites.check(req, studentID, className)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to marshal JSON response: %v", err), http.StatusInternalServerError)
-		return
+		http.Error(w, fmt.Sprintf("Failed to check prerequisites: %v", err), http.StatusInternalServerError)
+		return nil, false
 	}
 
-	w.WriteHeader(http.StatusOK)
-
-	_, err = w.Write(userInfoJSON)
-	if err != nil {
-		http.Error(w, "Failed to write response", http.StatusInternalServerError)
-		return
+	if len(missing) > 0 {
+		http.Error(w, "Missing prerequisites: "+describePrerequisites(missing), http.StatusUnprocessableEntity)
+		return nil, false
 	}
+
+	return override, true
 }
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/codec"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// ClassInfoHandlerV2 serves the v2 class_info contract. Unlike v1, single classes are addressed
+// by their own id, and the classes of a student live under /v2/student/{id}/class_info.
+type ClassInfoHandlerV2 struct {
+	classInfoStorage repository.ClassInfoPgRepo
+	prerequisites    *prerequisiteGate // nil unless the router has WithPrerequisites
+	queryParamKey    string
+	links            *links
+}
+
+// NewClassInfoHandlerV2 creates a new ClassInfoHandlerV2 with the given class_info storage service.
+// Links are built from the named routes of router.
+func NewClassInfoHandlerV2(
+	classInfoStorage repository.ClassInfoPgRepo,
+	queryParamKey string,
+	router *mux.Router,
+) *ClassInfoHandlerV2 {
+	return &ClassInfoHandlerV2{
+		classInfoStorage: classInfoStorage,
+		queryParamKey:    queryParamKey,
+		links:            newLinks(router, queryParamKey),
+	}
+}
+
+func (h *ClassInfoHandlerV2) Create(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req, codec.HAL)
+	if !ok {
+		return
+	}
+
+	var classInfo models.ClassInfo
+	if !decodeBody(w, req, &classInfo) {
+		return
+	}
+
+	override, ok := h.checkPrerequisites(w, req, classInfo.StudentID, classInfo.ClassName)
+	if !ok {
+		return
+	}
+
+	id, err := h.classInfoStorage.Add(req.Context(), classInfo)
+	if err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return
+	}
+
+	h.prerequisites.recordOverride(req.Context(), override)
+
+	// Read back the stored class, since the term defaults to the current one.
+	created, err := h.classInfoStorage.GetByID(req.Context(), id)
+	if err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return
+	}
+
+	w.Header().Set("Location", h.links.classInfo(id))
+	h.writeClassInfo(w, responseCodec, http.StatusCreated, created)
+}
+
+func (h *ClassInfoHandlerV2) Get(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req, codec.HAL)
+	if !ok {
+		return
+	}
+
+	id, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	classInfo, err := h.classInfoStorage.GetByID(req.Context(), id)
+	if err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return
+	}
+
+	h.writeClassInfo(w, responseCodec, http.StatusOK, classInfo)
+}
+
+// ListByStudent pages through the classes of the student in the path. The term query parameter selects
+// the academic term: "current" (the default), "all" or a term id.
+func (h *ClassInfoHandlerV2) ListByStudent(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req, codec.HAL)
+	if !ok {
+		return
+	}
+
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	p, ok := parsePage(w, req)
+	if !ok {
+		return
+	}
+
+	var (
+		classesInfo []models.ClassInfo
+		err         error
+	)
+
+	switch term := req.URL.Query().Get("term"); term {
+	case "", "current":
+		classesInfo, err = h.classInfoStorage.GetByStudentIDInTerm(req.Context(), studentID, nil)
+	case "all":
+		classesInfo, err = h.classInfoStorage.GetByStudentID(req.Context(), studentID)
+	default:
+		termID, parseErr := strconv.ParseInt(term, 10, 64)
+		if parseErr != nil {
+			problem.Write(w, req, http.StatusBadRequest, `term must be "current", "all" or a term id`)
+			return
+		}
+
+		classesInfo, err = h.classInfoStorage.GetByStudentIDInTerm(req.Context(), studentID, &termID)
+	}
+
+	if err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return
+	}
+
+	total := int64(len(classesInfo))
+	pageItems := make([]models.ClassInfo, 0, p.limit)
+
+	if p.offset < total {
+		end := p.offset + p.limit
+		if end > total {
+			end = total
+		}
+
+		pageItems = append(pageItems, classesInfo[p.offset:end]...)
+	}
+
+	if responseCodec != codec.HAL {
+		writeResponse(w, responseCodec, http.StatusOK, listEnvelope(req, pageItems, len(pageItems), total, p))
+		return
+	}
+
+	var collection models.ClassInfoCollection
+	collection.Links = halPageLinks(h.links.studentClasses(studentID), req, total, p)
+	collection.Links["student"] = models.HALLink{Href: h.links.student(studentID)}
+	collection.ListMeta = models.ListMeta{Total: total, Count: len(pageItems), Limit: p.limit, Offset: p.offset}
+	collection.Embedded.Classes = h.links.classInfoResources(pageItems)
+
+	writeResponse(w, responseCodec, http.StatusOK, collection)
+}
+
+// Update renames the class in the path and returns it.
+func (h *ClassInfoHandlerV2) Update(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req, codec.HAL)
+	if !ok {
+		return
+	}
+
+	id, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var classInfo models.ClassInfo
+	if !decodeBody(w, req, &classInfo) {
+		return
+	}
+
+	var override *models.AuditEvent
+
+	if h.prerequisites != nil {
+		current, err := h.classInfoStorage.GetByID(req.Context(), id)
+		if err != nil {
+			writeStorageError(w, req, err, "class_info")
+			return
+		}
+
+		if current.ClassName != classInfo.ClassName {
+			if override, ok = h.checkPrerequisites(w, req, current.StudentID, classInfo.ClassName); !ok {
+				return
+			}
+		}
+	}
+
+	if err := h.classInfoStorage.UpdateByID(req.Context(), id, classInfo); err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return
+	}
+
+	h.prerequisites.recordOverride(req.Context(), override)
+
+	updated, err := h.classInfoStorage.GetByID(req.Context(), id)
+	if err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return
+	}
+
+	h.writeClassInfo(w, responseCodec, http.StatusOK, updated)
+}
+
+func (h *ClassInfoHandlerV2) Delete(w http.ResponseWriter, req *http.Request) {
+	id, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	if err := h.classInfoStorage.DeleteByID(req.Context(), id); err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteByStudent removes every class of the student in the path.
+func (h *ClassInfoHandlerV2) DeleteByStudent(w http.ResponseWriter, req *http.Request) {
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	if err := h.classInfoStorage.DeleteClassByStudentID(req.Context(), studentID, nil); err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkPrerequisites answers 422 with the missing prerequisites when the student cannot enroll in className,
+// and reports whether the enrollment may go ahead, with the override to record once it succeeded.
+func (h *ClassInfoHandlerV2) checkPrerequisites(
+	w http.ResponseWriter,
+	req *http.Request,
+	studentID int64,
+	className string,
+) (*models.AuditEvent, bool) {
+	missing, override, err := h.prerequisites.check(req, studentID, className)
+	if err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return nil, false
+	}
+
+	if len(missing) > 0 {
+		details := problem.New(http.StatusUnprocessableEntity, "the student misses prerequisites of "+className)
+		details.Extensions = map[string]interface{}{"missing": missing}
+		problem.WriteDetails(w, req, details)
+
+		return nil, false
+	}
+
+	return override, true
+}
+
+// writeClassInfo renders classInfo as a HAL resource or as an envelope.
+func (h *ClassInfoHandlerV2) writeClassInfo(w http.ResponseWriter, responseCodec codec.Codec, status int, classInfo models.ClassInfo) {
+	if responseCodec == codec.HAL {
+		writeResponse(w, responseCodec, status, h.links.classInfoResource(classInfo))
+		return
+	}
+
+	writeResponse(w, responseCodec, status, models.Envelope{Data: classInfo})
+}
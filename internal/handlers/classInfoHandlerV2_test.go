@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClassInfoHandlerV2_Create(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+	classInfoHandler := NewClassInfoHandlerV2(mockRepo, queryParamKey, v2Routes())
+	classInfo := models.ClassInfo{StudentID: 1, ClassName: "Math"}
+	mockRepo.EXPECT().Add(gomock.Any(), classInfo).Return(int64(5), nil)
+	mockRepo.EXPECT().GetByID(gomock.Any(), int64(5)).Return(models.ClassInfo{ID: 5, StudentID: 1, ClassName: "Math", TermID: 3}, nil)
+
+	jsonData, err := json.Marshal(classInfo)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "/v2/class_info", bytes.NewReader(jsonData))
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	// act
+	classInfoHandler.Create(rr, req)
+	// assert
+	require.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "/v2/class_info/5", rr.Header().Get("Location"))
+
+	var actual struct {
+		Data models.ClassInfo `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+	assert.Equal(t, models.ClassInfo{ID: 5, StudentID: 1, ClassName: "Math", TermID: 3}, actual.Data)
+}
+
+func TestClassInfoHandlerV2_CreateTermErrors(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	tests := []struct {
+		description    string
+		mockError      error
+		expectedDetail string
+	}{
+		{
+			description:    "No current term",
+			mockError:      pkgErrors.ErrNoCurrentTerm,
+			expectedDetail: "no academic term covers the current date, set term_id",
+		},
+		{
+			description:    "Closed term",
+			mockError:      pkgErrors.ErrTermClosed,
+			expectedDetail: "the academic term of this class_info is closed",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+			classInfoHandler := NewClassInfoHandlerV2(mockRepo, queryParamKey, v2Routes())
+			mockRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(int64(-1), tc.mockError)
+
+			req, err := http.NewRequest(http.MethodPost, "/v2/class_info", bytes.NewReader([]byte(`{"student_id": 1, "class_name": "Math"}`)))
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			// act
+			classInfoHandler.Create(rr, req)
+			// assert
+			require.Equal(t, http.StatusConflict, rr.Code)
+			var actual problem.Details
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, tc.expectedDetail, actual.Detail)
+		})
+	}
+}
+
+func TestClassInfoHandlerV2_ListByStudent(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	classesInfo := []models.ClassInfo{
+		{ID: 1, StudentID: 2, ClassName: "Math"},
+		{ID: 2, StudentID: 2, ClassName: "Physics"},
+		{ID: 3, StudentID: 2, ClassName: "History"},
+	}
+	tests := []struct {
+		description   string
+		query         string
+		expectedData  []models.ClassInfo
+		expectedMeta  models.ListMeta
+		expectedLinks models.ListLinks
+	}{
+		{
+			description:   "First page",
+			query:         "?limit=2",
+			expectedData:  classesInfo[:2],
+			expectedMeta:  models.ListMeta{Total: 3, Count: 2, Limit: 2, Offset: 0},
+			expectedLinks: models.ListLinks{Self: "/v2/student/2/class_info?limit=2&offset=0", Next: "/v2/student/2/class_info?limit=2&offset=2"},
+		},
+		{
+			description:   "Offset past the end",
+			query:         "?offset=10",
+			expectedData:  []models.ClassInfo{},
+			expectedMeta:  models.ListMeta{Total: 3, Count: 0, Limit: defaultPageLimit, Offset: 10},
+			expectedLinks: models.ListLinks{Self: "/v2/student/2/class_info?limit=20&offset=10", Prev: "/v2/student/2/class_info?limit=20&offset=0"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+			classInfoHandler := NewClassInfoHandlerV2(mockRepo, queryParamKey, v2Routes())
+			mockRepo.EXPECT().GetByStudentIDInTerm(gomock.Any(), int64(2), gomock.Nil()).Return(classesInfo, nil)
+
+			req, err := http.NewRequest(http.MethodGet, "/v2/student/2/class_info"+tc.query, nil)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{queryParamKey: "2"})
+			rr := httptest.NewRecorder()
+			// act
+			classInfoHandler.ListByStudent(rr, req)
+			// assert
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var actual struct {
+				Data  []models.ClassInfo `json:"data"`
+				Meta  models.ListMeta    `json:"meta"`
+				Links models.ListLinks   `json:"links"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, tc.expectedData, actual.Data)
+			assert.Equal(t, tc.expectedMeta, actual.Meta)
+			assert.Equal(t, tc.expectedLinks, actual.Links)
+		})
+	}
+}
+
+func TestClassInfoHandlerV2_ListByStudentTerm(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	termID := int64(5)
+	tests := []struct {
+		description  string
+		query        string
+		mock         func(m *mock_repository.MockClassInfoPgRepo)
+		expectedCode int
+	}{
+		{
+			description: "Current term",
+			query:       "?term=current",
+			mock: func(m *mock_repository.MockClassInfoPgRepo) {
+				m.EXPECT().GetByStudentIDInTerm(gomock.Any(), int64(2), gomock.Nil()).Return(nil, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description: "Every term",
+			query:       "?term=all",
+			mock: func(m *mock_repository.MockClassInfoPgRepo) {
+				m.EXPECT().GetByStudentID(gomock.Any(), int64(2)).Return(nil, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description: "One term",
+			query:       "?term=5",
+			mock: func(m *mock_repository.MockClassInfoPgRepo) {
+				m.EXPECT().GetByStudentIDInTerm(gomock.Any(), int64(2), &termID).Return(nil, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "Invalid term",
+			query:        "?term=last",
+			mock:         func(m *mock_repository.MockClassInfoPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+			classInfoHandler := NewClassInfoHandlerV2(mockRepo, queryParamKey, v2Routes())
+			tc.mock(mockRepo)
+
+			req, err := http.NewRequest(http.MethodGet, "/v2/student/2/class_info"+tc.query, nil)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{queryParamKey: "2"})
+			rr := httptest.NewRecorder()
+			// act
+			classInfoHandler.ListByStudent(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestClassInfoHandlerV2_Update(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	classInfo := models.ClassInfo{ClassName: "Algebra"}
+	updated := models.ClassInfo{ID: 4, StudentID: 1, ClassName: "Algebra"}
+	tests := []struct {
+		description       string
+		mockExpectedError error
+		expectedCode      int
+	}{
+		{
+			description:  "Returns the updated class",
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:       "Class not found",
+			mockExpectedError: pkgErrors.ErrNotFound,
+			expectedCode:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			jsonData, err := json.Marshal(classInfo)
+			require.NoError(t, err)
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+			classInfoHandler := NewClassInfoHandlerV2(mockRepo, queryParamKey, v2Routes())
+			mockRepo.EXPECT().UpdateByID(gomock.Any(), int64(4), classInfo).Return(tc.mockExpectedError)
+			if tc.mockExpectedError == nil {
+				mockRepo.EXPECT().GetByID(gomock.Any(), int64(4)).Return(updated, nil)
+			}
+
+			req, err := http.NewRequest(http.MethodPut, "/v2/class_info/4", bytes.NewReader(jsonData))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{queryParamKey: "4"})
+			rr := httptest.NewRecorder()
+			// act
+			classInfoHandler.Update(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+				return
+			}
+
+			var actual struct {
+				Data models.ClassInfo `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, updated, actual.Data)
+		})
+	}
+}
+
+func TestClassInfoHandlerV2_Delete(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	tests := []struct {
+		description       string
+		mockExpectedError error
+		expectedCode      int
+	}{
+		{
+			description:  "Deleted",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			description:       "Class not found",
+			mockExpectedError: pkgErrors.ErrNotFound,
+			expectedCode:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+			classInfoHandler := NewClassInfoHandlerV2(mockRepo, queryParamKey, v2Routes())
+			mockRepo.EXPECT().DeleteByID(gomock.Any(), int64(4)).Return(tc.mockExpectedError)
+
+			req, err := http.NewRequest(http.MethodDelete, "/v2/class_info/4", nil)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{queryParamKey: "4"})
+			rr := httptest.NewRecorder()
+			// act
+			classInfoHandler.Delete(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
@@ -23,34 +23,56 @@ func TestClassInfoHandler_AddClass(t *testing.T) {
 	var (
 		queryParamKey = "id"
 	)
-	type mockExpected struct {
-		result int64
-		error  error
-	}
 	tests := []struct {
 		description               string
-		mockArguments             models.ClassInfo
-		mockExpectedEntities      mockExpected
+		request                   models.ClassInfo
+		mock                      func(repo *mock_repository.MockClassInfoPgRepo)
 		result                    models.ClassInfo
 		expectedCode              int
 		expectedHTTPErrorResponce string
 	}{
 		{
-			description:               "Succesfully Added into Database",
-			mockArguments:             models.ClassInfo{StudentID: 1, ClassName: "math"},
-			mockExpectedEntities:      mockExpected{result: 0, error: nil},
-			result:                    models.ClassInfo{StudentID: 1, ClassName: "math"},
-			expectedCode:              http.StatusOK,
-			expectedHTTPErrorResponce: "",
+			description: "Succesfully Added into Database",
+			request:     models.ClassInfo{StudentID: 1, ClassName: "math"},
+			mock: func(repo *mock_repository.MockClassInfoPgRepo) {
+				repo.EXPECT().LegacyTermID(gomock.Any()).Return(int64(3), nil)
+				repo.EXPECT().Add(gomock.Any(), models.ClassInfo{StudentID: 1, ClassName: "math", TermID: 3}).Return(int64(7), nil)
+				repo.EXPECT().GetByID(gomock.Any(), int64(7)).
+					Return(models.ClassInfo{ID: 7, StudentID: 1, ClassName: "math", TermID: 3, Status: models.EnrollmentStatusEnrolled}, nil)
+			},
+			result:       models.ClassInfo{ID: 7, StudentID: 1, ClassName: "math", TermID: 3, Status: models.EnrollmentStatusEnrolled},
+			expectedCode: http.StatusOK,
 		},
 		{
-			description:               "ForeignKey Error",
-			mockArguments:             models.ClassInfo{StudentID: 2, ClassName: "math"},
-			mockExpectedEntities:      mockExpected{result: -1, error: pkgErrors.ErrForeignKey},
-			result:                    models.ClassInfo{},
+			description: "Term given by the client",
+			request:     models.ClassInfo{StudentID: 1, ClassName: "math", TermID: 4},
+			mock: func(repo *mock_repository.MockClassInfoPgRepo) {
+				repo.EXPECT().Add(gomock.Any(), models.ClassInfo{StudentID: 1, ClassName: "math", TermID: 4}).Return(int64(7), nil)
+				repo.EXPECT().GetByID(gomock.Any(), int64(7)).
+					Return(models.ClassInfo{ID: 7, StudentID: 1, ClassName: "math", TermID: 4, Status: models.EnrollmentStatusWaitlisted}, nil)
+			},
+			result:       models.ClassInfo{ID: 7, StudentID: 1, ClassName: "math", TermID: 4, Status: models.EnrollmentStatusWaitlisted},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description: "ForeignKey Error",
+			request:     models.ClassInfo{StudentID: 2, ClassName: "math"},
+			mock: func(repo *mock_repository.MockClassInfoPgRepo) {
+				repo.EXPECT().LegacyTermID(gomock.Any()).Return(int64(3), nil)
+				repo.EXPECT().Add(gomock.Any(), models.ClassInfo{StudentID: 2, ClassName: "math", TermID: 3}).Return(int64(-1), pkgErrors.ErrForeignKey)
+			},
 			expectedCode:              http.StatusInternalServerError,
 			expectedHTTPErrorResponce: "Failed to add class_info: ERROR: insert or update on table \"class_info\" violates foreign key constraint \"fk_student\" (SQLSTATE 23503)\n",
 		},
+		{
+			description: "No open term",
+			request:     models.ClassInfo{StudentID: 1, ClassName: "math"},
+			mock: func(repo *mock_repository.MockClassInfoPgRepo) {
+				repo.EXPECT().LegacyTermID(gomock.Any()).Return(int64(0), pkgErrors.ErrNoCurrentTerm)
+			},
+			expectedCode:              http.StatusConflict,
+			expectedHTTPErrorResponce: "Failed to add class_info: No academic term covers the current date\n",
+		},
 	}
 
 	for _, tc := range tests {
@@ -58,11 +80,11 @@ func TestClassInfoHandler_AddClass(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			t.Parallel()
 			ctrl := gomock.NewController(t)
-			jsonData, err := json.Marshal(tc.mockArguments)
+			jsonData, err := json.Marshal(tc.request)
 			require.NoError(t, err)
 			mockRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
 			classInfoHandler := NewClassInfoHandler(mockRepo, queryParamKey)
-			mockRepo.EXPECT().Add(gomock.Any(), tc.mockArguments).Return(tc.mockExpectedEntities.result, tc.mockExpectedEntities.error)
+			tc.mock(mockRepo)
 			defer ctrl.Finish()
 			req, err := http.NewRequest(http.MethodPost, "/class_info", bytes.NewReader(jsonData))
 			require.NoError(t, err)
@@ -82,7 +104,7 @@ func TestClassInfoHandler_AddClass(t *testing.T) {
 			var actual models.ClassInfo
 			err = json.Unmarshal(rr.Body.Bytes(), &actual)
 			require.NoError(t, err)
-			assert.Equal(t, actual, tc.result)
+			assert.Equal(t, tc.result, actual)
 		})
 	}
 }
@@ -163,8 +185,16 @@ func TestClassInfoHandler_DeleteClassByStudent(t *testing.T) {
 		expectedMessage   string
 		mockArguments     int64
 		mockExpectedError error
+		legacyTermError   error
 		expectedCode      int
 	}{
+		{
+			description:     "No open term",
+			expectedMessage: "Failed to delete class_info: No academic term covers the current date\n",
+			mockArguments:   4,
+			legacyTermError: pkgErrors.ErrNoCurrentTerm,
+			expectedCode:    http.StatusConflict,
+		},
 		{
 			description:       "Unable to delete",
 			expectedMessage:   "Failed to delete record from class_info by StudentByID: assert.AnError general error for testing\n",
@@ -195,7 +225,14 @@ func TestClassInfoHandler_DeleteClassByStudent(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
 			classInfoHandler := NewClassInfoHandler(mockRepo, queryParamKey)
-			mockRepo.EXPECT().DeleteClassByStudentID(gomock.Any(), tc.mockArguments).Return(tc.mockExpectedError)
+			// only the legacy term is touched, so that closed terms do not get in the way
+			termID := int64(3)
+			if tc.legacyTermError != nil {
+				mockRepo.EXPECT().LegacyTermID(gomock.Any()).Return(int64(0), tc.legacyTermError)
+			} else {
+				mockRepo.EXPECT().LegacyTermID(gomock.Any()).Return(termID, nil)
+				mockRepo.EXPECT().DeleteClassByStudentID(gomock.Any(), tc.mockArguments, &termID).Return(tc.mockExpectedError)
+			}
 			defer ctrl.Finish()
 			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/class_info/%d", tc.mockArguments), bytes.NewReader([]byte{}))
 			require.NoError(t, err)
@@ -233,6 +270,20 @@ func TestClassInfoHandler_UpdateClass(t *testing.T) {
 			mockExpectedError: nil,
 			expectedCode:      http.StatusOK,
 		},
+		{
+			description:       "Term given by the client",
+			expectedMessage:   "Successfully Updated Class Info",
+			mockArguments:     models.ClassInfo{StudentID: 1, ClassName: "math", TermID: 4},
+			mockExpectedError: nil,
+			expectedCode:      http.StatusOK,
+		},
+		{
+			description:       "Term closed",
+			expectedMessage:   "Failed to update class_info: Academic term is closed\n",
+			mockArguments:     models.ClassInfo{StudentID: 1, ClassName: "math", TermID: 4},
+			mockExpectedError: pkgErrors.ErrTermClosed,
+			expectedCode:      http.StatusConflict,
+		},
 		{
 			description:       "Not Found",
 			expectedMessage:   "Cannot update the student in class_info due to existing references (foreign key constraint).\n",
@@ -251,7 +302,13 @@ func TestClassInfoHandler_UpdateClass(t *testing.T) {
 			jsonData, err := json.Marshal(tc.mockArguments)
 			require.NoError(t, err)
 			classInfoHandler := NewClassInfoHandler(mockRepo, queryParamKey)
-			mockRepo.EXPECT().Update(gomock.Any(), tc.mockArguments.StudentID, tc.mockArguments).Return(tc.mockExpectedError)
+			// without a term only the enrollments of the legacy term are moved
+			expected := tc.mockArguments
+			if expected.TermID == 0 {
+				expected.TermID = 3
+				mockRepo.EXPECT().LegacyTermID(gomock.Any()).Return(int64(3), nil)
+			}
+			mockRepo.EXPECT().Update(gomock.Any(), expected.StudentID, expected).Return(tc.mockExpectedError)
 			defer ctrl.Finish()
 			req, err := http.NewRequest(http.MethodPut, "/class_info", bytes.NewReader(jsonData))
 			require.NoError(t, err)
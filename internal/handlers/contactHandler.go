@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+)
+
+// contactParamKey is the path variable of the contact in student contact routes.
+const contactParamKey = "contact_id"
+
+// maxContactName is the longest contact name, in characters.
+const maxContactName = 200
+
+// e164 matches phone numbers in E.164 format: a plus sign and up to 15 digits, without a leading zero.
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+var contactRelationships = map[string]bool{
+	models.RelationshipMother:      true,
+	models.RelationshipFather:      true,
+	models.RelationshipParent:      true,
+	models.RelationshipGuardian:    true,
+	models.RelationshipGrandparent: true,
+	models.RelationshipOther:       true,
+}
+
+// ContactHandler serves the v2 parent and guardian contacts of students. Contacts are logged masked,
+// through models.Contact.LogValue.
+type ContactHandler struct {
+	contactStorage repository.ContactPgRepo
+	queryParamKey  string
+	links          *links
+}
+
+// NewContactHandler creates a new ContactHandler with the given contact storage.
+// Links are built from the named routes of router.
+func NewContactHandler(contactStorage repository.ContactPgRepo, queryParamKey string, router *mux.Router) *ContactHandler {
+	return &ContactHandler{
+		contactStorage: contactStorage,
+		queryParamKey:  queryParamKey,
+		links:          newLinks(router, queryParamKey),
+	}
+}
+
+// List lists the contacts of the student in the path.
+func (h *ContactHandler) List(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	contacts, err := h.contactStorage.ListByStudent(req.Context(), studentID)
+	if err != nil {
+		writeStorageError(w, req, err, "student")
+		return
+	}
+
+	if contacts == nil {
+		contacts = []models.Contact{}
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: contacts})
+}
+
+// Create adds a contact to the student in the path. With a contact_id, the existing contact of another
+// student, such as a sibling, is linked and only relationship is read from the body.
+func (h *ContactHandler) Create(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var contact models.Contact
+	if !decodeBody(w, req, &contact) {
+		return
+	}
+
+	if contact.ContactID != 0 {
+		if !contactRelationships[contact.Relationship] {
+			problem.Write(w, req, http.StatusBadRequest, "relationship must be "+relationshipList())
+			return
+		}
+
+		if err := h.contactStorage.Link(req.Context(), studentID, contact.ContactID, contact.Relationship); err != nil {
+			writeStorageError(w, req, err, "student or contact")
+			return
+		}
+	} else {
+		if detail := validateContact(contact); detail != "" {
+			problem.Write(w, req, http.StatusBadRequest, detail)
+			return
+		}
+
+		id, err := h.contactStorage.Add(req.Context(), studentID, contact)
+		if err != nil {
+			writeStorageError(w, req, err, "student")
+			return
+		}
+
+		contact.ContactID = id
+	}
+
+	created, err := h.contactStorage.GetByStudent(req.Context(), studentID, contact.ContactID)
+	if err != nil {
+		writeStorageError(w, req, err, "contact")
+		return
+	}
+
+	slog.InfoContext(req.Context(), "contact linked to student", "student_id", studentID, "contact", created)
+
+	w.Header().Set("Location", h.links.contact(studentID, created.ContactID))
+	writeResponse(w, responseCodec, http.StatusCreated, models.Envelope{Data: created})
+}
+
+func (h *ContactHandler) Get(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	studentID, contactID, ok := h.contactPath(w, req)
+	if !ok {
+		return
+	}
+
+	contact, err := h.contactStorage.GetByStudent(req.Context(), studentID, contactID)
+	if err != nil {
+		writeStorageError(w, req, err, "contact")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: contact})
+}
+
+// Update replaces the contact in the path and its relationship to the student. The contact changes
+// for every student it is linked to.
+func (h *ContactHandler) Update(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	studentID, contactID, ok := h.contactPath(w, req)
+	if !ok {
+		return
+	}
+
+	var contact models.Contact
+	if !decodeBody(w, req, &contact) {
+		return
+	}
+
+	if detail := validateContact(contact); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	contact.ContactID = contactID
+
+	if err := h.contactStorage.Update(req.Context(), studentID, contact); err != nil {
+		writeStorageError(w, req, err, "contact")
+		return
+	}
+
+	updated, err := h.contactStorage.GetByStudent(req.Context(), studentID, contactID)
+	if err != nil {
+		writeStorageError(w, req, err, "contact")
+		return
+	}
+
+	slog.InfoContext(req.Context(), "contact updated", "student_id", studentID, "contact", updated)
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: updated})
+}
+
+// Delete unlinks the contact in the path from the student. A contact linked to no other student is removed.
+func (h *ContactHandler) Delete(w http.ResponseWriter, req *http.Request) {
+	studentID, contactID, ok := h.contactPath(w, req)
+	if !ok {
+		return
+	}
+
+	if err := h.contactStorage.Unlink(req.Context(), studentID, contactID); err != nil {
+		writeStorageError(w, req, err, "contact")
+		return
+	}
+
+	slog.InfoContext(req.Context(), "contact unlinked from student", "student_id", studentID, "contact_id", contactID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ContactHandler) contactPath(w http.ResponseWriter, req *http.Request) (studentID, contactID int64, ok bool) {
+	studentID, ok = pathID(w, req, h.queryParamKey)
+	if !ok {
+		return 0, 0, false
+	}
+
+	contactID, ok = pathID(w, req, contactParamKey)
+
+	return studentID, contactID, ok
+}
+
+// validateContact returns why contact cannot be stored, or "" when it can. Details never echo
+// the submitted values, since they are personal data.
+func validateContact(contact models.Contact) string {
+	switch {
+	case strings.TrimSpace(contact.Name) == "":
+		return "name is required"
+	case utf8.RuneCountInString(contact.Name) > maxContactName:
+		return fmt.Sprintf("name must be at most %d characters", maxContactName)
+	case !contactRelationships[contact.Relationship]:
+		return "relationship must be " + relationshipList()
+	case contact.Email != "" && !validEmail(contact.Email):
+		return "email must be a valid email address"
+	case contact.Phone != "" && !e164.MatchString(contact.Phone):
+		return "phone must be in E.164 format, such as +14155552671"
+	}
+
+	switch contact.PreferredChannel {
+	case models.ContactChannelEmail:
+		if contact.Email == "" {
+			return "email is required when the preferred_channel is email"
+		}
+	case models.ContactChannelSMS, models.ContactChannelPhone:
+		if contact.Phone == "" {
+			return "phone is required when the preferred_channel is " + contact.PreferredChannel
+		}
+	default:
+		return fmt.Sprintf(
+			"preferred_channel must be %s, %s or %s",
+			models.ContactChannelEmail, models.ContactChannelSMS, models.ContactChannelPhone,
+		)
+	}
+
+	switch {
+	case contact.ConsentEmail && contact.Email == "":
+		return "consent_email requires an email"
+	case (contact.ConsentSMS || contact.ConsentPhone) && contact.Phone == "":
+		return "consent_sms and consent_phone require a phone"
+	}
+
+	return ""
+}
+
+// validEmail reports whether email is a bare address, without a display name, with a dotted domain.
+func validEmail(email string) bool {
+	if len(email) > 254 {
+		return false
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Name != "" || address.Address != email {
+		return false
+	}
+
+	domain := email[strings.LastIndexByte(email, '@')+1:]
+
+	return strings.Contains(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+func relationshipList() string {
+	return strings.Join([]string{
+		models.RelationshipMother, models.RelationshipFather, models.RelationshipParent,
+		models.RelationshipGuardian, models.RelationshipGrandparent,
+	}, ", ") + " or " + models.RelationshipOther
+}
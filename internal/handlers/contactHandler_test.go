@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newContactRouter(contactStorage *mock_repository.MockContactPgRepo) http.Handler {
+	return NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithContacts(contactStorage))
+}
+
+func TestContactHandler_Create(t *testing.T) {
+	t.Parallel()
+	contact := models.Contact{
+		Name: "Jane Doe", Relationship: models.RelationshipMother, Email: "jane.doe@example.com",
+		Phone: "+14155552671", PreferredChannel: models.ContactChannelSMS, ConsentSMS: true,
+	}
+	tests := []struct {
+		description      string
+		body             string
+		mock             func(m *mock_repository.MockContactPgRepo)
+		expectedCode     int
+		expectedLocation string
+	}{
+		{
+			description: "Created",
+			body: `{"name": "Jane Doe", "relationship": "mother", "email": "jane.doe@example.com",
+				"phone": "+14155552671", "preferred_channel": "sms", "consent_sms": true}`,
+			mock: func(m *mock_repository.MockContactPgRepo) {
+				m.EXPECT().Add(gomock.Any(), int64(7), contact).Return(int64(4), nil)
+				created := contact
+				created.ContactID = 4
+				m.EXPECT().GetByStudent(gomock.Any(), int64(7), int64(4)).Return(created, nil)
+			},
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/v2/student/7/contacts/4",
+		},
+		{
+			description: "Contact of a sibling linked",
+			body:        `{"contact_id": 4, "relationship": "guardian"}`,
+			mock: func(m *mock_repository.MockContactPgRepo) {
+				m.EXPECT().Link(gomock.Any(), int64(7), int64(4), models.RelationshipGuardian).Return(nil)
+				m.EXPECT().GetByStudent(gomock.Any(), int64(7), int64(4)).Return(models.Contact{ContactID: 4}, nil)
+			},
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/v2/student/7/contacts/4",
+		},
+		{
+			description: "Already linked",
+			body:        `{"contact_id": 4, "relationship": "guardian"}`,
+			mock: func(m *mock_repository.MockContactPgRepo) {
+				m.EXPECT().Link(gomock.Any(), int64(7), int64(4), models.RelationshipGuardian).Return(pkgErrors.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			description:  "Malformed email",
+			body:         `{"name": "Jane Doe", "relationship": "mother", "email": "Jane <jane@example.com>", "preferred_channel": "email"}`,
+			mock:         func(m *mock_repository.MockContactPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Email without a dotted domain",
+			body:         `{"name": "Jane Doe", "relationship": "mother", "email": "jane@localhost", "preferred_channel": "email"}`,
+			mock:         func(m *mock_repository.MockContactPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Phone not in E.164",
+			body:         `{"name": "Jane Doe", "relationship": "mother", "phone": "(415) 555-2671", "preferred_channel": "phone"}`,
+			mock:         func(m *mock_repository.MockContactPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Preferred channel without an address",
+			body:         `{"name": "Jane Doe", "relationship": "mother", "email": "jane@example.com", "preferred_channel": "sms"}`,
+			mock:         func(m *mock_repository.MockContactPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Consent without an address",
+			body:         `{"name": "Jane Doe", "relationship": "mother", "phone": "+14155552671", "preferred_channel": "sms", "consent_email": true}`,
+			mock:         func(m *mock_repository.MockContactPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Unknown relationship",
+			body:         `{"name": "Jane Doe", "relationship": "neighbour", "email": "jane@example.com", "preferred_channel": "email"}`,
+			mock:         func(m *mock_repository.MockContactPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Student not found",
+			body:        `{"name": "Jane Doe", "relationship": "mother", "email": "jane@example.com", "preferred_channel": "email"}`,
+			mock: func(m *mock_repository.MockContactPgRepo) {
+				m.EXPECT().Add(gomock.Any(), int64(7), gomock.Any()).Return(int64(-1), pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockContactPgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(http.MethodPost, "/v2/student/7/contacts", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			newContactRouter(mockRepo).ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			assert.Equal(t, tc.expectedLocation, rr.Header().Get("Location"))
+		})
+	}
+}
+
+func TestContactHandler_Update(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description  string
+		mock         func(m *mock_repository.MockContactPgRepo)
+		expectedCode int
+	}{
+		{
+			description: "Updated",
+			mock: func(m *mock_repository.MockContactPgRepo) {
+				m.EXPECT().Update(gomock.Any(), int64(7), models.Contact{
+					ContactID: 4, Name: "Jane Doe", Relationship: models.RelationshipGuardian,
+					Email: "jane@example.com", PreferredChannel: models.ContactChannelEmail, ConsentEmail: true,
+				}).Return(nil)
+				m.EXPECT().GetByStudent(gomock.Any(), int64(7), int64(4)).Return(models.Contact{ContactID: 4}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description: "Not linked to the student",
+			mock: func(m *mock_repository.MockContactPgRepo) {
+				m.EXPECT().Update(gomock.Any(), int64(7), gomock.Any()).Return(pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockContactPgRepo(ctrl)
+			tc.mock(mockRepo)
+			body := `{"name": "Jane Doe", "relationship": "guardian", "email": "jane@example.com",
+				"preferred_channel": "email", "consent_email": true}`
+			req := httptest.NewRequest(http.MethodPut, "/v2/student/7/contacts/4", strings.NewReader(body))
+			rr := httptest.NewRecorder()
+			// act
+			newContactRouter(mockRepo).ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestContact_LogValue(t *testing.T) {
+	t.Parallel()
+	// arrange
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+	contact := models.Contact{
+		ContactID: 4, Name: "Jane Doe", Relationship: models.RelationshipMother,
+		Email: "jane.doe@example.com", Phone: "+14155552671", PreferredChannel: models.ContactChannelEmail,
+	}
+	// act
+	logger.Info("contact linked to student", "contact", contact)
+	// assert
+	assert.NotContains(t, out.String(), "Jane Doe")
+	assert.NotContains(t, out.String(), "jane.doe")
+	assert.NotContains(t, out.String(), "5552671")
+	assert.Contains(t, out.String(), `"email":"j***@example.com"`)
+	assert.Contains(t, out.String(), `"phone":"***71"`)
+}
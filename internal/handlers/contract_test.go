@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// contractCase is one request against the full router and the response a version promises for it.
+type contractCase struct {
+	description     string
+	method          string
+	path            string
+	body            string
+	headers         map[string]string
+	mock            func(student *mock_repository.MockStudentPgRepo, classInfo *mock_repository.MockClassInfoPgRepo)
+	expectedCode    int
+	expectedJSON    string
+	expectedText    string
+	expectedHeaders map[string]string
+}
+
+// versionContract is the contract of one API version, checked under every prefix it is mounted on.
+type versionContract struct {
+	version  string
+	prefixes []string
+	cases    []contractCase
+}
+
+var contractStudent = models.StudentRequest{StudentID: 1, StudentName: "Test", Grade: 90}
+
+// contractProfile is contractStudent with a profile, which only v2 represents.
+var contractProfile = models.StudentRequest{
+	StudentID: 1, StudentName: "Test", Grade: 90, StudentNumber: "S-1", Status: models.StudentActive,
+	CreatedAt: &contractTime, UpdatedAt: &contractTime,
+}
+
+var contractTime = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func v1Contract() versionContract {
+	return versionContract{
+		version:  "v1",
+		prefixes: []string{"", "/v1"},
+		cases: []contractCase{
+			{
+				description: "Get student",
+				method:      http.MethodGet,
+				path:        "/student/1",
+				mock: func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+					student.EXPECT().GetByID(gomock.Any(), int64(1)).Return(contractProfile, nil)
+				},
+				expectedCode: http.StatusOK,
+				expectedJSON: `{"student_id": 1, "student_name": "Test", "grade": 90}`,
+			},
+			{
+				description: "Get missing student",
+				method:      http.MethodGet,
+				path:        "/student/4",
+				mock: func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+					student.EXPECT().GetByID(gomock.Any(), int64(4)).Return(models.StudentRequest{}, pkgErrors.ErrNotFound)
+				},
+				expectedCode: http.StatusNotFound,
+				expectedText: "Student not found\n",
+			},
+			{
+				description: "Create student",
+				method:      http.MethodPost,
+				path:        "/student",
+				body:        `{"student_name": "Test", "grade": 90}`,
+				mock: func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+					student.EXPECT().Add(gomock.Any(), models.StudentRequest{StudentName: "Test", Grade: 90}).Return(int64(1), nil)
+				},
+				expectedCode: http.StatusOK,
+				expectedJSON: `{"student_id": 1, "student_name": "Test", "grade": 90}`,
+			},
+			{
+				description: "Delete student",
+				method:      http.MethodDelete,
+				path:        "/student/1",
+				mock: func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+					student.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil)
+				},
+				expectedCode: http.StatusOK,
+				expectedText: "Successfully Deleted Student Info",
+			},
+			{
+				description: "Get classes by student",
+				method:      http.MethodGet,
+				path:        "/class_info/1",
+				mock: func(_ *mock_repository.MockStudentPgRepo, classInfo *mock_repository.MockClassInfoPgRepo) {
+					classInfo.EXPECT().GetByStudentID(gomock.Any(), int64(1)).
+						Return([]models.ClassInfo{{ID: 2, StudentID: 1, ClassName: "Math", TermID: 3}}, nil)
+				},
+				expectedCode: http.StatusOK,
+				expectedJSON: `[{"id": 2, "student_id": 1, "class_name": "Math", "term_id": 3}]`,
+			},
+			{
+				description:  "HAL is not offered",
+				method:       http.MethodGet,
+				path:         "/student/1",
+				headers:      map[string]string{"Accept": "application/hal+json"},
+				expectedCode: http.StatusNotAcceptable,
+			},
+			{
+				description:  "Unsupported method",
+				method:       http.MethodPatch,
+				path:         "/student",
+				expectedCode: http.StatusMethodNotAllowed,
+			},
+		},
+	}
+}
+
+func v2Contract() versionContract {
+	return versionContract{
+		version:  "v2",
+		prefixes: []string{"/v2"},
+		cases: []contractCase{
+			{
+				description: "Get student",
+				method:      http.MethodGet,
+				path:        "/student/1",
+				mock: func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+					student.EXPECT().GetByIDWithFields(gomock.Any(), int64(1), gomock.Nil()).Return(contractStudent, nil)
+				},
+				expectedCode: http.StatusOK,
+				expectedJSON: `{"data": {"student_id": 1, "student_name": "Test", "grade": 90}}`,
+			},
+			{
+				description: "Get student as HAL",
+				method:      http.MethodGet,
+				path:        "/student/1",
+				headers:     map[string]string{"Accept": "application/hal+json"},
+				mock: func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+					student.EXPECT().GetByIDWithFields(gomock.Any(), int64(1), gomock.Nil()).Return(contractStudent, nil)
+				},
+				expectedCode: http.StatusOK,
+				expectedJSON: `{
+					"student_id": 1, "student_name": "Test", "grade": 90,
+					"_links": {"self": {"href": "/v2/student/1"}, "classes": {"href": "/v2/student/1/class_info"}}
+				}`,
+				expectedHeaders: map[string]string{"Content-Type": "application/hal+json"},
+			},
+			{
+				description: "Get missing student",
+				method:      http.MethodGet,
+				path:        "/student/4",
+				mock: func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+					student.EXPECT().GetByIDWithFields(gomock.Any(), int64(4), gomock.Nil()).Return(models.StudentRequest{}, pkgErrors.ErrNotFound)
+				},
+				expectedCode:    http.StatusNotFound,
+				expectedHeaders: map[string]string{"Content-Type": problem.ContentType},
+			},
+			{
+				description: "Create student",
+				method:      http.MethodPost,
+				path:        "/student",
+				body:        `{"student_name": "Test", "grade": 90}`,
+				mock: func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+					student.EXPECT().Add(gomock.Any(), models.StudentRequest{StudentName: "Test", Grade: 90}).Return(int64(1), nil)
+					student.EXPECT().GetByID(gomock.Any(), int64(1)).Return(contractProfile, nil)
+				},
+				expectedCode: http.StatusCreated,
+				expectedJSON: `{"data": {
+					"student_id": 1, "student_name": "Test", "grade": 90, "student_number": "S-1", "status": "active",
+					"created_at": "2026-10-19T12:00:00Z", "updated_at": "2026-10-19T12:00:00Z"
+				}}`,
+				expectedHeaders: map[string]string{"Location": "/v2/student/1"},
+			},
+			{
+				description: "Delete student",
+				method:      http.MethodDelete,
+				path:        "/student/1",
+				mock: func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+					student.EXPECT().Delete(gomock.Any(), int64(1)).Return(nil)
+				},
+				expectedCode: http.StatusNoContent,
+			},
+			{
+				description: "List students",
+				method:      http.MethodGet,
+				path:        "/student?limit=1",
+				mock: func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+					student.EXPECT().List(gomock.Any(), int64(1), int64(0), gomock.Nil()).Return([]models.StudentRequest{contractStudent}, nil)
+					student.EXPECT().Count(gomock.Any()).Return(int64(2), nil)
+				},
+				expectedCode: http.StatusOK,
+				expectedJSON: `{
+					"data": [{"student_id": 1, "student_name": "Test", "grade": 90}],
+					"meta": {"total": 2, "count": 1, "limit": 1, "offset": 0},
+					"links": {"self": "/v2/student?limit=1&offset=0", "next": "/v2/student?limit=1&offset=1"}
+				}`,
+			},
+			{
+				description: "List classes of a student",
+				method:      http.MethodGet,
+				path:        "/student/1/class_info",
+				mock: func(_ *mock_repository.MockStudentPgRepo, classInfo *mock_repository.MockClassInfoPgRepo) {
+					classInfo.EXPECT().GetByStudentIDInTerm(gomock.Any(), int64(1), gomock.Nil()).
+						Return([]models.ClassInfo{{ID: 2, StudentID: 1, ClassName: "Math", TermID: 3}}, nil)
+				},
+				expectedCode: http.StatusOK,
+				expectedJSON: `{
+					"data": [{"id": 2, "student_id": 1, "class_name": "Math", "term_id": 3}],
+					"meta": {"total": 1, "count": 1, "limit": 20, "offset": 0},
+					"links": {"self": "/v2/student/1/class_info?limit=20&offset=0"}
+				}`,
+			},
+			{
+				description:  "Unsupported method",
+				method:       http.MethodPatch,
+				path:         "/student",
+				expectedCode: http.StatusMethodNotAllowed,
+			},
+		},
+	}
+}
+
+func newContractRouter(
+	t *testing.T,
+	mock func(student *mock_repository.MockStudentPgRepo, classInfo *mock_repository.MockClassInfoPgRepo),
+	opts ...RouterOption,
+) *mux.Router {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	studentRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+	classInfoRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+
+	if mock != nil {
+		mock(studentRepo, classInfoRepo)
+	}
+
+	return NewRouter(studentRepo, classInfoRepo, NewHealthHandler(nil, ""), "id", opts...)
+}
+
+func TestVersionContracts(t *testing.T) {
+	t.Parallel()
+
+	for _, contract := range []versionContract{v1Contract(), v2Contract()} {
+		contract := contract
+		for _, prefix := range contract.prefixes {
+			prefix := prefix
+			for _, tc := range contract.cases {
+				tc := tc
+				t.Run(contract.version+" "+tc.method+" "+prefix+tc.path+" "+tc.description, func(t *testing.T) {
+					t.Parallel()
+					router := newContractRouter(t, tc.mock)
+
+					req, err := http.NewRequest(tc.method, prefix+tc.path, strings.NewReader(tc.body))
+					require.NoError(t, err)
+					for key, value := range tc.headers {
+						req.Header.Set(key, value)
+					}
+					rr := httptest.NewRecorder()
+					// act
+					router.ServeHTTP(rr, req)
+					// assert
+					require.Equal(t, tc.expectedCode, rr.Code, rr.Body.String())
+					for key, value := range tc.expectedHeaders {
+						assert.Equal(t, value, rr.Header().Get(key), key)
+					}
+					if tc.expectedJSON != "" {
+						assert.JSONEq(t, tc.expectedJSON, rr.Body.String())
+					}
+					if tc.expectedText != "" {
+						assert.Equal(t, tc.expectedText, rr.Body.String())
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestRouter_Deprecation(t *testing.T) {
+	t.Parallel()
+
+	deprecation := Deprecation{
+		Since:  time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC),
+		Link:   "https://example.com/migrating-to-v2",
+	}
+	tests := []struct {
+		description string
+		path        string
+		deprecated  bool
+	}{
+		{description: "Unprefixed v1", path: "/student/1", deprecated: true},
+		{description: "Prefixed v1", path: "/v1/student/1", deprecated: true},
+		{description: "v2", path: "/v2/student/1", deprecated: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			router := newContractRouter(t, func(student *mock_repository.MockStudentPgRepo, _ *mock_repository.MockClassInfoPgRepo) {
+				// v1 reads whole students, v2 reads sparse fieldsets.
+				student.EXPECT().GetByID(gomock.Any(), int64(1)).Return(contractStudent, nil).AnyTimes()
+				student.EXPECT().GetByIDWithFields(gomock.Any(), int64(1), gomock.Nil()).Return(contractStudent, nil).AnyTimes()
+			}, WithDeprecation("v1", deprecation))
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, http.StatusOK, rr.Code)
+			if !tc.deprecated {
+				assert.Empty(t, rr.Header().Get("Deprecation"))
+				assert.Empty(t, rr.Header().Get("Sunset"))
+				return
+			}
+			assert.Equal(t, "@1792368000", rr.Header().Get("Deprecation"))
+			assert.Equal(t, "Wed, 30 Jun 2027 00:00:00 GMT", rr.Header().Get("Sunset"))
+			assert.Equal(t, `<https://example.com/migrating-to-v2>; rel="deprecation"`, rr.Header().Get("Link"))
+		})
+	}
+}
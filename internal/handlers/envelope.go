@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// listEnvelope wraps one page of data, linking to the neighbouring pages of the request URL.
+func listEnvelope(req *http.Request, data interface{}, count int, total int64, p page) models.ListEnvelope {
+	return models.ListEnvelope{
+		Data:  data,
+		Meta:  models.ListMeta{Total: total, Count: count, Limit: p.limit, Offset: p.offset},
+		Links: pageLinks(req.URL.Path, req, total, p),
+	}
+}
+
+// pageLinks links to the current and neighbouring pages of base, keeping the other query parameters of req.
+func pageLinks(base string, req *http.Request, total int64, p page) models.ListLinks {
+	link := func(offset int64) string {
+		u := url.URL{Path: base}
+		query := req.URL.Query()
+		query.Set("limit", strconv.FormatInt(p.limit, 10))
+		query.Set("offset", strconv.FormatInt(offset, 10))
+		u.RawQuery = query.Encode()
+
+		return u.String()
+	}
+
+	links := models.ListLinks{Self: link(p.offset)}
+
+	if p.offset+p.limit < total {
+		links.Next = link(p.offset + p.limit)
+	}
+
+	if p.offset > 0 {
+		prev := p.offset - p.limit
+		if prev < 0 {
+			prev = 0
+		}
+
+		links.Prev = link(prev)
+	}
+
+	return links
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"net/http"
+	"strings"
+)
+
+const (
+	fieldsParam  = "fields"
+	includeParam = "include"
+	// embedParam is accepted as an alias of includeParam, since HAL calls included resources embedded.
+	embedParam     = "embed"
+	includeClasses = "classes"
+)
+
+// parseFields reads the comma separated fields query parameter. It returns nil when it is absent,
+// which selects every field. Fields outside allowed get 400 and false.
+func parseFields(w http.ResponseWriter, req *http.Request, allowed []string) ([]string, bool) {
+	raw := req.URL.Query().Get(fieldsParam)
+	if raw == "" {
+		return nil, true
+	}
+
+	var fields []string
+
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if !utils.Contains(allowed, field) {
+			problem.Write(w, req, http.StatusBadRequest, "fields must be a subset of "+strings.Join(allowed, ", "))
+			return nil, false
+		}
+
+		if !utils.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, true
+}
+
+// parseInclude reads the related resources to include from the include and embed query parameters.
+// Unknown relations get 400 and false.
+func parseInclude(w http.ResponseWriter, req *http.Request, supported ...string) (map[string]bool, bool) {
+	include := make(map[string]bool)
+
+	for _, param := range []string{includeParam, embedParam} {
+		raw := req.URL.Query().Get(param)
+		if raw == "" {
+			continue
+		}
+
+		for _, relation := range strings.Split(raw, ",") {
+			relation = strings.TrimSpace(relation)
+			if !utils.Contains(supported, relation) {
+				problem.Write(w, req, http.StatusBadRequest, param+" must be one of "+strings.Join(supported, ", "))
+				return nil, false
+			}
+
+			include[relation] = true
+		}
+	}
+
+	return include, true
+}
+
+// studentView renders the requested fields of student, or all of them when fields is empty.
+// classes is nil unless the classes are included.
+func studentView(student models.StudentRequest, fields []string, classes *[]models.ClassInfo) models.StudentView {
+	selected := func(field string) bool {
+		return len(fields) == 0 || utils.Contains(fields, field)
+	}
+
+	view := models.StudentView{Classes: classes}
+
+	if selected("student_id") {
+		view.StudentID = &student.StudentID
+	}
+
+	if selected("student_name") {
+		view.StudentName = &student.StudentName
+	}
+
+	if selected("grade") {
+		view.Grade = &student.Grade
+	}
+
+	// Unset profile fields and timestamps are omitted.
+	optional := func(field, value string) *string {
+		if value == "" || !selected(field) {
+			return nil
+		}
+
+		return &value
+	}
+
+	view.FirstName = optional("first_name", student.FirstName)
+	view.LastName = optional("last_name", student.LastName)
+	view.PreferredName = optional("preferred_name", student.PreferredName)
+	view.DateOfBirth = optional("date_of_birth", student.DateOfBirth)
+	view.Email = optional("email", student.Email)
+	view.StudentNumber = optional("student_number", student.StudentNumber)
+	view.Status = optional("status", student.Status)
+
+	if selected("created_at") {
+		view.CreatedAt = student.CreatedAt
+	}
+
+	if selected("updated_at") {
+		view.UpdatedAt = student.UpdatedAt
+	}
+
+	return view
+}
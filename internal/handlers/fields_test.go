@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStudentHandlerV2_ListFieldsAndInclude(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	students := []models.StudentRequest{{StudentID: 1, StudentName: "A"}, {StudentID: 2, StudentName: "B"}}
+	tests := []struct {
+		description    string
+		query          string
+		accept         string
+		mockFields     []string
+		includeClasses bool
+		expectedCode   int
+		expectedBody   string
+	}{
+		{
+			description:  "Sparse fieldset",
+			query:        "?fields=student_name",
+			mockFields:   []string{"student_name"},
+			expectedCode: http.StatusOK,
+			expectedBody: `{
+				"data": [{"student_name": "A"}, {"student_name": "B"}],
+				"meta": {"total": 2, "count": 2, "limit": 20, "offset": 0},
+				"links": {"self": "/v2/student?fields=student_name&limit=20&offset=0"}
+			}`,
+		},
+		{
+			description:    "Included classes are batch loaded",
+			query:          "?fields=student_id,student_name&include=classes",
+			mockFields:     []string{"student_id", "student_name"},
+			includeClasses: true,
+			expectedCode:   http.StatusOK,
+			expectedBody: `{
+				"data": [
+					{"student_id": 1, "student_name": "A", "classes": [{"id": 3, "student_id": 1, "class_name": "Math", "term_id": 0}]},
+					{"student_id": 2, "student_name": "B", "classes": []}
+				],
+				"meta": {"total": 2, "count": 2, "limit": 20, "offset": 0},
+				"links": {"self": "/v2/student?fields=student_id%2Cstudent_name&include=classes&limit=20&offset=0"}
+			}`,
+		},
+		{
+			description:    "Included classes are embedded in HAL",
+			query:          "?fields=grade&embed=classes",
+			accept:         halContentType,
+			mockFields:     []string{"grade"},
+			includeClasses: true,
+			expectedCode:   http.StatusOK,
+			expectedBody: `{
+				"_links": {"self": {"href": "/v2/student?embed=classes&fields=grade&limit=20&offset=0"}},
+				"_embedded": {"students": [
+					{
+						"grade": 0,
+						"_links": {"self": {"href": "/v2/student/1"}, "classes": {"href": "/v2/student/1/class_info"}},
+						"_embedded": {"classes": [{
+							"id": 3, "student_id": 1, "class_name": "Math", "term_id": 0,
+							"_links": {"self": {"href": "/v2/class_info/3"}, "student": {"href": "/v2/student/1"}}
+						}]}
+					},
+					{
+						"grade": 0,
+						"_links": {"self": {"href": "/v2/student/2"}, "classes": {"href": "/v2/student/2/class_info"}},
+						"_embedded": {"classes": []}
+					}
+				]},
+				"total": 2, "count": 2, "limit": 20, "offset": 0
+			}`,
+		},
+		{
+			description:  "Unknown field",
+			query:        "?fields=password",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Unknown relation",
+			query:        "?include=teachers",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			studentRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+			classInfoRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+			studentHandler := NewStudentHandlerV2(studentRepo, classInfoRepo, queryParamKey, v2Routes())
+			if tc.expectedCode == http.StatusOK {
+				studentRepo.EXPECT().List(gomock.Any(), int64(defaultPageLimit), int64(0), tc.mockFields).Return(students, nil)
+				studentRepo.EXPECT().Count(gomock.Any()).Return(int64(2), nil)
+			}
+			if tc.includeClasses {
+				classInfoRepo.EXPECT().GetByStudentIDs(gomock.Any(), []int64{1, 2}).
+					Return(map[int64][]models.ClassInfo{1: {{ID: 3, StudentID: 1, ClassName: "Math"}}}, nil)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/v2/student"+tc.query, nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept", tc.accept)
+			rr := httptest.NewRecorder()
+			// act
+			studentHandler.List(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code, rr.Body.String())
+			if rr.Code != http.StatusOK {
+				return
+			}
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestStudentHandlerV2_GetIncludeXML(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	ctrl := gomock.NewController(t)
+	studentRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+	classInfoRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+	studentHandler := NewStudentHandlerV2(studentRepo, classInfoRepo, queryParamKey, v2Routes())
+	studentRepo.EXPECT().GetByIDWithFields(gomock.Any(), int64(1), []string{"student_name"}).
+		Return(models.StudentRequest{StudentID: 1, StudentName: "A"}, nil)
+	classInfoRepo.EXPECT().GetByStudentID(gomock.Any(), int64(1)).
+		Return([]models.ClassInfo{{ID: 3, StudentID: 1, ClassName: "Math"}}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v2/student/1?fields=student_name&include=classes", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/xml")
+	req = mux.SetURLVars(req, map[string]string{queryParamKey: "1"})
+	rr := httptest.NewRecorder()
+	// act
+	studentHandler.Get(rr, req)
+	// assert
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(),
+		"<response><student><student_name>A</student_name><classes><class_info><id>3</id>"+
+			"<student_id>1</student_id><class_name>Math</class_name><term_id>0</term_id></class_info></classes></student></response>")
+}
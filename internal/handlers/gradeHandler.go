@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/grading"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// GradeHandler serves the grades of enrollments and the transcripts of students in the v2 API.
+type GradeHandler struct {
+	gradeStorage  repository.GradePgRepo
+	scales        grading.Scales
+	queryParamKey string
+}
+
+// NewGradeHandler creates a new GradeHandler. Scores are converted to letters with scales.
+func NewGradeHandler(gradeStorage repository.GradePgRepo, scales grading.Scales, queryParamKey string) *GradeHandler {
+	return &GradeHandler{
+		gradeStorage:  gradeStorage,
+		scales:        scales,
+		queryParamKey: queryParamKey,
+	}
+}
+
+// Set grades the enrollment in the path from the score and optional scale in the body,
+// replacing its previous grade.
+func (h *GradeHandler) Set(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classInfoID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var grade models.Grade
+	if !decodeBody(w, req, &grade) {
+		return
+	}
+
+	if grade.Score < 0 || grade.Score > 100 {
+		problem.Write(w, req, http.StatusBadRequest, "score must be between 0 and 100")
+		return
+	}
+
+	scale, err := h.scales.Lookup(grade.Scale)
+	if err != nil {
+		problem.Write(w, req, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Scores are stored with two decimals, so the letter is derived from the stored value.
+	score := math.Round(grade.Score*100) / 100
+	band := scale.Grade(score)
+
+	stored, err := h.gradeStorage.Set(req.Context(), models.Grade{
+		ClassInfoID: classInfoID,
+		Score:       score,
+		Letter:      band.Letter,
+		GradePoints: band.Points,
+		Scale:       scale.Name,
+	})
+	if err != nil {
+		writeStorageError(w, req, err, "class_info")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: stored})
+}
+
+func (h *GradeHandler) Get(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classInfoID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	grade, err := h.gradeStorage.GetByClassInfoID(req.Context(), classInfoID)
+	if err != nil {
+		writeStorageError(w, req, err, "grade")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: grade})
+}
+
+func (h *GradeHandler) Delete(w http.ResponseWriter, req *http.Request) {
+	classInfoID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	if err := h.gradeStorage.Delete(req.Context(), classInfoID); err != nil {
+		writeStorageError(w, req, err, "grade")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Transcript lists the classes and grades of the student in the path by term, with term and
+// cumulative GPAs. Besides the standard formats it can be exported as a printable PDF.
+func (h *GradeHandler) Transcript(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req, transcriptPDF)
+	if !ok {
+		return
+	}
+
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	entries, err := h.gradeStorage.GetTranscript(req.Context(), studentID)
+	if err != nil {
+		writeStorageError(w, req, err, "student")
+		return
+	}
+
+	transcript := buildTranscript(studentID, entries)
+
+	if responseCodec == transcriptPDF {
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="transcript-%d.pdf"`, studentID))
+		writeResponse(w, responseCodec, http.StatusOK, transcript)
+
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: transcript})
+}
+
+// buildTranscript groups entries, which are ordered by term, into the terms of a transcript.
+// GPAs are weighted by credits and only count graded classes.
+func buildTranscript(studentID int64, entries []models.TranscriptEntry) models.Transcript {
+	transcript := models.Transcript{StudentID: studentID, Terms: []models.TranscriptTerm{}}
+
+	var all []grading.Weighted
+
+	termGrades := make(map[int64][]grading.Weighted)
+
+	for _, entry := range entries {
+		n := len(transcript.Terms)
+		if n == 0 || transcript.Terms[n-1].TermID != entry.Term.TermID {
+			transcript.Terms = append(transcript.Terms, models.TranscriptTerm{
+				TermID:    entry.Term.TermID,
+				Name:      entry.Term.Name,
+				StartDate: entry.Term.StartDate,
+				EndDate:   entry.Term.EndDate,
+				Status:    entry.Term.Status,
+				Classes:   []models.TranscriptClass{},
+			})
+			n++
+		}
+
+		class := models.TranscriptClass{
+			ClassInfoID: entry.ClassInfoID,
+			ClassName:   entry.ClassName,
+			Credits:     entry.Credits,
+		}
+
+		if entry.Grade != nil {
+			score, points := entry.Grade.Score, entry.Grade.GradePoints
+			class.Score, class.Letter, class.GradePoints = &score, entry.Grade.Letter, &points
+
+			weighted := grading.Weighted{Points: points, Credits: entry.Credits}
+			termGrades[entry.Term.TermID] = append(termGrades[entry.Term.TermID], weighted)
+			all = append(all, weighted)
+		}
+
+		transcript.Terms[n-1].Classes = append(transcript.Terms[n-1].Classes, class)
+	}
+
+	for i := range transcript.Terms {
+		transcript.Terms[i].GPA, transcript.Terms[i].Credits = gpa(termGrades[transcript.Terms[i].TermID])
+	}
+
+	transcript.GPA, transcript.Credits = gpa(all)
+
+	return transcript
+}
+
+// gpa returns the GPA of grades and the credits it covers, or nil when no grade carries credits.
+func gpa(grades []grading.Weighted) (*float64, float64) {
+	value, credits, ok := grading.GPA(grades)
+	if !ok {
+		return nil, 0
+	}
+
+	return &value, credits
+}
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/grading"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testScales = grading.NewScales("simple",
+	grading.Scale{Name: "simple", Bands: []grading.Band{
+		{Letter: "A", MinScore: 90, Points: 4},
+		{Letter: "B", MinScore: 80, Points: 3},
+		{Letter: "F", MinScore: 0, Points: 0},
+	}},
+	grading.Scale{Name: "pass", Bands: []grading.Band{
+		{Letter: "P", MinScore: 50, Points: 1},
+		{Letter: "F", MinScore: 0, Points: 0},
+	}},
+)
+
+func newGradeRouter(gradeStorage *mock_repository.MockGradePgRepo, classStorage *mock_repository.MockClassPgRepo) http.Handler {
+	return NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithGrades(gradeStorage, classStorage, testScales))
+}
+
+func TestGradeHandler_Set(t *testing.T) {
+	t.Parallel()
+	gradedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		description    string
+		body           string
+		mock           func(m *mock_repository.MockGradePgRepo)
+		expectedCode   int
+		expectedLetter string
+		expectedDetail string
+	}{
+		{
+			description: "Graded on the default scale",
+			body:        `{"score": 89.996}`,
+			mock: func(m *mock_repository.MockGradePgRepo) {
+				grade := models.Grade{ClassInfoID: 7, Score: 90, Letter: "A", GradePoints: 4, Scale: "simple"}
+				stored := grade
+				stored.GradedAt = gradedAt
+				m.EXPECT().Set(gomock.Any(), grade).Return(stored, nil)
+			},
+			expectedCode:   http.StatusOK,
+			expectedLetter: "A",
+		},
+		{
+			description: "Graded on a named scale",
+			body:        `{"score": 55, "scale": "pass"}`,
+			mock: func(m *mock_repository.MockGradePgRepo) {
+				grade := models.Grade{ClassInfoID: 7, Score: 55, Letter: "P", GradePoints: 1, Scale: "pass"}
+				m.EXPECT().Set(gomock.Any(), grade).Return(grade, nil)
+			},
+			expectedCode:   http.StatusOK,
+			expectedLetter: "P",
+		},
+		{
+			description:    "Score out of range",
+			body:           `{"score": 101}`,
+			mock:           func(m *mock_repository.MockGradePgRepo) {},
+			expectedCode:   http.StatusBadRequest,
+			expectedDetail: "score must be between 0 and 100",
+		},
+		{
+			description:    "Unknown scale",
+			body:           `{"score": 90, "scale": "ib"}`,
+			mock:           func(m *mock_repository.MockGradePgRepo) {},
+			expectedCode:   http.StatusBadRequest,
+			expectedDetail: `unknown grading scale: "ib"`,
+		},
+		{
+			description: "Enrollment not found",
+			body:        `{"score": 90}`,
+			mock: func(m *mock_repository.MockGradePgRepo) {
+				m.EXPECT().Set(gomock.Any(), gomock.Any()).Return(models.Grade{}, pkgErrors.ErrNotFound)
+			},
+			expectedCode:   http.StatusNotFound,
+			expectedDetail: "class_info not found",
+		},
+		{
+			description: "Term closed",
+			body:        `{"score": 90}`,
+			mock: func(m *mock_repository.MockGradePgRepo) {
+				m.EXPECT().Set(gomock.Any(), gomock.Any()).Return(models.Grade{}, pkgErrors.ErrTermClosed)
+			},
+			expectedCode:   http.StatusConflict,
+			expectedDetail: "the academic term of this class_info is closed",
+		},
+		{
+			description: "Waitlisted",
+			body:        `{"score": 90}`,
+			mock: func(m *mock_repository.MockGradePgRepo) {
+				m.EXPECT().Set(gomock.Any(), gomock.Any()).Return(models.Grade{}, pkgErrors.ErrWaitlisted)
+			},
+			expectedCode:   http.StatusConflict,
+			expectedDetail: "class_info is waitlisted",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockGradePgRepo(ctrl)
+			tc.mock(mockRepo)
+			router := newGradeRouter(mockRepo, mock_repository.NewMockClassPgRepo(ctrl))
+			req := httptest.NewRequest(http.MethodPut, "/v2/class_info/7/grade", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				var actual problem.Details
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+				assert.Equal(t, tc.expectedDetail, actual.Detail)
+				return
+			}
+			var actual struct {
+				Data models.Grade `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, tc.expectedLetter, actual.Data.Letter)
+		})
+	}
+}
+
+func TestGradeHandler_Delete(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description  string
+		err          error
+		expectedCode int
+	}{
+		{description: "Deleted", expectedCode: http.StatusNoContent},
+		{description: "Not graded", err: pkgErrors.ErrNotFound, expectedCode: http.StatusNotFound},
+		{description: "Term closed", err: pkgErrors.ErrTermClosed, expectedCode: http.StatusConflict},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockGradePgRepo(ctrl)
+			mockRepo.EXPECT().Delete(gomock.Any(), int64(7)).Return(tc.err)
+			router := newGradeRouter(mockRepo, mock_repository.NewMockClassPgRepo(ctrl))
+			req := httptest.NewRequest(http.MethodDelete, "/v2/class_info/7/grade", nil)
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func transcriptEntries() []models.TranscriptEntry {
+	fall := models.AcademicTerm{TermID: 1, Name: "Fall 2025", StartDate: "2025-09-01", EndDate: "2025-12-20", Status: models.TermStatusClosed}
+	spring := models.AcademicTerm{TermID: 2, Name: "Spring 2026", StartDate: "2026-01-10", EndDate: "2026-05-30", Status: models.TermStatusOpen}
+
+	return []models.TranscriptEntry{
+		{Term: fall, ClassInfoID: 10, ClassName: "Math", Credits: 3, Grade: &models.Grade{Score: 95, Letter: "A", GradePoints: 4}},
+		{Term: fall, ClassInfoID: 11, ClassName: "Music", Credits: 1, Grade: &models.Grade{Score: 85, Letter: "B", GradePoints: 3}},
+		{Term: spring, ClassInfoID: 12, ClassName: "Physics", Credits: 4, Grade: &models.Grade{Score: 82, Letter: "B", GradePoints: 3}},
+		{Term: spring, ClassInfoID: 13, ClassName: "Chemistry", Credits: 4},
+	}
+}
+
+func TestBuildTranscript(t *testing.T) {
+	t.Parallel()
+	// arrange
+	entries := transcriptEntries()
+	// act
+	transcript := buildTranscript(5, entries)
+	// assert
+	require.Len(t, transcript.Terms, 2)
+	assert.Len(t, transcript.Terms[0].Classes, 2)
+	require.NotNil(t, transcript.Terms[0].GPA)
+	assert.Equal(t, 3.75, *transcript.Terms[0].GPA)
+	assert.Equal(t, 4.0, transcript.Terms[0].Credits)
+	require.Len(t, transcript.Terms[1].Classes, 2)
+	assert.Nil(t, transcript.Terms[1].Classes[1].Score)
+	assert.Equal(t, 3.0, *transcript.Terms[1].GPA)
+	assert.Equal(t, 4.0, transcript.Terms[1].Credits)
+	require.NotNil(t, transcript.GPA)
+	assert.Equal(t, 3.38, *transcript.GPA)
+	assert.Equal(t, 8.0, transcript.Credits)
+}
+
+func TestBuildTranscript_NoGrades(t *testing.T) {
+	t.Parallel()
+	// arrange
+	entries := []models.TranscriptEntry{}
+	// act
+	transcript := buildTranscript(5, entries)
+	// assert
+	assert.Equal(t, []models.TranscriptTerm{}, transcript.Terms)
+	assert.Nil(t, transcript.GPA)
+}
+
+func TestGradeHandler_Transcript(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description         string
+		accept              string
+		err                 error
+		expectedCode        int
+		expectedContentType string
+	}{
+		{
+			description:         "JSON",
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/json",
+		},
+		{
+			description:         "PDF",
+			accept:              "application/pdf",
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/pdf",
+		},
+		{
+			description:         "Student not found",
+			err:                 pkgErrors.ErrNotFound,
+			expectedCode:        http.StatusNotFound,
+			expectedContentType: "application/problem+json",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockGradePgRepo(ctrl)
+			entries := transcriptEntries()
+			if tc.err != nil {
+				entries = nil
+			}
+			mockRepo.EXPECT().GetTranscript(gomock.Any(), int64(5)).Return(entries, tc.err)
+			router := newGradeRouter(mockRepo, mock_repository.NewMockClassPgRepo(ctrl))
+			req := httptest.NewRequest(http.MethodGet, "/v2/student/5/transcript", nil)
+			req.Header.Set("Accept", tc.accept)
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			assert.Equal(t, tc.expectedContentType, rr.Header().Get("Content-Type"))
+			switch tc.expectedContentType {
+			case "application/pdf":
+				assert.True(t, bytes.HasPrefix(rr.Body.Bytes(), []byte("%PDF-")))
+				assert.Equal(t, `attachment; filename="transcript-5.pdf"`, rr.Header().Get("Content-Disposition"))
+			case "application/json":
+				var actual struct {
+					Data models.Transcript `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+				assert.Len(t, actual.Data.Terms, 2)
+				assert.Equal(t, 3.38, *actual.Data.GPA)
+			}
+		})
+	}
+}
+
+func TestGradeHandler_TranscriptPDFOnlyOnTranscript(t *testing.T) {
+	t.Parallel()
+	// arrange
+	ctrl := gomock.NewController(t)
+	router := newGradeRouter(mock_repository.NewMockGradePgRepo(ctrl), mock_repository.NewMockClassPgRepo(ctrl))
+	req := httptest.NewRequest(http.MethodGet, "/v2/class_info/7/grade", nil)
+	req.Header.Set("Accept", "application/pdf")
+	rr := httptest.NewRecorder()
+	// act
+	router.ServeHTTP(rr, req)
+	// assert
+	assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// Route names of the v2 API. Links are built from them, so that representations follow path changes.
+const (
+	routeStudents       = "v2.students"
+	routeStudent        = "v2.student"
+	routeStudentClasses = "v2.student.class_info"
+	routeClassInfo      = "v2.class_info"
+	routeTerm           = "v2.term"
+	routeGrade          = "v2.class_info.grade"
+	routeTranscript     = "v2.student.transcript"
+	routeSession        = "v2.session"
+	routeRoom           = "v2.room"
+	routeScheduleSlot   = "v2.schedule_slot"
+	routeContact        = "v2.student.contact"
+	routeAssignment     = "v2.assignment"
+)
+
+// links builds resource URLs from the named routes of router.
+type links struct {
+	router        *mux.Router
+	queryParamKey string
+}
+
+func newLinks(router *mux.Router, queryParamKey string) *links {
+	return &links{router: router, queryParamKey: queryParamKey}
+}
+
+// url returns the path of the named route, or an empty string when the route is not registered.
+func (l *links) url(name string, id ...int64) string {
+	if l == nil || l.router == nil {
+		return ""
+	}
+
+	route := l.router.Get(name)
+	if route == nil {
+		return ""
+	}
+
+	var pairs []string
+	if len(id) > 0 {
+		pairs = []string{l.queryParamKey, strconv.FormatInt(id[0], 10)}
+	}
+
+	u, err := route.URL(pairs...)
+	if err != nil {
+		return ""
+	}
+
+	return u.String()
+}
+
+func (l *links) student(id int64) string        { return l.url(routeStudent, id) }
+func (l *links) studentClasses(id int64) string { return l.url(routeStudentClasses, id) }
+func (l *links) classInfo(id int64) string      { return l.url(routeClassInfo, id) }
+func (l *links) students() string               { return l.url(routeStudents) }
+func (l *links) term(id int64) string           { return l.url(routeTerm, id) }
+func (l *links) grade(id int64) string          { return l.url(routeGrade, id) }
+func (l *links) transcript(id int64) string     { return l.url(routeTranscript, id) }
+func (l *links) session(id int64) string        { return l.url(routeSession, id) }
+func (l *links) room(id int64) string           { return l.url(routeRoom, id) }
+func (l *links) scheduleSlot(id int64) string   { return l.url(routeScheduleSlot, id) }
+func (l *links) assignment(id int64) string     { return l.url(routeAssignment, id) }
+
+// contact returns the path of a contact of a student, which takes both ids.
+func (l *links) contact(studentID, contactID int64) string {
+	if l == nil || l.router == nil {
+		return ""
+	}
+
+	route := l.router.Get(routeContact)
+	if route == nil {
+		return ""
+	}
+
+	u, err := route.URL(
+		l.queryParamKey, strconv.FormatInt(studentID, 10),
+		contactParamKey, strconv.FormatInt(contactID, 10),
+	)
+	if err != nil {
+		return ""
+	}
+
+	return u.String()
+}
+
+// studentResource renders the requested fields of student with its links, embedding classes when they are included.
+func (l *links) studentResource(
+	student models.StudentRequest,
+	fields []string,
+	classes *[]models.ClassInfo,
+) models.StudentResource {
+	resource := models.StudentResource{
+		StudentView: studentView(student, fields, nil),
+		Links: models.HALLinks{
+			"self":    {Href: l.student(student.StudentID)},
+			"classes": {Href: l.studentClasses(student.StudentID)},
+		},
+	}
+
+	// Transcripts are only linked when their routes are mounted.
+	if transcript := l.transcript(student.StudentID); transcript != "" {
+		resource.Links["transcript"] = models.HALLink{Href: transcript}
+	}
+
+	if classes != nil {
+		resource.Embedded = &models.StudentEmbedded{Classes: l.classInfoResources(*classes)}
+	}
+
+	return resource
+}
+
+func (l *links) classInfoResource(classInfo models.ClassInfo) models.ClassInfoResource {
+	resource := models.ClassInfoResource{
+		ClassInfo: classInfo,
+		Links: models.HALLinks{
+			"self":    {Href: l.classInfo(classInfo.ID)},
+			"student": {Href: l.student(classInfo.StudentID)},
+		},
+	}
+
+	// Terms and grades are only linked when their routes are mounted.
+	if term := l.term(classInfo.TermID); term != "" {
+		resource.Links["term"] = models.HALLink{Href: term}
+	}
+
+	if grade := l.grade(classInfo.ID); grade != "" {
+		resource.Links["grade"] = models.HALLink{Href: grade}
+	}
+
+	return resource
+}
+
+func (l *links) classInfoResources(classesInfo []models.ClassInfo) []models.ClassInfoResource {
+	resources := make([]models.ClassInfoResource, 0, len(classesInfo))
+	for _, classInfo := range classesInfo {
+		resources = append(resources, l.classInfoResource(classInfo))
+	}
+
+	return resources
+}
+
+// halPageLinks converts the page links of base into HAL links.
+func halPageLinks(base string, req *http.Request, total int64, p page) models.HALLinks {
+	pageLinks := pageLinks(base, req, total, p)
+	halLinks := models.HALLinks{"self": {Href: pageLinks.Self}}
+
+	if pageLinks.Next != "" {
+		halLinks["next"] = models.HALLink{Href: pageLinks.Next}
+	}
+
+	if pageLinks.Prev != "" {
+		halLinks["prev"] = models.HALLink{Href: pageLinks.Prev}
+	}
+
+	return halLinks
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const halContentType = "application/hal+json"
+
+func TestStudentHandlerV2_GetHAL(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	student := models.StudentRequest{StudentID: 1, StudentName: "Test", Grade: 90}
+	tests := []struct {
+		description  string
+		query        string
+		embedClasses bool
+		expectedCode int
+		expectedJSON string
+	}{
+		{
+			description:  "Links only",
+			expectedCode: http.StatusOK,
+			expectedJSON: `{
+				"student_id": 1, "student_name": "Test", "grade": 90,
+				"_links": {"self": {"href": "/v2/student/1"}, "classes": {"href": "/v2/student/1/class_info"}}
+			}`,
+		},
+		{
+			description:  "Embedded classes",
+			query:        "?embed=classes",
+			embedClasses: true,
+			expectedCode: http.StatusOK,
+			expectedJSON: `{
+				"student_id": 1, "student_name": "Test", "grade": 90,
+				"_links": {"self": {"href": "/v2/student/1"}, "classes": {"href": "/v2/student/1/class_info"}},
+				"_embedded": {"classes": [{
+					"id": 2, "student_id": 1, "class_name": "Math", "term_id": 0,
+					"_links": {"self": {"href": "/v2/class_info/2"}, "student": {"href": "/v2/student/1"}}
+				}]}
+			}`,
+		},
+		{
+			description:  "Unknown relation",
+			query:        "?embed=teachers",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			studentRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+			classInfoRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+			studentHandler := NewStudentHandlerV2(studentRepo, classInfoRepo, queryParamKey, v2Routes())
+			if tc.expectedCode == http.StatusOK {
+				studentRepo.EXPECT().GetByIDWithFields(gomock.Any(), int64(1), gomock.Nil()).Return(student, nil)
+			}
+			if tc.embedClasses {
+				classInfoRepo.EXPECT().GetByStudentID(gomock.Any(), int64(1)).
+					Return([]models.ClassInfo{{ID: 2, StudentID: 1, ClassName: "Math"}}, nil)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/v2/student/1"+tc.query, nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept", halContentType)
+			req = mux.SetURLVars(req, map[string]string{queryParamKey: "1"})
+			rr := httptest.NewRecorder()
+			// act
+			studentHandler.Get(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+			assert.Equal(t, halContentType, rr.Header().Get("Content-Type"))
+			assert.JSONEq(t, tc.expectedJSON, rr.Body.String())
+		})
+	}
+}
+
+func TestStudentHandlerV2_ListHAL(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	ctrl := gomock.NewController(t)
+	studentRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+	studentHandler := NewStudentHandlerV2(studentRepo, nil, queryParamKey, v2Routes())
+	studentRepo.EXPECT().List(gomock.Any(), int64(1), int64(1), gomock.Nil()).
+		Return([]models.StudentRequest{{StudentID: 2, StudentName: "B", Grade: 80}}, nil)
+	studentRepo.EXPECT().Count(gomock.Any()).Return(int64(3), nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v2/student?limit=1&offset=1", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", halContentType)
+	rr := httptest.NewRecorder()
+	// act
+	studentHandler.List(rr, req)
+	// assert
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"_links": {
+			"self": {"href": "/v2/student?limit=1&offset=1"},
+			"next": {"href": "/v2/student?limit=1&offset=2"},
+			"prev": {"href": "/v2/student?limit=1&offset=0"}
+		},
+		"_embedded": {"students": [{
+			"student_id": 2, "student_name": "B", "grade": 80,
+			"_links": {"self": {"href": "/v2/student/2"}, "classes": {"href": "/v2/student/2/class_info"}}
+		}]},
+		"total": 3, "count": 1, "limit": 1, "offset": 1
+	}`, rr.Body.String())
+}
+
+func TestClassInfoHandlerV2_ListByStudentHAL(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	ctrl := gomock.NewController(t)
+	classInfoRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+	classInfoHandler := NewClassInfoHandlerV2(classInfoRepo, queryParamKey, v2Routes())
+	classInfoRepo.EXPECT().GetByStudentIDInTerm(gomock.Any(), int64(1), gomock.Nil()).
+		Return([]models.ClassInfo{{ID: 2, StudentID: 1, ClassName: "Math"}}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v2/student/1/class_info", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", halContentType)
+	req = mux.SetURLVars(req, map[string]string{queryParamKey: "1"})
+	rr := httptest.NewRecorder()
+	// act
+	classInfoHandler.ListByStudent(rr, req)
+	// assert
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"_links": {
+			"self": {"href": "/v2/student/1/class_info?limit=20&offset=0"},
+			"student": {"href": "/v2/student/1"}
+		},
+		"_embedded": {"classes": [{
+			"id": 2, "student_id": 1, "class_name": "Math", "term_id": 0,
+			"_links": {"self": {"href": "/v2/class_info/2"}, "student": {"href": "/v2/student/1"}}
+		}]},
+		"total": 1, "count": 1, "limit": 20, "offset": 0
+	}`, rr.Body.String())
+}
@@ -19,3 +19,135 @@ type ClassInfoHandlerInterface interface {
 	DeleteClassByStudent(w http.ResponseWriter, req *http.Request)
 	GetAllClassesByStudent(w http.ResponseWriter, req *http.Request)
 }
+
+// TeacherHandlerInterface defines the methods required for handling teacher-related requests.
+type TeacherHandlerInterface interface {
+	Create(w http.ResponseWriter, req *http.Request)
+	Update(w http.ResponseWriter, req *http.Request)
+	Get(w http.ResponseWriter, req *http.Request)
+	Delete(w http.ResponseWriter, req *http.Request)
+	GetClasses(w http.ResponseWriter, req *http.Request)
+	AssignClass(w http.ResponseWriter, req *http.Request)
+	UnassignClass(w http.ResponseWriter, req *http.Request)
+	GetTeachersByClass(w http.ResponseWriter, req *http.Request)
+}
+
+// HealthHandlerInterface defines the methods required for serving health probes.
+type HealthHandlerInterface interface {
+	Liveness(w http.ResponseWriter, req *http.Request)
+	Readiness(w http.ResponseWriter, req *http.Request)
+	Details(w http.ResponseWriter, req *http.Request)
+}
+
+// RoleHandlerInterface defines the methods required for managing role assignments.
+type RoleHandlerInterface interface {
+	List(w http.ResponseWriter, req *http.Request)
+	GetBySubject(w http.ResponseWriter, req *http.Request)
+	Assign(w http.ResponseWriter, req *http.Request)
+	Revoke(w http.ResponseWriter, req *http.Request)
+}
+
+// StudentHandlerV2Interface defines the methods required for the v2 student endpoints.
+type StudentHandlerV2Interface interface {
+	Create(w http.ResponseWriter, req *http.Request)
+	Get(w http.ResponseWriter, req *http.Request)
+	List(w http.ResponseWriter, req *http.Request)
+	Update(w http.ResponseWriter, req *http.Request)
+	Delete(w http.ResponseWriter, req *http.Request)
+	SetStatus(w http.ResponseWriter, req *http.Request)
+	StatusHistory(w http.ResponseWriter, req *http.Request)
+}
+
+// ClassInfoHandlerV2Interface defines the methods required for the v2 class_info endpoints.
+type ClassInfoHandlerV2Interface interface {
+	Create(w http.ResponseWriter, req *http.Request)
+	Get(w http.ResponseWriter, req *http.Request)
+	ListByStudent(w http.ResponseWriter, req *http.Request)
+	Update(w http.ResponseWriter, req *http.Request)
+	Delete(w http.ResponseWriter, req *http.Request)
+	DeleteByStudent(w http.ResponseWriter, req *http.Request)
+}
+
+// TermHandlerInterface defines the methods required for the v2 academic term endpoints.
+type TermHandlerInterface interface {
+	Create(w http.ResponseWriter, req *http.Request)
+	Get(w http.ResponseWriter, req *http.Request)
+	GetCurrent(w http.ResponseWriter, req *http.Request)
+	List(w http.ResponseWriter, req *http.Request)
+	Update(w http.ResponseWriter, req *http.Request)
+}
+
+// GradeHandlerInterface defines the methods required for the v2 grade and transcript endpoints.
+type GradeHandlerInterface interface {
+	Set(w http.ResponseWriter, req *http.Request)
+	Get(w http.ResponseWriter, req *http.Request)
+	Delete(w http.ResponseWriter, req *http.Request)
+	Transcript(w http.ResponseWriter, req *http.Request)
+}
+
+// ClassHandlerInterface defines the methods required for the v2 class endpoints.
+type ClassHandlerInterface interface {
+	List(w http.ResponseWriter, req *http.Request)
+	Get(w http.ResponseWriter, req *http.Request)
+	Update(w http.ResponseWriter, req *http.Request)
+	Events(w http.ResponseWriter, req *http.Request)
+}
+
+// PrerequisiteHandlerInterface defines the methods required for the v2 prerequisite endpoints.
+type PrerequisiteHandlerInterface interface {
+	Get(w http.ResponseWriter, req *http.Request)
+	Replace(w http.ResponseWriter, req *http.Request)
+	Eligibility(w http.ResponseWriter, req *http.Request)
+}
+
+// AttendanceHandlerInterface defines the methods required for the v2 class session and attendance endpoints.
+type AttendanceHandlerInterface interface {
+	CreateSession(w http.ResponseWriter, req *http.Request)
+	ListSessions(w http.ResponseWriter, req *http.Request)
+	GetSession(w http.ResponseWriter, req *http.Request)
+	DeleteSession(w http.ResponseWriter, req *http.Request)
+	GetAttendance(w http.ResponseWriter, req *http.Request)
+	MarkAttendance(w http.ResponseWriter, req *http.Request)
+	StudentReport(w http.ResponseWriter, req *http.Request)
+	ClassReport(w http.ResponseWriter, req *http.Request)
+}
+
+// ScheduleHandlerInterface defines the methods required for the v2 room, schedule slot and student schedule endpoints.
+type ScheduleHandlerInterface interface {
+	ListRooms(w http.ResponseWriter, req *http.Request)
+	CreateRoom(w http.ResponseWriter, req *http.Request)
+	GetRoom(w http.ResponseWriter, req *http.Request)
+	UpdateRoom(w http.ResponseWriter, req *http.Request)
+	DeleteRoom(w http.ResponseWriter, req *http.Request)
+	ListClassSlots(w http.ResponseWriter, req *http.Request)
+	CreateSlot(w http.ResponseWriter, req *http.Request)
+	GetSlot(w http.ResponseWriter, req *http.Request)
+	UpdateSlot(w http.ResponseWriter, req *http.Request)
+	DeleteSlot(w http.ResponseWriter, req *http.Request)
+	StudentSchedule(w http.ResponseWriter, req *http.Request)
+	StudentScheduleICS(w http.ResponseWriter, req *http.Request)
+}
+
+// ContactHandlerInterface defines the methods required for the v2 student contact endpoints.
+type ContactHandlerInterface interface {
+	List(w http.ResponseWriter, req *http.Request)
+	Create(w http.ResponseWriter, req *http.Request)
+	Get(w http.ResponseWriter, req *http.Request)
+	Update(w http.ResponseWriter, req *http.Request)
+	Delete(w http.ResponseWriter, req *http.Request)
+}
+
+// AssignmentHandlerInterface defines the methods required for the v2 assignment, submission and gradebook endpoints.
+type AssignmentHandlerInterface interface {
+	List(w http.ResponseWriter, req *http.Request)
+	Create(w http.ResponseWriter, req *http.Request)
+	Get(w http.ResponseWriter, req *http.Request)
+	Update(w http.ResponseWriter, req *http.Request)
+	Delete(w http.ResponseWriter, req *http.Request)
+	ListSubmissions(w http.ResponseWriter, req *http.Request)
+	GetSubmission(w http.ResponseWriter, req *http.Request)
+	SetSubmission(w http.ResponseWriter, req *http.Request)
+	DeleteSubmission(w http.ResponseWriter, req *http.Request)
+	Gradebook(w http.ResponseWriter, req *http.Request)
+	RollUp(w http.ResponseWriter, req *http.Request)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/health"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// HealthHandler serves the liveness, readiness and detailed health endpoints.
+type HealthHandler struct {
+	checker      *health.Checker
+	detailsToken string
+}
+
+// NewHealthHandler creates a new HealthHandler. When detailsToken is empty the
+// detailed report is only served to loopback clients.
+func NewHealthHandler(checker *health.Checker, detailsToken string) *HealthHandler {
+	return &HealthHandler{
+		checker:      checker,
+		detailsToken: detailsToken,
+	}
+}
+
+// Liveness reports that the process is running and able to serve HTTP.
+func (h *HealthHandler) Liveness(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write([]byte("ok"))
+	if err != nil {
+		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+	}
+}
+
+// Readiness reports whether the instance should receive traffic.
+func (h *HealthHandler) Readiness(w http.ResponseWriter, req *http.Request) {
+	if h.checker.Draining() {
+		http.Error(w, "not ready: shutting down", http.StatusServiceUnavailable)
+		return
+	}
+
+	report := h.checker.Run(req.Context())
+	if report.Status != health.StatusUp {
+		for _, result := range report.Checks {
+			if result.Status != health.StatusUp {
+				http.Error(w, fmt.Sprintf("not ready: %s check failed", result.Name), http.StatusServiceUnavailable)
+				return
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write([]byte("ready"))
+	if err != nil {
+		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+	}
+}
+
+// Details returns the per-check status and latency report.
+func (h *HealthHandler) Details(w http.ResponseWriter, req *http.Request) {
+	if !h.authorized(req) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	report := h.checker.Run(req.Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeResponse(w, responseCodec, status, report)
+}
+
+func (h *HealthHandler) authorized(req *http.Request) bool {
+	if h.detailsToken == "" {
+		host, _, err := net.SplitHostPort(req.RemoteAddr)
+		if err != nil {
+			return false
+		}
+
+		ip := net.ParseIP(host)
+
+		return ip != nil && ip.IsLoopback()
+	}
+
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.detailsToken)) == 1
+}
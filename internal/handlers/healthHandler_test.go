@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/health"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler_Readiness(t *testing.T) {
+	t.Parallel()
+
+	upCheck := health.Check{Name: "postgres", Run: func(context.Context) error { return nil }}
+	downCheck := health.Check{Name: "migrations", Run: func(context.Context) error { return assert.AnError }}
+
+	tests := []struct {
+		description     string
+		checks          []health.Check
+		draining        bool
+		expectedCode    int
+		expectedMessage string
+	}{
+		{
+			description:     "Ready",
+			checks:          []health.Check{upCheck},
+			expectedCode:    http.StatusOK,
+			expectedMessage: "ready",
+		},
+		{
+			description:     "Dependency down",
+			checks:          []health.Check{upCheck, downCheck},
+			expectedCode:    http.StatusServiceUnavailable,
+			expectedMessage: "not ready: migrations check failed\n",
+		},
+		{
+			description:     "Draining",
+			checks:          []health.Check{upCheck},
+			draining:        true,
+			expectedCode:    http.StatusServiceUnavailable,
+			expectedMessage: "not ready: shutting down\n",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			checker := health.NewChecker(tc.checks...)
+			if tc.draining {
+				checker.SetDraining()
+			}
+
+			healthHandler := NewHealthHandler(checker, "")
+
+			req, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			// act
+			healthHandler.Readiness(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			assert.Equal(t, tc.expectedMessage, rr.Body.String())
+		})
+	}
+}
+
+func TestHealthHandler_Details(t *testing.T) {
+	t.Parallel()
+
+	checker := health.NewChecker(
+		health.Check{Name: "postgres", Run: func(context.Context) error { return nil }},
+		health.Check{Name: "migrations", Run: func(context.Context) error { return assert.AnError }},
+	)
+
+	tests := []struct {
+		description   string
+		detailsToken  string
+		remoteAddr    string
+		authorization string
+		expectedCode  int
+	}{
+		{
+			description:  "Loopback without token configured",
+			remoteAddr:   "127.0.0.1:5000",
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			description:  "Remote without token configured",
+			remoteAddr:   "10.0.0.8:5000",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:   "Wrong token",
+			detailsToken:  "secret",
+			remoteAddr:    "10.0.0.8:5000",
+			authorization: "Bearer guess",
+			expectedCode:  http.StatusForbidden,
+		},
+		{
+			description:   "Valid token",
+			detailsToken:  "secret",
+			remoteAddr:    "10.0.0.8:5000",
+			authorization: "Bearer secret",
+			expectedCode:  http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			healthHandler := NewHealthHandler(checker, tc.detailsToken)
+
+			req, err := http.NewRequest(http.MethodGet, "/health/details", nil)
+			require.NoError(t, err)
+
+			req.RemoteAddr = tc.remoteAddr
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+
+			rr := httptest.NewRecorder()
+			// act
+			healthHandler.Details(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+
+			if rr.Code == http.StatusForbidden {
+				return
+			}
+
+			var report health.Report
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+			assert.Equal(t, health.StatusDown, report.Status)
+			require.Len(t, report.Checks, 2)
+			assert.Equal(t, health.StatusUp, report.Checks[0].Status)
+			assert.Equal(t, health.StatusDown, report.Checks[1].Status)
+			assert.Equal(t, assert.AnError.Error(), report.Checks[1].Error)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import "encoding/xml"
+
+// Statuses of an academic term. Terms move forward only, and the enrollments of a closed term are frozen.
+const (
+	TermStatusPlanned = "planned"
+	TermStatusOpen    = "open"
+	TermStatusClosed  = "closed"
+)
+
+// DateLayout is the format of calendar dates in requests and responses.
+const DateLayout = "2006-01-02"
+
+type AcademicTerm struct {
+	XMLName   xml.Name `json:"-" xml:"academic_term"`
+	TermID    int64    `json:"term_id" xml:"term_id"`
+	Name      string   `json:"name" xml:"name"`
+	StartDate string   `json:"start_date" xml:"start_date"`
+	EndDate   string   `json:"end_date" xml:"end_date"`
+	Status    string   `json:"status" xml:"status"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Statuses of a submission. SubmissionPending is never stored: it is the status of an enrolled
+// student whose submission has not been recorded yet.
+const (
+	SubmissionPending   = "pending"
+	SubmissionSubmitted = "submitted"
+	SubmissionMissing   = "missing"
+	SubmissionExcused   = "excused"
+)
+
+// Assignment is work published for a class during a term. Weight is the share of the assignment
+// in the assignment score of the class, 1 unless set.
+type Assignment struct {
+	XMLName      xml.Name  `json:"-" xml:"assignment"`
+	AssignmentID int64     `json:"assignment_id" xml:"assignment_id"`
+	ClassID      int64     `json:"class_id" xml:"class_id"`
+	TermID       int64     `json:"term_id" xml:"term_id"`
+	Title        string    `json:"title" xml:"title"`
+	Description  string    `json:"description" xml:"description"`
+	DueAt        time.Time `json:"due_at" xml:"due_at"`
+	MaxPoints    float64   `json:"max_points" xml:"max_points"`
+	Weight       *float64  `json:"weight" xml:"weight,omitempty"`
+}
+
+// Submission is the work of an enrolled student on an assignment. Late is true when it was submitted
+// after the due date, or when it is still pending after the due date.
+type Submission struct {
+	XMLName      xml.Name   `json:"-" xml:"submission"`
+	AssignmentID int64      `json:"assignment_id" xml:"assignment_id"`
+	StudentID    int64      `json:"student_id" xml:"student_id"`
+	StudentName  string     `json:"student_name,omitempty" xml:"student_name,omitempty"`
+	ClassInfoID  int64      `json:"class_info_id" xml:"class_info_id"`
+	Status       string     `json:"status" xml:"status"`
+	SubmittedAt  *time.Time `json:"submitted_at" xml:"submitted_at,omitempty"`
+	Late         bool       `json:"late" xml:"late"`
+	Score        *float64   `json:"score" xml:"score,omitempty"`
+	Feedback     string     `json:"feedback" xml:"feedback"`
+}
+
+// Gradebook is the students × assignments matrix of a class in a term.
+type Gradebook struct {
+	XMLName     xml.Name       `json:"-" xml:"gradebook"`
+	ClassID     int64          `json:"class_id" xml:"class_id"`
+	Term        AcademicTerm   `json:"term" xml:"term"`
+	Assignments []Assignment   `json:"assignments" xml:"assignments>assignment"`
+	Students    []GradebookRow `json:"students" xml:"students>student"`
+}
+
+// GradebookRow is an enrolled student with one submission per assignment of the gradebook, in the same
+// order. Score is the weighted percentage of the counted submissions, or nil when none counts.
+type GradebookRow struct {
+	StudentID   int64        `json:"student_id" xml:"student_id"`
+	StudentName string       `json:"student_name" xml:"student_name"`
+	ClassInfoID int64        `json:"class_info_id" xml:"class_info_id"`
+	Submissions []Submission `json:"submissions" xml:"submissions>submission"`
+	Score       *float64     `json:"score" xml:"score,omitempty"`
+}
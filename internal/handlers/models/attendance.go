@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Attendance statuses of a student in a class session.
+const (
+	AttendancePresent = "present"
+	AttendanceAbsent  = "absent"
+	AttendanceLate    = "late"
+	AttendanceExcused = "excused"
+)
+
+// ClassSession is a meeting of a class. Its attendance is taken for the students enrolled in the class
+// during the term that covers StartsAt.
+type ClassSession struct {
+	XMLName   xml.Name  `json:"-" xml:"class_session"`
+	SessionID int64     `json:"session_id" xml:"session_id"`
+	ClassID   int64     `json:"class_id" xml:"class_id"`
+	ClassName string    `json:"class_name" xml:"class_name"`
+	StartsAt  time.Time `json:"starts_at" xml:"starts_at"`
+	EndsAt    time.Time `json:"ends_at" xml:"ends_at"`
+}
+
+// AttendanceRecord is the attendance of a student in a session. Status is empty, and RecordedAt nil,
+// for a student on the roster who has not been marked yet.
+type AttendanceRecord struct {
+	XMLName     xml.Name   `json:"-" xml:"attendance"`
+	StudentID   int64      `json:"student_id" xml:"student_id"`
+	StudentName string     `json:"student_name,omitempty" xml:"student_name,omitempty"`
+	Status      string     `json:"status" xml:"status"`
+	Note        string     `json:"note" xml:"note"`
+	RecordedAt  *time.Time `json:"recorded_at,omitempty" xml:"recorded_at,omitempty"`
+}
+
+// AttendanceMarking marks a whole session at once. Records set the attendance of the listed students, and
+// DefaultStatus, when set, marks every other student on the roster who has not been marked yet.
+type AttendanceMarking struct {
+	XMLName       xml.Name           `json:"-" xml:"attendance_marking"`
+	DefaultStatus string             `json:"default_status,omitempty" xml:"default_status,omitempty"`
+	Records       []AttendanceRecord `json:"records" xml:"records>attendance"`
+}
+
+// AttendanceRate sums up the attendance of a student in a class over a date range. Rate is the share
+// of present and late among present, late and absent marks; excused and unmarked sessions do not count,
+// and it is nil when no session counts.
+type AttendanceRate struct {
+	XMLName     xml.Name `json:"-" xml:"attendance_rate"`
+	StudentID   int64    `json:"student_id" xml:"student_id"`
+	StudentName string   `json:"student_name" xml:"student_name"`
+	ClassID     int64    `json:"class_id" xml:"class_id"`
+	ClassName   string   `json:"class_name" xml:"class_name"`
+	Sessions    int64    `json:"sessions" xml:"sessions"`
+	Present     int64    `json:"present" xml:"present"`
+	Late        int64    `json:"late" xml:"late"`
+	Absent      int64    `json:"absent" xml:"absent"`
+	Excused     int64    `json:"excused" xml:"excused"`
+	Unmarked    int64    `json:"unmarked" xml:"unmarked"`
+	Rate        *float64 `json:"rate" xml:"rate,omitempty"`
+}
+
+// AttendanceReport lists attendance rates over the dates From to To, both inclusive and empty when open.
+type AttendanceReport struct {
+	XMLName xml.Name         `json:"-" xml:"attendance_report"`
+	From    string           `json:"from,omitempty" xml:"from,omitempty"`
+	To      string           `json:"to,omitempty" xml:"to,omitempty"`
+	Rates   []AttendanceRate `json:"rates" xml:"rates>attendance_rate"`
+}
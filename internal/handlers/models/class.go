@@ -0,0 +1,13 @@
+package models
+
+import "encoding/xml"
+
+// Class is a course that students enroll in by name. Its credits weight its grades in the GPA,
+// and Capacity caps its enrollments per term; nil means unlimited.
+type Class struct {
+	XMLName   xml.Name `json:"-" xml:"class"`
+	ClassID   int64    `json:"class_id" xml:"class_id"`
+	ClassName string   `json:"class_name" xml:"class_name"`
+	Credits   float64  `json:"credits" xml:"credits"`
+	Capacity  *int64   `json:"capacity" xml:"capacity,omitempty"`
+}
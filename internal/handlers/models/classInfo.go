@@ -1,7 +1,42 @@
 package models
 
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Statuses of an enrollment. Enrollments beyond the capacity of a class are waitlisted,
+// and promoted in order when a seat frees up.
+const (
+	EnrollmentStatusEnrolled   = "enrolled"
+	EnrollmentStatusWaitlisted = "waitlisted"
+)
+
+// Events recorded for enrollments.
+const (
+	EnrollmentEventEnrolled   = "enrolled"
+	EnrollmentEventWaitlisted = "waitlisted"
+	EnrollmentEventPromoted   = "promoted"
+	EnrollmentEventDropped    = "dropped"
+)
+
 type ClassInfo struct {
-	ID        int64  `json:"id"`
-	StudentID int64  `json:"student_id"`
-	ClassName string `json:"class_name"`
+	XMLName   xml.Name `json:"-" xml:"class_info"`
+	ID        int64    `json:"id" xml:"id"`
+	StudentID int64    `json:"student_id" xml:"student_id"`
+	ClassName string   `json:"class_name" xml:"class_name"`
+	TermID    int64    `json:"term_id" xml:"term_id"`
+	Status    string   `json:"status,omitempty" xml:"status,omitempty"`
+}
+
+// EnrollmentEvent records a change to an enrollment. The enrollment may have been deleted since.
+type EnrollmentEvent struct {
+	XMLName     xml.Name  `json:"-" xml:"enrollment_event"`
+	EventID     int64     `json:"event_id" xml:"event_id"`
+	ClassInfoID int64     `json:"class_info_id" xml:"class_info_id"`
+	StudentID   int64     `json:"student_id" xml:"student_id"`
+	ClassName   string    `json:"class_name" xml:"class_name"`
+	TermID      int64     `json:"term_id" xml:"term_id"`
+	Event       string    `json:"event" xml:"event"`
+	CreatedAt   time.Time `json:"created_at" xml:"created_at"`
 }
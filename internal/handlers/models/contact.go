@@ -0,0 +1,52 @@
+package models
+
+import (
+	"CRUD_Go_Backend/internal/pkg/pii"
+	"encoding/xml"
+	"log/slog"
+)
+
+// Channels a contact can prefer to be reached on.
+const (
+	ContactChannelEmail = "email"
+	ContactChannelSMS   = "sms"
+	ContactChannelPhone = "phone"
+)
+
+// Relationships of a contact to a student.
+const (
+	RelationshipMother      = "mother"
+	RelationshipFather      = "father"
+	RelationshipParent      = "parent"
+	RelationshipGuardian    = "guardian"
+	RelationshipGrandparent = "grandparent"
+	RelationshipOther       = "other"
+)
+
+// Contact is a parent or guardian of a student. Contacts can be shared by siblings; Relationship
+// is the relationship to the student the contact is read through. Phone is in E.164 format, and
+// each consent flag records whether the contact agreed to be reached on that channel.
+type Contact struct {
+	XMLName          xml.Name `json:"-" xml:"contact"`
+	ContactID        int64    `json:"contact_id" xml:"contact_id"`
+	Name             string   `json:"name" xml:"name"`
+	Relationship     string   `json:"relationship" xml:"relationship"`
+	Email            string   `json:"email,omitempty" xml:"email,omitempty"`
+	Phone            string   `json:"phone,omitempty" xml:"phone,omitempty"`
+	PreferredChannel string   `json:"preferred_channel" xml:"preferred_channel"`
+	ConsentEmail     bool     `json:"consent_email" xml:"consent_email"`
+	ConsentSMS       bool     `json:"consent_sms" xml:"consent_sms"`
+	ConsentPhone     bool     `json:"consent_phone" xml:"consent_phone"`
+}
+
+// LogValue masks the name, email and phone of the contact, so that logging it does not leak personal data.
+func (c Contact) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int64("contact_id", c.ContactID),
+		slog.String("name", pii.Name(c.Name)),
+		slog.String("relationship", c.Relationship),
+		slog.String("email", pii.Email(c.Email)),
+		slog.String("phone", pii.Phone(c.Phone)),
+		slog.String("preferred_channel", c.PreferredChannel),
+	)
+}
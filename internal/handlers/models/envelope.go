@@ -0,0 +1,32 @@
+package models
+
+import "encoding/xml"
+
+// Envelope is the v2 response body for a single resource.
+type Envelope struct {
+	XMLName xml.Name    `json:"-" xml:"response"`
+	Data    interface{} `json:"data" xml:"data"`
+}
+
+// ListEnvelope is the v2 response body for a page of resources.
+type ListEnvelope struct {
+	XMLName xml.Name    `json:"-" xml:"response"`
+	Data    interface{} `json:"data" xml:"data"`
+	Meta    ListMeta    `json:"meta" xml:"meta"`
+	Links   ListLinks   `json:"links" xml:"links"`
+}
+
+// ListMeta describes the page in a ListEnvelope.
+type ListMeta struct {
+	Total  int64 `json:"total" xml:"total"`
+	Count  int   `json:"count" xml:"count"`
+	Limit  int64 `json:"limit" xml:"limit"`
+	Offset int64 `json:"offset" xml:"offset"`
+}
+
+// ListLinks point to neighbouring pages. Next and Prev are empty on the last and first page.
+type ListLinks struct {
+	Self string `json:"self" xml:"self"`
+	Next string `json:"next,omitempty" xml:"next,omitempty"`
+	Prev string `json:"prev,omitempty" xml:"prev,omitempty"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Grade is the grade of one enrollment. Clients send the score and optionally the scale;
+// the letter and grade points are derived from the scale when the grade is set.
+type Grade struct {
+	XMLName     xml.Name  `json:"-" xml:"grade"`
+	ClassInfoID int64     `json:"class_info_id" xml:"class_info_id"`
+	Score       float64   `json:"score" xml:"score"`
+	Letter      string    `json:"letter" xml:"letter"`
+	GradePoints float64   `json:"grade_points" xml:"grade_points"`
+	Scale       string    `json:"scale" xml:"scale"`
+	GradedAt    time.Time `json:"graded_at" xml:"graded_at"`
+}
+
+// TranscriptEntry is one enrollment of a student with its term, credits and grade, if graded.
+type TranscriptEntry struct {
+	Term        AcademicTerm
+	ClassInfoID int64
+	ClassName   string
+	Credits     float64
+	Grade       *Grade
+}
+
+// Transcript lists the enrollments of a student by term. GPA is nil until a class with credits is graded.
+type Transcript struct {
+	XMLName   xml.Name         `json:"-" xml:"transcript"`
+	StudentID int64            `json:"student_id" xml:"student_id"`
+	Terms     []TranscriptTerm `json:"terms" xml:"terms>term"`
+	Credits   float64          `json:"credits" xml:"credits"`
+	GPA       *float64         `json:"gpa" xml:"gpa,omitempty"`
+}
+
+// TranscriptTerm is the part of a transcript for one term. Credits counts the graded credits.
+type TranscriptTerm struct {
+	TermID    int64             `json:"term_id" xml:"term_id"`
+	Name      string            `json:"name" xml:"name"`
+	StartDate string            `json:"start_date" xml:"start_date"`
+	EndDate   string            `json:"end_date" xml:"end_date"`
+	Status    string            `json:"status" xml:"status"`
+	Classes   []TranscriptClass `json:"classes" xml:"classes>class"`
+	Credits   float64           `json:"credits" xml:"credits"`
+	GPA       *float64          `json:"gpa" xml:"gpa,omitempty"`
+}
+
+// TranscriptClass is an enrollment on a transcript. Grade is nil while the class is not graded.
+type TranscriptClass struct {
+	ClassInfoID int64    `json:"class_info_id" xml:"class_info_id"`
+	ClassName   string   `json:"class_name" xml:"class_name"`
+	Credits     float64  `json:"credits" xml:"credits"`
+	Score       *float64 `json:"score" xml:"score,omitempty"`
+	Letter      string   `json:"letter,omitempty" xml:"letter,omitempty"`
+	GradePoints *float64 `json:"grade_points" xml:"grade_points,omitempty"`
+}
@@ -0,0 +1,45 @@
+package models
+
+// HALLink is a link object of a HAL representation.
+type HALLink struct {
+	Href string `json:"href"`
+}
+
+// HALLinks maps link relations to links.
+type HALLinks map[string]HALLink
+
+// StudentResource is the application/hal+json representation of a student.
+type StudentResource struct {
+	StudentView
+	Links    HALLinks         `json:"_links"`
+	Embedded *StudentEmbedded `json:"_embedded,omitempty"`
+}
+
+// StudentEmbedded holds the resources embedded in a student on request.
+type StudentEmbedded struct {
+	Classes []ClassInfoResource `json:"classes"`
+}
+
+// ClassInfoResource is the application/hal+json representation of a class.
+type ClassInfoResource struct {
+	ClassInfo
+	Links HALLinks `json:"_links"`
+}
+
+// StudentCollection is one page of students as application/hal+json.
+type StudentCollection struct {
+	Links    HALLinks `json:"_links"`
+	Embedded struct {
+		Students []StudentResource `json:"students"`
+	} `json:"_embedded"`
+	ListMeta
+}
+
+// ClassInfoCollection is one page of classes as application/hal+json.
+type ClassInfoCollection struct {
+	Links    HALLinks `json:"_links"`
+	Embedded struct {
+		Classes []ClassInfoResource `json:"classes"`
+	} `json:"_embedded"`
+	ListMeta
+}
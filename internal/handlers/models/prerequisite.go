@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Prerequisites are the requirements for enrolling in a class: every group in AllOf must be satisfied.
+type Prerequisites struct {
+	XMLName   xml.Name            `json:"-" xml:"prerequisites"`
+	ClassID   int64               `json:"class_id" xml:"class_id"`
+	ClassName string              `json:"class_name" xml:"class_name"`
+	AllOf     []PrerequisiteGroup `json:"all_of" xml:"all_of>group"`
+}
+
+// PrerequisiteGroup is satisfied by any one of its requirements.
+type PrerequisiteGroup struct {
+	AnyOf []Requirement `json:"any_of" xml:"any_of>requirement"`
+}
+
+// Requirement is satisfied by a grade in a class, of at least MinScore when it is set.
+// Score is the best score of the student in the class, in eligibility answers.
+type Requirement struct {
+	ClassID   int64    `json:"class_id" xml:"class_id"`
+	ClassName string   `json:"class_name,omitempty" xml:"class_name,omitempty"`
+	MinScore  *float64 `json:"min_score" xml:"min_score,omitempty"`
+	Score     *float64 `json:"score,omitempty" xml:"score,omitempty"`
+}
+
+// Eligibility tells whether a student satisfies the prerequisites of a class. Missing lists the
+// groups the student does not satisfy.
+type Eligibility struct {
+	XMLName   xml.Name            `json:"-" xml:"eligibility"`
+	StudentID int64               `json:"student_id" xml:"student_id"`
+	ClassID   int64               `json:"class_id" xml:"class_id"`
+	ClassName string              `json:"class_name" xml:"class_name"`
+	Eligible  bool                `json:"eligible" xml:"eligible"`
+	Missing   []PrerequisiteGroup `json:"missing" xml:"missing>group"`
+}
+
+// Actions recorded in the audit trail.
+const (
+	AuditActionPrerequisiteOverride = "prerequisite_override"
+)
+
+// AuditEvent records an action that bypassed a rule, and who took it.
+type AuditEvent struct {
+	XMLName   xml.Name  `json:"-" xml:"audit_event"`
+	AuditID   int64     `json:"audit_id" xml:"audit_id"`
+	Actor     string    `json:"actor" xml:"actor"`
+	Action    string    `json:"action" xml:"action"`
+	StudentID *int64    `json:"student_id" xml:"student_id,omitempty"`
+	ClassName *string   `json:"class_name" xml:"class_name,omitempty"`
+	Detail    string    `json:"detail" xml:"detail"`
+	CreatedAt time.Time `json:"created_at" xml:"created_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type RoleAssignment struct {
+	XMLName   xml.Name  `json:"-" xml:"role_assignment"`
+	Subject   string    `json:"subject" xml:"subject"`
+	Role      string    `json:"role" xml:"role"`
+	StudentID *int64    `json:"student_id,omitempty" xml:"student_id,omitempty"`
+	CreatedAt time.Time `json:"created_at" xml:"created_at"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "encoding/xml"
+
+// TimeLayout is the format of times of day in schedules.
+const TimeLayout = "15:04"
+
+// Room is a place where classes meet. Capacity is informational; nil means unknown.
+type Room struct {
+	XMLName  xml.Name `json:"-" xml:"room"`
+	RoomID   int64    `json:"room_id" xml:"room_id"`
+	RoomName string   `json:"room_name" xml:"room_name"`
+	Capacity *int64   `json:"capacity" xml:"capacity,omitempty"`
+}
+
+// ScheduleSlot is a weekly meeting of a class in a room during a term. DayOfWeek counts from
+// Monday (1) to Sunday (7). In student schedules Status is the status of the enrollment.
+type ScheduleSlot struct {
+	XMLName   xml.Name `json:"-" xml:"schedule_slot"`
+	SlotID    int64    `json:"slot_id" xml:"slot_id"`
+	ClassID   int64    `json:"class_id" xml:"class_id"`
+	ClassName string   `json:"class_name" xml:"class_name"`
+	TermID    int64    `json:"term_id" xml:"term_id"`
+	RoomID    int64    `json:"room_id" xml:"room_id"`
+	RoomName  string   `json:"room_name" xml:"room_name"`
+	DayOfWeek int      `json:"day_of_week" xml:"day_of_week"`
+	StartTime string   `json:"start_time" xml:"start_time"`
+	EndTime   string   `json:"end_time" xml:"end_time"`
+	Status    string   `json:"status,omitempty" xml:"status,omitempty"`
+}
+
+// WeekSchedule is the week of a student in a term, from Monday to Sunday.
+type WeekSchedule struct {
+	XMLName   xml.Name      `json:"-" xml:"schedule"`
+	StudentID int64         `json:"student_id" xml:"student_id"`
+	Term      AcademicTerm  `json:"term" xml:"term"`
+	Days      []ScheduleDay `json:"days" xml:"days>day"`
+}
+
+// ScheduleDay lists the slots of a day of the week in start order.
+type ScheduleDay struct {
+	DayOfWeek int            `json:"day_of_week" xml:"day_of_week"`
+	Day       string         `json:"day" xml:"day"`
+	Slots     []ScheduleSlot `json:"slots" xml:"slots>schedule_slot"`
+}
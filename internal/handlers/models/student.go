@@ -1,7 +1,68 @@
 package models
 
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Statuses of a student. Students are created as applicants or active, and move on through
+// CanChangeStudentStatus.
+const (
+	StudentApplicant = "applicant"
+	StudentActive    = "active"
+	StudentGraduated = "graduated"
+	StudentWithdrawn = "withdrawn"
+)
+
+// studentTransitions lists the statuses each status can change to. Graduation is final, while
+// withdrawn students can be readmitted.
+var studentTransitions = map[string][]string{
+	StudentApplicant: {StudentActive, StudentWithdrawn},
+	StudentActive:    {StudentGraduated, StudentWithdrawn},
+	StudentWithdrawn: {StudentActive},
+}
+
+// IsStudentStatus reports whether status is one of the Student* statuses.
+func IsStudentStatus(status string) bool {
+	return status == StudentGraduated || studentTransitions[status] != nil
+}
+
+// CanChangeStudentStatus reports whether a student can change from status from to status to.
+func CanChangeStudentStatus(from, to string) bool {
+	for _, next := range studentTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StudentRequest is a student. The profile fields after Grade are optional and left out of the
+// v1 representation; DateOfBirth is formatted with DateLayout.
 type StudentRequest struct {
-	StudentID   int64  `json:"student_id"`
-	StudentName string `json:"student_name"`
-	Grade       int64  `json:"grade"`
+	XMLName       xml.Name   `json:"-" xml:"student"`
+	StudentID     int64      `json:"student_id" xml:"student_id"`
+	StudentName   string     `json:"student_name" xml:"student_name"`
+	Grade         int64      `json:"grade" xml:"grade"`
+	FirstName     string     `json:"first_name,omitempty" xml:"first_name,omitempty"`
+	LastName      string     `json:"last_name,omitempty" xml:"last_name,omitempty"`
+	PreferredName string     `json:"preferred_name,omitempty" xml:"preferred_name,omitempty"`
+	DateOfBirth   string     `json:"date_of_birth,omitempty" xml:"date_of_birth,omitempty"`
+	Email         string     `json:"email,omitempty" xml:"email,omitempty"`
+	StudentNumber string     `json:"student_number,omitempty" xml:"student_number,omitempty"`
+	Status        string     `json:"status,omitempty" xml:"status,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty" xml:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+}
+
+// StudentStatusChange is a status of a student and when it was set. FromStatus is nil for the status
+// the student was created with.
+type StudentStatusChange struct {
+	XMLName    xml.Name  `json:"-" xml:"status_change"`
+	StudentID  int64     `json:"student_id" xml:"student_id"`
+	FromStatus *string   `json:"from_status" xml:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status" xml:"to_status"`
+	Reason     string    `json:"reason" xml:"reason"`
+	ChangedAt  time.Time `json:"changed_at" xml:"changed_at"`
 }
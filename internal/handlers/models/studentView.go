@@ -0,0 +1,25 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// StudentView is the v2 representation of a student. Fields left out of a sparse fieldset are nil
+// and omitted, and so are unset profile fields. Classes is only set when the classes are included.
+type StudentView struct {
+	XMLName       xml.Name     `json:"-" xml:"student"`
+	StudentID     *int64       `json:"student_id,omitempty" xml:"student_id,omitempty"`
+	StudentName   *string      `json:"student_name,omitempty" xml:"student_name,omitempty"`
+	Grade         *int64       `json:"grade,omitempty" xml:"grade,omitempty"`
+	FirstName     *string      `json:"first_name,omitempty" xml:"first_name,omitempty"`
+	LastName      *string      `json:"last_name,omitempty" xml:"last_name,omitempty"`
+	PreferredName *string      `json:"preferred_name,omitempty" xml:"preferred_name,omitempty"`
+	DateOfBirth   *string      `json:"date_of_birth,omitempty" xml:"date_of_birth,omitempty"`
+	Email         *string      `json:"email,omitempty" xml:"email,omitempty"`
+	StudentNumber *string      `json:"student_number,omitempty" xml:"student_number,omitempty"`
+	Status        *string      `json:"status,omitempty" xml:"status,omitempty"`
+	CreatedAt     *time.Time   `json:"created_at,omitempty" xml:"created_at,omitempty"`
+	UpdatedAt     *time.Time   `json:"updated_at,omitempty" xml:"updated_at,omitempty"`
+	Classes       *[]ClassInfo `json:"classes,omitempty" xml:"classes>class_info,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "encoding/xml"
+
+// Roles a teacher can have in a class. A class has at most one lead.
+const (
+	TeacherRoleLead      = "lead"
+	TeacherRoleAssistant = "assistant"
+)
+
+type TeacherRequest struct {
+	XMLName     xml.Name `json:"-" xml:"teacher"`
+	TeacherID   int64    `json:"teacher_id" xml:"teacher_id"`
+	TeacherName string   `json:"teacher_name" xml:"teacher_name"`
+}
+
+// TeacherAssignment is a teacher's role in a class. Classes are identified by class_name on assignment
+// and created on first use.
+type TeacherAssignment struct {
+	XMLName     xml.Name `json:"-" xml:"teacher_assignment"`
+	ClassID     int64    `json:"class_id" xml:"class_id"`
+	ClassName   string   `json:"class_name" xml:"class_name"`
+	TeacherID   int64    `json:"teacher_id" xml:"teacher_id"`
+	TeacherName string   `json:"teacher_name,omitempty" xml:"teacher_name,omitempty"`
+	Role        string   `json:"role" xml:"role"`
+}
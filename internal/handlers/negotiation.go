@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/pkg/codec"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"fmt"
+	"net/http"
+)
+
+// negotiate picks the response codec from the Accept header. Handlers call it before doing any work,
+// so that a request that would get 406 has no side effects. extensions are the codecs the handler can
+// build a representation for in addition to the standard ones. On failure it writes 406 and returns false.
+func negotiate(w http.ResponseWriter, req *http.Request, extensions ...codec.Codec) (codec.Codec, bool) {
+	responseCodec, err := codec.Negotiate(req.Header.Get("Accept"), extensions...)
+	if err != nil {
+		problem.Write(w, req, http.StatusNotAcceptable, err.Error())
+		return nil, false
+	}
+
+	return responseCodec, true
+}
+
+// decodeBody reads the request body into v with the codec chosen by Content-Type.
+// On failure it writes 413, 415 or 400 and returns false.
+func decodeBody(w http.ResponseWriter, req *http.Request, v interface{}) bool {
+	requestCodec, err := codec.ForContentType(req.Header.Get("Content-Type"))
+	if err != nil {
+		problem.Write(w, req, http.StatusUnsupportedMediaType, err.Error())
+		return false
+	}
+
+	body, ok := readBody(w, req)
+	if !ok {
+		return false
+	}
+
+	if err := requestCodec.Unmarshal(body, v); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to unmarshal %s: %v", requestCodec.Name(), err), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// writeResponse encodes value with responseCodec.
+func writeResponse(w http.ResponseWriter, responseCodec codec.Codec, status int, value interface{}) {
+	content, err := responseCodec.Marshal(value)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to marshal %s response: %v", responseCodec.Name(), err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", responseCodec.ContentType())
+	w.WriteHeader(status)
+
+	if _, err = w.Write(content); err != nil {
+		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+	}
+}
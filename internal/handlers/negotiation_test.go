@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/codec"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStudentHandler_Get_Negotiation(t *testing.T) {
+	t.Parallel()
+	student := models.StudentRequest{StudentID: 1, StudentName: "Test", Grade: 90}
+	tests := []struct {
+		description         string
+		accept              string
+		expectedCode        int
+		expectedContentType string
+		expectedCodec       codec.Codec
+	}{
+		{
+			description:         "No Accept header",
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/json",
+			expectedCodec:       codec.JSON,
+		},
+		{
+			description:         "XML",
+			accept:              "application/xml",
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/xml",
+			expectedCodec:       codec.XML,
+		},
+		{
+			description:         "MessagePack",
+			accept:              "application/msgpack",
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/msgpack",
+			expectedCodec:       codec.MessagePack,
+		},
+		{
+			description:         "CBOR",
+			accept:              "application/cbor",
+			expectedCode:        http.StatusOK,
+			expectedContentType: "application/cbor",
+			expectedCodec:       codec.CBOR,
+		},
+		{
+			description:         "Unsupported",
+			accept:              "text/csv",
+			expectedCode:        http.StatusNotAcceptable,
+			expectedContentType: problem.ContentType,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// arrange
+			mockRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+			studentHandler := NewStudentHandler(mockRepo, "id")
+			if tc.expectedCodec != nil {
+				mockRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(student, nil)
+			}
+			req, err := http.NewRequest(http.MethodGet, "/student/1", nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept", tc.accept)
+			req = mux.SetURLVars(req, map[string]string{"id": "1"})
+			rr := httptest.NewRecorder()
+			// act
+			studentHandler.Get(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			assert.Equal(t, tc.expectedContentType, rr.Header().Get("Content-Type"))
+			if tc.expectedCodec == nil {
+				return
+			}
+			var actual models.StudentRequest
+			require.NoError(t, tc.expectedCodec.Unmarshal(rr.Body.Bytes(), &actual))
+			actual.XMLName = student.XMLName
+			assert.Equal(t, student, actual)
+		})
+	}
+}
+
+func TestClassInfoHandler_AddClass_Negotiation(t *testing.T) {
+	t.Parallel()
+	classInfo := models.ClassInfo{StudentID: 7, ClassName: "Math", TermID: 2}
+	tests := []struct {
+		description  string
+		contentType  string
+		accept       string
+		requestCodec codec.Codec
+		expectedCode int
+	}{
+		{
+			description:  "CBOR request, XML response",
+			contentType:  "application/cbor",
+			accept:       "application/xml",
+			requestCodec: codec.CBOR,
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "MessagePack request",
+			contentType:  "application/msgpack",
+			requestCodec: codec.MessagePack,
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "Unsupported Content-Type",
+			contentType:  "text/csv",
+			requestCodec: codec.JSON,
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			description:  "Unsupported Accept is rejected before the insert",
+			accept:       "text/csv",
+			requestCodec: codec.JSON,
+			expectedCode: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// arrange
+			mockRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+			classInfoHandler := NewClassInfoHandler(mockRepo, "id")
+			if tc.expectedCode == http.StatusOK {
+				mockRepo.EXPECT().Add(gomock.Any(), classInfo).Return(int64(3), nil)
+				mockRepo.EXPECT().GetByID(gomock.Any(), int64(3)).
+					Return(models.ClassInfo{ID: 3, StudentID: 7, ClassName: "Math", TermID: 2, Status: models.EnrollmentStatusEnrolled}, nil)
+			}
+			body, err := tc.requestCodec.Marshal(classInfo)
+			require.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost, "/class_info", bytes.NewReader(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", tc.contentType)
+			req.Header.Set("Accept", tc.accept)
+			rr := httptest.NewRecorder()
+			// act
+			classInfoHandler.AddClass(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			if tc.expectedCode == http.StatusOK && tc.accept == "application/xml" {
+				assert.Contains(t, rr.Body.String(), "<class_info><id>3</id>")
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+type page struct {
+	limit  int64
+	offset int64
+}
+
+// parsePage reads the limit and offset query parameters. On failure it writes 400 and returns false.
+func parsePage(w http.ResponseWriter, req *http.Request) (page, bool) {
+	p := page{limit: defaultPageLimit}
+
+	for _, param := range []struct {
+		name  string
+		value *int64
+		min   int64
+		max   int64
+	}{
+		{"limit", &p.limit, 1, maxPageLimit},
+		{"offset", &p.offset, 0, -1},
+	} {
+		raw := req.URL.Query().Get(param.name)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || value < param.min || (param.max > 0 && value > param.max) {
+			detail := fmt.Sprintf("%s must be an integer of at least %d", param.name, param.min)
+			if param.max > 0 {
+				detail = fmt.Sprintf("%s must be an integer between %d and %d", param.name, param.min, param.max)
+			}
+
+			problem.Write(w, req, http.StatusBadRequest, detail)
+
+			return page{}, false
+		}
+
+		*param.value = value
+	}
+
+	return p, true
+}
+
+// parseDateRange reads the optional from and to query parameters, dates formatted as YYYY-MM-DD.
+// On failure it writes 400 and returns false.
+func parseDateRange(w http.ResponseWriter, req *http.Request) (from, to *time.Time, ok bool) {
+	bounds := make([]*time.Time, 2)
+
+	for i, name := range []string{"from", "to"} {
+		raw := req.URL.Query().Get(name)
+		if raw == "" {
+			continue
+		}
+
+		date, err := time.Parse(models.DateLayout, raw)
+		if err != nil {
+			problem.Write(w, req, http.StatusBadRequest, name+" must be a date formatted as YYYY-MM-DD")
+			return nil, nil, false
+		}
+
+		bounds[i] = &date
+	}
+
+	if bounds[0] != nil && bounds[1] != nil && bounds[1].Before(*bounds[0]) {
+		problem.Write(w, req, http.StatusBadRequest, "to must not be before from")
+		return nil, nil, false
+	}
+
+	return bounds[0], bounds[1], true
+}
+
+// parseTerm reads the optional term query parameter: current, the default, or a term id.
+// termID is nil for the current term. On failure it writes 400 and returns false.
+func parseTerm(w http.ResponseWriter, req *http.Request) (termID *int64, ok bool) {
+	raw := req.URL.Query().Get("term")
+	if raw == "" || raw == "current" {
+		return nil, true
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, req, http.StatusBadRequest, "term must be current or a term id")
+		return nil, false
+	}
+
+	return &id, true
+}
+
+// pathID reads a numeric path variable. On failure it writes 400 and returns false.
+func pathID(w http.ResponseWriter, req *http.Request, key string) (int64, bool) {
+	raw, ok := mux.Vars(req)[key]
+	if !ok {
+		problem.Write(w, req, http.StatusBadRequest, "missing path parameter "+key)
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		problem.Write(w, req, http.StatusBadRequest, fmt.Sprintf("%s must be an integer", key))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// plainPathID is pathID with the plain-text errors of the v1 handlers.
+func plainPathID(w http.ResponseWriter, req *http.Request, key string) (int64, bool) {
+	raw, ok := mux.Vars(req)[key]
+	if !ok {
+		http.Error(w, "Invalid request. Missing query parameter.", http.StatusBadRequest)
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		http.Error(w, "Failed to convert string to int64.", http.StatusBadRequest)
+		return 0, false
+	}
+
+	return id, true
+}
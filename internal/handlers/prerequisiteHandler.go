@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/auth"
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// overrideParam is the query parameter with which an admin enrolls a student who misses prerequisites.
+// Routes with it set to "true" require auth.PermPrerequisiteOverride.
+const overrideParam = "override_prerequisites"
+
+// PrerequisiteHandler serves the prerequisites of classes and the eligibility of students for them.
+type PrerequisiteHandler struct {
+	prerequisiteStorage repository.PrerequisitePgRepo
+	gate                *prerequisiteGate
+	queryParamKey       string
+}
+
+// NewPrerequisiteHandler creates a new PrerequisiteHandler that checks eligibility like gate.
+func NewPrerequisiteHandler(gate *prerequisiteGate, queryParamKey string) *PrerequisiteHandler {
+	return &PrerequisiteHandler{
+		prerequisiteStorage: gate.prerequisiteStorage,
+		gate:                gate,
+		queryParamKey:       queryParamKey,
+	}
+}
+
+func (h *PrerequisiteHandler) Get(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	prerequisites, err := h.prerequisiteStorage.GetByClassID(req.Context(), classID)
+	if err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: prerequisites})
+}
+
+// Replace sets the prerequisites of the class in the path. Every group of all_of must be satisfied,
+// each by any one of its requirements.
+func (h *PrerequisiteHandler) Replace(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var prerequisites models.Prerequisites
+	if !decodeBody(w, req, &prerequisites) {
+		return
+	}
+
+	if detail := validatePrerequisites(classID, prerequisites); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	err := h.prerequisiteStorage.Replace(req.Context(), classID, prerequisites)
+	if err != nil {
+		switch {
+		case errors.Is(err, pkgErrors.ErrReferenceNotFound):
+			problem.Write(w, req, http.StatusUnprocessableEntity, "a required class does not exist")
+		case errors.Is(err, pkgErrors.ErrPrerequisiteCycle):
+			problem.Write(w, req, http.StatusUnprocessableEntity, "the class would become a prerequisite of itself")
+		default:
+			writeStorageError(w, req, err, "class")
+		}
+
+		return
+	}
+
+	stored, err := h.prerequisiteStorage.GetByClassID(req.Context(), classID)
+	if err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: stored})
+}
+
+// Eligibility tells whether the student in the path satisfies the prerequisites of the class named by
+// the course query parameter, and which requirements are missing.
+func (h *PrerequisiteHandler) Eligibility(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	course := req.URL.Query().Get("course")
+	if course == "" {
+		problem.Write(w, req, http.StatusBadRequest, "course is required")
+		return
+	}
+
+	prerequisites, err := h.prerequisiteStorage.GetByClassName(req.Context(), course)
+	if err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	eligibility, err := h.gate.eligibility(req.Context(), studentID, prerequisites)
+	if err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: eligibility})
+}
+
+// validatePrerequisites returns why prerequisites cannot be set on classID, or "" when they can.
+// Minimum scores are rounded to the two decimals they are stored with.
+func validatePrerequisites(classID int64, prerequisites models.Prerequisites) string {
+	for _, group := range prerequisites.AllOf {
+		if len(group.AnyOf) == 0 {
+			return "every group needs at least one requirement"
+		}
+
+		seen := make(map[int64]bool, len(group.AnyOf))
+		for i := range group.AnyOf {
+			requirement := &group.AnyOf[i]
+
+			switch {
+			case requirement.ClassID <= 0:
+				return "every requirement needs a class_id"
+			case requirement.ClassID == classID:
+				return "a class cannot be its own prerequisite"
+			case seen[requirement.ClassID]:
+				return fmt.Sprintf("class %d appears twice in a group", requirement.ClassID)
+			case requirement.MinScore != nil && (*requirement.MinScore < 0 || *requirement.MinScore > 100):
+				return "min_score must be between 0 and 100"
+			}
+
+			seen[requirement.ClassID] = true
+
+			if requirement.MinScore != nil {
+				rounded := math.Round(*requirement.MinScore*100) / 100
+				requirement.MinScore = &rounded
+			}
+		}
+	}
+
+	return ""
+}
+
+// prerequisiteGate holds back enrollments of students who miss the prerequisites of the class.
+// A nil gate lets every enrollment through.
+type prerequisiteGate struct {
+	prerequisiteStorage repository.PrerequisitePgRepo
+	auditStorage        repository.AuditPgRepo
+}
+
+func newPrerequisiteGate(prerequisiteStorage repository.PrerequisitePgRepo, auditStorage repository.AuditPgRepo) *prerequisiteGate {
+	return &prerequisiteGate{
+		prerequisiteStorage: prerequisiteStorage,
+		auditStorage:        auditStorage,
+	}
+}
+
+// check returns the prerequisite groups of className that the student misses. When the request sets
+// overrideParam, none are returned; instead the override is returned for recordOverride once the
+// enrollment succeeded.
+func (g *prerequisiteGate) check(
+	req *http.Request,
+	studentID int64,
+	className string,
+) ([]models.PrerequisiteGroup, *models.AuditEvent, error) {
+	if g == nil || className == "" {
+		return nil, nil, nil
+	}
+
+	prerequisites, err := g.prerequisiteStorage.GetByClassName(req.Context(), className)
+	if err != nil {
+		// Classes are created on first enrollment, so a new class has no prerequisites yet.
+		if errors.Is(err, pkgErrors.ErrNotFound) {
+			return nil, nil, nil
+		}
+
+		return nil, nil, err
+	}
+
+	eligibility, err := g.eligibility(req.Context(), studentID, prerequisites)
+	if err != nil || eligibility.Eligible {
+		return nil, nil, err
+	}
+
+	// Only the exact value is routed through the override permission check.
+	if req.URL.Query().Get(overrideParam) != "true" {
+		return eligibility.Missing, nil, nil
+	}
+
+	actor := "anonymous"
+	if principal, ok := auth.PrincipalFromContext(req.Context()); ok {
+		actor = principal.Subject
+	}
+
+	return nil, &models.AuditEvent{
+		Actor:     actor,
+		Action:    models.AuditActionPrerequisiteOverride,
+		StudentID: &studentID,
+		ClassName: &className,
+		Detail:    "missing " + describePrerequisites(eligibility.Missing),
+	}, nil
+}
+
+// recordOverride adds an override returned by check to the audit trail. It runs after the enrollment,
+// which cannot be taken back at that point, so a failure is logged with the override rather than returned.
+func (g *prerequisiteGate) recordOverride(ctx context.Context, override *models.AuditEvent) {
+	if g == nil || override == nil {
+		return
+	}
+
+	if _, err := g.auditStorage.Record(ctx, *override); err != nil {
+		slog.ErrorContext(ctx, "failed to record prerequisite override",
+			"actor", override.Actor,
+			"student_id", *override.StudentID,
+			"class_name", *override.ClassName,
+			"detail", override.Detail,
+			"error", err,
+		)
+	}
+}
+
+// eligibility checks the student against prerequisites.
+func (g *prerequisiteGate) eligibility(ctx context.Context, studentID int64, prerequisites models.Prerequisites) (models.Eligibility, error) {
+	var classIDs []int64
+	for _, group := range prerequisites.AllOf {
+		for _, requirement := range group.AnyOf {
+			classIDs = append(classIDs, requirement.ClassID)
+		}
+	}
+
+	scores := map[int64]float64{}
+	if len(classIDs) > 0 {
+		var err error
+		if scores, err = g.prerequisiteStorage.GetBestScores(ctx, studentID, classIDs); err != nil {
+			return models.Eligibility{}, err
+		}
+	}
+
+	missing := missingPrerequisites(prerequisites.AllOf, scores)
+
+	return models.Eligibility{
+		StudentID: studentID,
+		ClassID:   prerequisites.ClassID,
+		ClassName: prerequisites.ClassName,
+		Eligible:  len(missing) == 0,
+		Missing:   missing,
+	}, nil
+}
+
+// missingPrerequisites returns the groups that no score satisfies, with the scores the student has.
+func missingPrerequisites(groups []models.PrerequisiteGroup, scores map[int64]float64) []models.PrerequisiteGroup {
+	missing := []models.PrerequisiteGroup{}
+
+	for _, group := range groups {
+		unmet := models.PrerequisiteGroup{AnyOf: make([]models.Requirement, 0, len(group.AnyOf))}
+		satisfied := false
+
+		for _, requirement := range group.AnyOf {
+			if score, ok := scores[requirement.ClassID]; ok {
+				if requirement.MinScore == nil || score >= *requirement.MinScore {
+					satisfied = true
+					break
+				}
+
+				requirement.Score = &score
+			}
+
+			unmet.AnyOf = append(unmet.AnyOf, requirement)
+		}
+
+		if !satisfied {
+			missing = append(missing, unmet)
+		}
+	}
+
+	return missing
+}
+
+// describePrerequisites renders groups for plain-text messages, such as "Calculus I (min score 70) or Calculus AB; Physics".
+func describePrerequisites(groups []models.PrerequisiteGroup) string {
+	descriptions := make([]string, 0, len(groups))
+
+	for _, group := range groups {
+		options := make([]string, 0, len(group.AnyOf))
+
+		for _, requirement := range group.AnyOf {
+			option := requirement.ClassName
+			if requirement.MinScore != nil {
+				option += fmt.Sprintf(" (min score %s)", strconv.FormatFloat(*requirement.MinScore, 'f', -1, 64))
+			}
+
+			options = append(options, option)
+		}
+
+		descriptions = append(descriptions, strings.Join(options, " or "))
+	}
+
+	return strings.Join(descriptions, "; ")
+}
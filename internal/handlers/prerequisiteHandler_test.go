@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func minScore(score float64) *float64 {
+	return &score
+}
+
+// calculusII requires Calculus I with at least 70, and either Linear Algebra or Statistics.
+var calculusII = models.Prerequisites{
+	ClassID:   3,
+	ClassName: "Calculus II",
+	AllOf: []models.PrerequisiteGroup{
+		{AnyOf: []models.Requirement{{ClassID: 1, ClassName: "Calculus I", MinScore: minScore(70)}}},
+		{AnyOf: []models.Requirement{{ClassID: 4, ClassName: "Linear Algebra"}, {ClassID: 5, ClassName: "Statistics"}}},
+	},
+}
+
+func newPrerequisiteRouter(
+	classInfoStorage *mock_repository.MockClassInfoPgRepo,
+	prerequisiteStorage *mock_repository.MockPrerequisitePgRepo,
+	auditStorage *mock_repository.MockAuditPgRepo,
+) http.Handler {
+	return NewRouter(nil, classInfoStorage, NewHealthHandler(nil, ""), "id", WithPrerequisites(prerequisiteStorage, auditStorage))
+}
+
+func TestMissingPrerequisites(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description     string
+		scores          map[int64]float64
+		expectedMissing []models.PrerequisiteGroup
+	}{
+		{
+			description:     "Satisfied",
+			scores:          map[int64]float64{1: 70, 5: 55},
+			expectedMissing: []models.PrerequisiteGroup{},
+		},
+		{
+			description: "Score below the minimum",
+			scores:      map[int64]float64{1: 65, 4: 90},
+			expectedMissing: []models.PrerequisiteGroup{
+				{AnyOf: []models.Requirement{{ClassID: 1, ClassName: "Calculus I", MinScore: minScore(70), Score: minScore(65)}}},
+			},
+		},
+		{
+			description:     "Nothing taken",
+			scores:          map[int64]float64{},
+			expectedMissing: calculusII.AllOf,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// act
+			missing := missingPrerequisites(calculusII.AllOf, tc.scores)
+			// assert
+			assert.Equal(t, tc.expectedMissing, missing)
+		})
+	}
+}
+
+func TestDescribePrerequisites(t *testing.T) {
+	t.Parallel()
+	// act
+	description := describePrerequisites(calculusII.AllOf)
+	// assert
+	assert.Equal(t, "Calculus I (min score 70); Linear Algebra or Statistics", description)
+}
+
+func TestPrerequisiteHandler_Replace(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description  string
+		body         string
+		mock         func(m *mock_repository.MockPrerequisitePgRepo)
+		expectedCode int
+	}{
+		{
+			description: "Replaced",
+			body:        `{"all_of": [{"any_of": [{"class_id": 1, "min_score": 69.999}]}, {"any_of": [{"class_id": 4}, {"class_id": 5}]}]}`,
+			mock: func(m *mock_repository.MockPrerequisitePgRepo) {
+				m.EXPECT().Replace(gomock.Any(), int64(3), models.Prerequisites{AllOf: []models.PrerequisiteGroup{
+					{AnyOf: []models.Requirement{{ClassID: 1, MinScore: minScore(70)}}},
+					{AnyOf: []models.Requirement{{ClassID: 4}, {ClassID: 5}}},
+				}}).Return(nil)
+				m.EXPECT().GetByClassID(gomock.Any(), int64(3)).Return(calculusII, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "Empty group",
+			body:         `{"all_of": [{"any_of": []}]}`,
+			mock:         func(m *mock_repository.MockPrerequisitePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Own prerequisite",
+			body:         `{"all_of": [{"any_of": [{"class_id": 3}]}]}`,
+			mock:         func(m *mock_repository.MockPrerequisitePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Minimum score above 100",
+			body:         `{"all_of": [{"any_of": [{"class_id": 1, "min_score": 101}]}]}`,
+			mock:         func(m *mock_repository.MockPrerequisitePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Unknown required class",
+			body:        `{"all_of": [{"any_of": [{"class_id": 9}]}]}`,
+			mock: func(m *mock_repository.MockPrerequisitePgRepo) {
+				m.EXPECT().Replace(gomock.Any(), int64(3), gomock.Any()).Return(pkgErrors.ErrReferenceNotFound)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			description: "Cycle",
+			body:        `{"all_of": [{"any_of": [{"class_id": 6}]}]}`,
+			mock: func(m *mock_repository.MockPrerequisitePgRepo) {
+				m.EXPECT().Replace(gomock.Any(), int64(3), gomock.Any()).Return(pkgErrors.ErrPrerequisiteCycle)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			description: "Class not found",
+			body:        `{"all_of": []}`,
+			mock: func(m *mock_repository.MockPrerequisitePgRepo) {
+				m.EXPECT().Replace(gomock.Any(), int64(3), gomock.Any()).Return(pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockPrerequisitePgRepo(ctrl)
+			tc.mock(mockRepo)
+			router := newPrerequisiteRouter(
+				mock_repository.NewMockClassInfoPgRepo(ctrl), mockRepo, mock_repository.NewMockAuditPgRepo(ctrl),
+			)
+			req := httptest.NewRequest(http.MethodPut, "/v2/class/3/prerequisites", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestPrerequisiteHandler_Eligibility(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description      string
+		url              string
+		mock             func(m *mock_repository.MockPrerequisitePgRepo)
+		expectedCode     int
+		expectedEligible bool
+		expectedMissing  int
+	}{
+		{
+			description: "Eligible",
+			url:         "/v2/student/7/eligibility?course=Calculus+II",
+			mock: func(m *mock_repository.MockPrerequisitePgRepo) {
+				m.EXPECT().GetByClassName(gomock.Any(), "Calculus II").Return(calculusII, nil)
+				m.EXPECT().GetBestScores(gomock.Any(), int64(7), []int64{1, 4, 5}).Return(map[int64]float64{1: 88, 4: 75}, nil)
+			},
+			expectedCode:     http.StatusOK,
+			expectedEligible: true,
+		},
+		{
+			description: "Missing requirements",
+			url:         "/v2/student/7/eligibility?course=Calculus+II",
+			mock: func(m *mock_repository.MockPrerequisitePgRepo) {
+				m.EXPECT().GetByClassName(gomock.Any(), "Calculus II").Return(calculusII, nil)
+				m.EXPECT().GetBestScores(gomock.Any(), int64(7), []int64{1, 4, 5}).Return(map[int64]float64{1: 60}, nil)
+			},
+			expectedCode:    http.StatusOK,
+			expectedMissing: 2,
+		},
+		{
+			description: "No prerequisites",
+			url:         "/v2/student/7/eligibility?course=Art",
+			mock: func(m *mock_repository.MockPrerequisitePgRepo) {
+				m.EXPECT().GetByClassName(gomock.Any(), "Art").Return(models.Prerequisites{ClassID: 2, ClassName: "Art"}, nil)
+			},
+			expectedCode:     http.StatusOK,
+			expectedEligible: true,
+		},
+		{
+			description:  "Course missing",
+			url:          "/v2/student/7/eligibility",
+			mock:         func(m *mock_repository.MockPrerequisitePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Unknown course",
+			url:         "/v2/student/7/eligibility?course=Alchemy",
+			mock: func(m *mock_repository.MockPrerequisitePgRepo) {
+				m.EXPECT().GetByClassName(gomock.Any(), "Alchemy").Return(models.Prerequisites{}, pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockPrerequisitePgRepo(ctrl)
+			tc.mock(mockRepo)
+			router := newPrerequisiteRouter(
+				mock_repository.NewMockClassInfoPgRepo(ctrl), mockRepo, mock_repository.NewMockAuditPgRepo(ctrl),
+			)
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var actual struct {
+				Data models.Eligibility `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, int64(7), actual.Data.StudentID)
+			assert.Equal(t, tc.expectedEligible, actual.Data.Eligible)
+			assert.Len(t, actual.Data.Missing, tc.expectedMissing)
+		})
+	}
+}
+
+func TestEnrollment_Prerequisites(t *testing.T) {
+	t.Parallel()
+	body := `{"student_id": 7, "class_name": "Calculus II"}`
+	tests := []struct {
+		description  string
+		method       string
+		url          string
+		mock         func(classInfo *mock_repository.MockClassInfoPgRepo, audit *mock_repository.MockAuditPgRepo)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			description:  "Rejected",
+			method:       http.MethodPost,
+			url:          "/v2/class_info",
+			mock:         func(*mock_repository.MockClassInfoPgRepo, *mock_repository.MockAuditPgRepo) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `"missing":[{"any_of":[{"class_id":1,"class_name":"Calculus I","min_score":70,"score":60}]}`,
+		},
+		{
+			description:  "Rejected in v1",
+			method:       http.MethodPost,
+			url:          "/v1/class_info",
+			mock:         func(*mock_repository.MockClassInfoPgRepo, *mock_repository.MockAuditPgRepo) {},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: "Missing prerequisites: Calculus I (min score 70)",
+		},
+		{
+			description: "Overridden",
+			method:      http.MethodPost,
+			url:         "/v2/class_info?override_prerequisites=true",
+			mock: func(classInfo *mock_repository.MockClassInfoPgRepo, audit *mock_repository.MockAuditPgRepo) {
+				studentID, className := int64(7), "Calculus II"
+				// the override is recorded once the enrollment exists
+				gomock.InOrder(
+					classInfo.EXPECT().Add(gomock.Any(), models.ClassInfo{StudentID: 7, ClassName: "Calculus II"}).Return(int64(12), nil),
+					audit.EXPECT().Record(gomock.Any(), models.AuditEvent{
+						Actor:     "anonymous",
+						Action:    models.AuditActionPrerequisiteOverride,
+						StudentID: &studentID,
+						ClassName: &className,
+						Detail:    "missing Calculus I (min score 70)",
+					}).Return(int64(1), nil),
+				)
+				classInfo.EXPECT().GetByID(gomock.Any(), int64(12)).
+					Return(models.ClassInfo{ID: 12, StudentID: 7, ClassName: "Calculus II", TermID: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			description: "Override of a failed enrollment is not recorded",
+			method:      http.MethodPost,
+			url:         "/v2/class_info?override_prerequisites=true",
+			mock: func(classInfo *mock_repository.MockClassInfoPgRepo, _ *mock_repository.MockAuditPgRepo) {
+				classInfo.EXPECT().Add(gomock.Any(), models.ClassInfo{StudentID: 7, ClassName: "Calculus II"}).
+					Return(int64(-1), pkgErrors.ErrTermClosed)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			description:  "Only true overrides",
+			method:       http.MethodPost,
+			url:          "/v2/class_info?override_prerequisites=1",
+			mock:         func(*mock_repository.MockClassInfoPgRepo, *mock_repository.MockAuditPgRepo) {},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			description: "Moved into the class",
+			method:      http.MethodPut,
+			url:         "/v2/class_info/12",
+			mock: func(classInfo *mock_repository.MockClassInfoPgRepo, audit *mock_repository.MockAuditPgRepo) {
+				classInfo.EXPECT().GetByID(gomock.Any(), int64(12)).
+					Return(models.ClassInfo{ID: 12, StudentID: 7, ClassName: "Calculus I", TermID: 1}, nil)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			classInfoRepo := mock_repository.NewMockClassInfoPgRepo(ctrl)
+			prerequisiteRepo := mock_repository.NewMockPrerequisitePgRepo(ctrl)
+			auditRepo := mock_repository.NewMockAuditPgRepo(ctrl)
+			prerequisites := models.Prerequisites{ClassID: 3, ClassName: "Calculus II", AllOf: calculusII.AllOf[:1]}
+			prerequisiteRepo.EXPECT().GetByClassName(gomock.Any(), "Calculus II").Return(prerequisites, nil)
+			prerequisiteRepo.EXPECT().GetBestScores(gomock.Any(), int64(7), []int64{1}).Return(map[int64]float64{1: 60}, nil)
+			tc.mock(classInfoRepo, auditRepo)
+			router := newPrerequisiteRouter(classInfoRepo, prerequisiteRepo, auditRepo)
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(body))
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			assert.Contains(t, rr.Body.String(), tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/auth"
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Path variables of the role admin routes.
+const (
+	subjectParamKey = "subject"
+	roleParamKey    = "role"
+)
+
+// RoleHandler handles the admin endpoints that manage role assignments.
+type RoleHandler struct {
+	roleStorage repository.RoleAssignmentPgRepo
+}
+
+// NewRoleHandler creates a new RoleHandler with the given role assignment storage.
+func NewRoleHandler(roleStorage repository.RoleAssignmentPgRepo) *RoleHandler {
+	return &RoleHandler{
+		roleStorage: roleStorage,
+	}
+}
+
+func (h *RoleHandler) List(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.roleStorage.List(req.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list role assignments: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, assignments)
+}
+
+func (h *RoleHandler) GetBySubject(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.roleStorage.GetBySubject(req.Context(), mux.Vars(req)[subjectParamKey])
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get role assignments: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, assignments)
+}
+
+// Assign grants a role to a subject. The student role needs a body such as {"student_id": N}
+// naming the only student the subject may read.
+func (h *RoleHandler) Assign(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	assignment := models.RoleAssignment{
+		Subject: mux.Vars(req)[subjectParamKey],
+		Role:    mux.Vars(req)[roleParamKey],
+	}
+
+	if !auth.IsValidRole(assignment.Role) {
+		http.Error(w, fmt.Sprintf("Unknown role %q", assignment.Role), http.StatusBadRequest)
+		return
+	}
+
+	if req.ContentLength != 0 {
+		var scope struct {
+			StudentID *int64 `json:"student_id" xml:"student_id"`
+		}
+
+		if !decodeBody(w, req, &scope) {
+			return
+		}
+
+		assignment.StudentID = scope.StudentID
+	}
+
+	if (assignment.Role == auth.RoleStudent) != (assignment.StudentID != nil) {
+		http.Error(w, "student_id is required for the student role and not allowed for other roles", http.StatusBadRequest)
+		return
+	}
+
+	assignment, err := h.roleStorage.Assign(req.Context(), assignment)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrReferenceNotFound) {
+			http.Error(w, "Student with such student_id not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to assign role: %v", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, assignment)
+}
+
+func (h *RoleHandler) Revoke(w http.ResponseWriter, req *http.Request) {
+	err := h.roleStorage.Revoke(req.Context(), mux.Vars(req)[subjectParamKey], mux.Vars(req)[roleParamKey])
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrNotFound) {
+			http.Error(w, "Role assignment not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to revoke role: %v", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	_, err = w.Write([]byte("Successfully Revoked Role"))
+	if err != nil {
+		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+	}
+}
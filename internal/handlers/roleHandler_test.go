@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRoleHandler_Assign(t *testing.T) {
+	t.Parallel()
+	studentID := int64(7)
+	tests := []struct {
+		description               string
+		role                      string
+		body                      string
+		mockArguments             *models.RoleAssignment
+		mockError                 error
+		expectedCode              int
+		expectedHTTPErrorResponse string
+	}{
+		{
+			description:   "Assign teacher",
+			role:          "teacher",
+			mockArguments: &models.RoleAssignment{Subject: "alice", Role: "teacher"},
+			expectedCode:  http.StatusOK,
+		},
+		{
+			description:   "Assign student",
+			role:          "student",
+			body:          `{"student_id": 7}`,
+			mockArguments: &models.RoleAssignment{Subject: "alice", Role: "student", StudentID: &studentID},
+			expectedCode:  http.StatusOK,
+		},
+		{
+			description:               "Unknown role",
+			role:                      "owner",
+			expectedCode:              http.StatusBadRequest,
+			expectedHTTPErrorResponse: "Unknown role \"owner\"\n",
+		},
+		{
+			description:               "Student role without student_id",
+			role:                      "student",
+			expectedCode:              http.StatusBadRequest,
+			expectedHTTPErrorResponse: "student_id is required for the student role and not allowed for other roles\n",
+		},
+		{
+			description:               "Student not found",
+			role:                      "student",
+			body:                      `{"student_id": 7}`,
+			mockArguments:             &models.RoleAssignment{Subject: "alice", Role: "student", StudentID: &studentID},
+			mockError:                 pkgErrors.ErrReferenceNotFound,
+			expectedCode:              http.StatusNotFound,
+			expectedHTTPErrorResponse: "Student with such student_id not found\n",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// arrange
+			mockRepo := mock_repository.NewMockRoleAssignmentPgRepo(ctrl)
+			roleHandler := NewRoleHandler(mockRepo)
+			if tc.mockArguments != nil {
+				mockRepo.EXPECT().Assign(gomock.Any(), *tc.mockArguments).Return(*tc.mockArguments, tc.mockError)
+			}
+			req, err := http.NewRequest(http.MethodPut, "/admin/roles/alice/"+tc.role, bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{subjectParamKey: "alice", roleParamKey: tc.role})
+			rr := httptest.NewRecorder()
+			// act
+			roleHandler.Assign(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				assert.Equal(t, tc.expectedHTTPErrorResponse, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestRoleHandler_Revoke(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description      string
+		mockError        error
+		expectedCode     int
+		expectedResponse string
+	}{
+		{
+			description:      "Revoked",
+			expectedCode:     http.StatusOK,
+			expectedResponse: "Successfully Revoked Role",
+		},
+		{
+			description:      "Assignment not found",
+			mockError:        pkgErrors.ErrNotFound,
+			expectedCode:     http.StatusNotFound,
+			expectedResponse: "Role assignment not found\n",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			// arrange
+			mockRepo := mock_repository.NewMockRoleAssignmentPgRepo(ctrl)
+			roleHandler := NewRoleHandler(mockRepo)
+			mockRepo.EXPECT().Revoke(gomock.Any(), "alice", "teacher").Return(tc.mockError)
+			req, err := http.NewRequest(http.MethodDelete, "/admin/roles/alice/teacher", nil)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{subjectParamKey: "alice", roleParamKey: "teacher"})
+			rr := httptest.NewRecorder()
+			// act
+			roleHandler.Revoke(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			assert.Equal(t, tc.expectedResponse, rr.Body.String())
+		})
+	}
+}
@@ -1,22 +1,231 @@
 package handlers
 
 import (
+	"CRUD_Go_Backend/internal/auth"
+	"CRUD_Go_Backend/internal/pkg/cors"
+	"CRUD_Go_Backend/internal/pkg/grading"
+	"CRUD_Go_Backend/internal/pkg/metrics"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/pkg/security"
 	"CRUD_Go_Backend/internal/repository"
 	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 )
 
+const serverName = "crud-go-backend"
+
+type routerOptions struct {
+	metricsPath    string
+	authentication mux.MiddlewareFunc
+	authorizer     Authorizer
+	roleStorage    repository.RoleAssignmentPgRepo
+	teacherStorage repository.TeacherPgRepo
+	termStorage    repository.AcademicTermPgRepo
+	gradeStorage   repository.GradePgRepo
+	classStorage   repository.ClassPgRepo
+	gradingScales  grading.Scales
+	prerequisites  *prerequisiteGate
+	attendance     repository.AttendancePgRepo
+	roomStorage    repository.RoomPgRepo
+	schedule       repository.SchedulePgRepo
+	contacts       repository.ContactPgRepo
+	assignments    repository.AssignmentPgRepo
+	rateLimit      mux.MiddlewareFunc
+	ipRateLimit    mux.MiddlewareFunc
+	maxBodyBytes   int64
+	cors           *cors.Policy
+	security       *security.Options
+	deprecations   map[string]Deprecation
+}
+
+// Authorizer decides whether the authenticated caller may use a route.
+type Authorizer interface {
+	Require(permission auth.Permission, owner auth.OwnerFunc) mux.MiddlewareFunc
+}
+
+// RouterOption configures optional parts of the router.
+type RouterOption func(*routerOptions)
+
+// WithAuthentication runs the given authentication middleware in front of every route.
+func WithAuthentication(middleware mux.MiddlewareFunc) RouterOption {
+	return func(o *routerOptions) {
+		o.authentication = middleware
+	}
+}
+
+// WithAuthorization checks every API route against the caller's roles and mounts
+// the /admin/roles endpoints that manage them. It requires WithAuthentication.
+func WithAuthorization(authorizer Authorizer, roleStorage repository.RoleAssignmentPgRepo) RouterOption {
+	return func(o *routerOptions) {
+		o.authorizer = authorizer
+		o.roleStorage = roleStorage
+	}
+}
+
+// WithTeachers mounts the teacher endpoints and the assignment of teachers to classes in the v1 API.
+func WithTeachers(teacherStorage repository.TeacherPgRepo) RouterOption {
+	return func(o *routerOptions) {
+		o.teacherStorage = teacherStorage
+	}
+}
+
+// WithTerms mounts the academic term endpoints in the v2 API.
+func WithTerms(termStorage repository.AcademicTermPgRepo) RouterOption {
+	return func(o *routerOptions) {
+		o.termStorage = termStorage
+	}
+}
+
+// WithGrades mounts the grade, transcript and class catalog endpoints in the v2 API.
+// Scores are converted to letter grades with scales.
+func WithGrades(gradeStorage repository.GradePgRepo, classStorage repository.ClassPgRepo, scales grading.Scales) RouterOption {
+	return func(o *routerOptions) {
+		o.gradeStorage = gradeStorage
+		o.classStorage = classStorage
+		o.gradingScales = scales
+	}
+}
+
+// WithPrerequisites holds back enrollments of students who miss the prerequisites of the class, and mounts
+// the prerequisite and eligibility endpoints in the v2 API and the audit trail of overrides at /admin/audit.
+func WithPrerequisites(prerequisiteStorage repository.PrerequisitePgRepo, auditStorage repository.AuditPgRepo) RouterOption {
+	return func(o *routerOptions) {
+		o.prerequisites = newPrerequisiteGate(prerequisiteStorage, auditStorage)
+	}
+}
+
+// WithAttendance mounts the class session, attendance and attendance rate endpoints in the v2 API.
+func WithAttendance(attendanceStorage repository.AttendancePgRepo) RouterOption {
+	return func(o *routerOptions) {
+		o.attendance = attendanceStorage
+	}
+}
+
+// WithSchedule mounts the room, schedule slot and student schedule endpoints in the v2 API.
+func WithSchedule(roomStorage repository.RoomPgRepo, scheduleStorage repository.SchedulePgRepo) RouterOption {
+	return func(o *routerOptions) {
+		o.roomStorage = roomStorage
+		o.schedule = scheduleStorage
+	}
+}
+
+// WithContacts mounts the parent and guardian contacts of students in the v2 API.
+func WithContacts(contactStorage repository.ContactPgRepo) RouterOption {
+	return func(o *routerOptions) {
+		o.contacts = contactStorage
+	}
+}
+
+// WithAssignments mounts the assignment, submission and gradebook endpoints in the v2 API. With WithGrades,
+// the assignment scores of a gradebook can also be rolled up into class grades.
+func WithAssignments(assignmentStorage repository.AssignmentPgRepo) RouterOption {
+	return func(o *routerOptions) {
+		o.assignments = assignmentStorage
+	}
+}
+
+// WithRateLimit runs the given rate limiting middleware after authentication,
+// so that it can key clients by principal. Requests rejected by authentication never reach it;
+// use WithIPRateLimit to throttle those.
+func WithRateLimit(middleware mux.MiddlewareFunc) RouterOption {
+	return func(o *routerOptions) {
+		o.rateLimit = middleware
+	}
+}
+
+// WithIPRateLimit runs the given rate limiting middleware before authentication,
+// so that unauthenticated requests and invalid credentials are throttled as well.
+func WithIPRateLimit(middleware mux.MiddlewareFunc) RouterOption {
+	return func(o *routerOptions) {
+		o.ipRateLimit = middleware
+	}
+}
+
+// WithMaxBodyBytes rejects request bodies larger than maxBytes with 413.
+func WithMaxBodyBytes(maxBytes int64) RouterOption {
+	return func(o *routerOptions) {
+		o.maxBodyBytes = maxBytes
+	}
+}
+
+// WithCORS answers CORS preflight requests for every route and adds CORS headers for allowed origins.
+func WithCORS(policy *cors.Policy) RouterOption {
+	return func(o *routerOptions) {
+		o.cors = policy
+	}
+}
+
+// WithSecurityHeaders sets the standard security headers on every response.
+func WithSecurityHeaders(options security.Options) RouterOption {
+	return func(o *routerOptions) {
+		o.security = &options
+	}
+}
+
+// WithMetrics records Prometheus request metrics and serves them on path.
+func WithMetrics(path string) RouterOption {
+	return func(o *routerOptions) {
+		o.metricsPath = path
+	}
+}
+
 func NewRouter(
 	studentStorage repository.StudentPgRepo,
 	classInfoStorage repository.ClassInfoPgRepo,
+	healthHandler HealthHandlerInterface,
 	queryParamKey string,
+	opts ...RouterOption,
 ) *mux.Router {
+	var options routerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	router := mux.NewRouter()
+	// Server spans are named after the route template and continue the caller's W3C traceparent.
+	router.Use(otelmux.Middleware(serverName))
 
-	studentHandler := NewStudentHandler(studentStorage, queryParamKey)
-	classInfoHandler := NewClassInfoHandler(classInfoStorage, queryParamKey)
+	if options.metricsPath != "" {
+		router.Use(metrics.Middleware)
+		router.Handle(options.metricsPath, metrics.Handler()).Methods(http.MethodGet)
+	}
+
+	if options.security != nil {
+		router.Use(security.Headers(*options.security))
+	}
+
+	// CORS runs before authentication, since browsers send preflight requests without credentials.
+	if options.cors != nil {
+		router.Use(options.cors.Middleware(router))
+	}
+
+	if options.maxBodyBytes > 0 {
+		router.Use(limitBody(options.maxBodyBytes))
+	}
+
+	if options.ipRateLimit != nil {
+		router.Use(options.ipRateLimit)
+	}
+
+	if options.authentication != nil {
+		router.Use(options.authentication)
+	}
+
+	if options.rateLimit != nil {
+		router.Use(options.rateLimit)
+	}
+
+	// require wraps handler with a permission check; without WithAuthorization every caller is allowed.
+	require := func(permission auth.Permission, owner auth.OwnerFunc, handler http.HandlerFunc) http.Handler {
+		if options.authorizer == nil {
+			return handler
+		}
+
+		return options.authorizer.Require(permission, owner)(handler)
+	}
 
 	// Main Page to check
 	router.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
@@ -28,23 +237,113 @@ func NewRouter(
 		}
 	}).Methods(http.MethodGet)
 
-	// Handler for student
-	router.HandleFunc("/student", studentHandler.Create).Methods(http.MethodPost)
-	router.HandleFunc("/student", studentHandler.Update).Methods(http.MethodPut)
-	router.HandleFunc(fmt.Sprintf("/student/{%s:[0-9]+}", queryParamKey), studentHandler.Get).Methods(http.MethodGet)
-	router.HandleFunc(fmt.Sprintf("/student/{%s:[0-9]+}", queryParamKey), studentHandler.Delete).Methods(http.MethodDelete)
-
-	// Handler for class_info
-	router.HandleFunc("/class_info", classInfoHandler.AddClass).Methods(http.MethodPost)
-	router.HandleFunc("/class_info", classInfoHandler.UpdateClass).Methods(http.MethodPut)
-	router.HandleFunc(
-		fmt.Sprintf("/class_info/{%s:[0-9]+}", queryParamKey),
-		classInfoHandler.DeleteClassByStudent,
-	).Methods(http.MethodDelete)
-	router.HandleFunc(
-		fmt.Sprintf("/class_info/{%s:[0-9]+}", queryParamKey),
-		classInfoHandler.GetAllClassesByStudent,
-	).Methods(http.MethodGet)
+	// Health probes
+	router.HandleFunc("/healthz", healthHandler.Liveness).Methods(http.MethodGet)
+	router.HandleFunc("/readyz", healthHandler.Readiness).Methods(http.MethodGet)
+	router.HandleFunc("/health/details", healthHandler.Details).Methods(http.MethodGet)
+
+	classInfoV1 := NewClassInfoHandler(classInfoStorage, queryParamKey)
+	classInfoV1.prerequisites = options.prerequisites
+
+	v1 := v1Handlers{
+		student:       NewStudentHandler(studentStorage, queryParamKey),
+		classInfo:     classInfoV1,
+		prerequisites: options.prerequisites != nil,
+		queryParamKey: queryParamKey,
+	}
+	if options.teacherStorage != nil {
+		v1.teacher = NewTeacherHandler(options.teacherStorage, queryParamKey)
+	}
+
+	classInfoV2 := NewClassInfoHandlerV2(classInfoStorage, queryParamKey, router)
+	classInfoV2.prerequisites = options.prerequisites
+
+	v2 := v2Handlers{
+		student:       NewStudentHandlerV2(studentStorage, classInfoStorage, queryParamKey, router),
+		classInfo:     classInfoV2,
+		queryParamKey: queryParamKey,
+	}
+	if options.termStorage != nil {
+		v2.term = NewTermHandler(options.termStorage, queryParamKey, router)
+	}
+	if options.gradeStorage != nil {
+		v2.grade = NewGradeHandler(options.gradeStorage, options.gradingScales, queryParamKey)
+		v2.class = NewClassHandler(options.classStorage, queryParamKey)
+	}
+	if options.prerequisites != nil {
+		v2.prerequisite = NewPrerequisiteHandler(options.prerequisites, queryParamKey)
+	}
+	if options.attendance != nil {
+		v2.attendance = NewAttendanceHandler(options.attendance, queryParamKey, router)
+	}
+	if options.schedule != nil {
+		v2.schedule = NewScheduleHandler(options.roomStorage, options.schedule, queryParamKey, router)
+	}
+	if options.contacts != nil {
+		v2.contact = NewContactHandler(options.contacts, queryParamKey, router)
+	}
+	if options.assignments != nil {
+		v2.assignment = NewAssignmentHandler(
+			options.assignments, options.gradeStorage, options.gradingScales, queryParamKey, router,
+		)
+		v2.rollUp = options.gradeStorage != nil
+	}
+
+	versions := []apiVersion{
+		{
+			name:     "v1",
+			legacy:   true,
+			register: v1.register,
+		},
+		{
+			name:     "v2",
+			register: v2.register,
+		},
+	}
+
+	for _, version := range versions {
+		var deprecation *Deprecation
+		if d, ok := options.deprecations[version.name]; ok {
+			deprecation = &d
+		}
+
+		// The subrouter has no path prefix of its own and routes carry the full path: in mux,
+		// PathPrefix subrouters answer 404 instead of 405 for unsupported methods.
+		versioned := router.NewRoute().Subrouter()
+		versioned.Use(versionMiddleware(version.name, deprecation))
+		version.register(versioned, "/"+version.name, require)
+
+		if version.legacy {
+			version.register(versioned, "", require)
+		}
+	}
+
+	// Handler for role assignments
+	if options.authorizer != nil {
+		roleHandler := NewRoleHandler(options.roleStorage)
+		assignmentPath := fmt.Sprintf("/admin/roles/{%s}/{%s}", subjectParamKey, roleParamKey)
+
+		router.Handle("/admin/roles", require(auth.PermRoleManage, nil, roleHandler.List)).Methods(http.MethodGet)
+		router.Handle(
+			fmt.Sprintf("/admin/roles/{%s}", subjectParamKey),
+			require(auth.PermRoleManage, nil, roleHandler.GetBySubject),
+		).Methods(http.MethodGet)
+		router.Handle(assignmentPath, require(auth.PermRoleManage, nil, roleHandler.Assign)).Methods(http.MethodPut)
+		router.Handle(assignmentPath, require(auth.PermRoleManage, nil, roleHandler.Revoke)).Methods(http.MethodDelete)
+	}
+
+	// Handler for the audit trail
+	if options.prerequisites != nil {
+		auditHandler := NewAuditHandler(options.prerequisites.auditStorage)
+		router.Handle("/admin/audit", require(auth.PermAuditRead, nil, auditHandler.List)).Methods(http.MethodGet)
+	}
+
+	// Lets preflight requests reach the CORS middleware on every path; any other OPTIONS request is not allowed.
+	if options.cors != nil {
+		router.PathPrefix("/").Methods(http.MethodOptions).HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			problem.Write(w, req, http.StatusMethodNotAllowed, "OPTIONS is only supported for CORS preflight requests")
+		})
+	}
 
 	return router
 }
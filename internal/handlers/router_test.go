@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/pkg/ratelimit"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRouter_IPRateLimitBeforeAuthentication(t *testing.T) {
+	t.Parallel()
+
+	// arrange
+	rejectAll := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+	}
+	router := NewRouter(nil, nil, NewHealthHandler(nil, ""), "id",
+		WithAuthentication(rejectAll),
+		WithIPRateLimit(ratelimit.NewPerIP(ratelimit.Limit{RequestsPerSecond: 0.001, Burst: 1}).Middleware),
+	)
+
+	serve := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/student/1", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		return rr.Code
+	}
+
+	// act
+	first := serve()
+	second := serve()
+
+	// assert
+	assert.Equal(t, http.StatusUnauthorized, first)
+	assert.Equal(t, http.StatusTooManyRequests, second)
+}
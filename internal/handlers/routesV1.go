@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/auth"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// v1Handlers is the handler set of the original API: bare bodies and plain-text messages.
+type v1Handlers struct {
+	student       StudentHandlerInterface
+	classInfo     ClassInfoHandlerInterface
+	teacher       TeacherHandlerInterface // nil unless the router has WithTeachers
+	prerequisites bool                    // true when the router has WithPrerequisites
+	queryParamKey string
+}
+
+func (h v1Handlers) register(router *mux.Router, prefix string, require requireFunc) {
+	studentPath := fmt.Sprintf("%s/student/{%s:[0-9]+}", prefix, h.queryParamKey)
+	classInfoPath := fmt.Sprintf("%s/class_info/{%s:[0-9]+}", prefix, h.queryParamKey)
+	ownStudent := auth.StudentFromPath(h.queryParamKey)
+
+	// Handler for student
+	router.Handle(prefix+"/student", require(auth.PermStudentWrite, nil, h.student.Create)).Methods(http.MethodPost)
+	router.Handle(prefix+"/student", require(auth.PermStudentWrite, nil, h.student.Update)).Methods(http.MethodPut)
+	router.Handle(studentPath, require(auth.PermStudentRead, ownStudent, h.student.Get)).Methods(http.MethodGet)
+	router.Handle(studentPath, require(auth.PermStudentWrite, nil, h.student.Delete)).Methods(http.MethodDelete)
+
+	// Handler for class_info, keyed by student id
+	if h.prerequisites {
+		// Enrolling despite missing prerequisites takes its own permission, so these routes come first.
+		router.Handle(prefix+"/class_info", require(auth.PermPrerequisiteOverride, nil, h.classInfo.AddClass)).
+			Methods(http.MethodPost).Queries(overrideParam, "true")
+		router.Handle(prefix+"/class_info", require(auth.PermPrerequisiteOverride, nil, h.classInfo.UpdateClass)).
+			Methods(http.MethodPut).Queries(overrideParam, "true")
+	}
+	router.Handle(prefix+"/class_info", require(auth.PermClassWrite, nil, h.classInfo.AddClass)).Methods(http.MethodPost)
+	router.Handle(prefix+"/class_info", require(auth.PermClassWrite, nil, h.classInfo.UpdateClass)).Methods(http.MethodPut)
+	router.Handle(
+		classInfoPath,
+		require(auth.PermClassWrite, nil, h.classInfo.DeleteClassByStudent),
+	).Methods(http.MethodDelete)
+	router.Handle(
+		classInfoPath,
+		require(auth.PermClassRead, ownStudent, h.classInfo.GetAllClassesByStudent),
+	).Methods(http.MethodGet)
+
+	if h.teacher != nil {
+		h.registerTeacher(router, prefix, require)
+	}
+}
+
+func (h v1Handlers) registerTeacher(router *mux.Router, prefix string, require requireFunc) {
+	teacherPath := fmt.Sprintf("%s/teacher/{%s:[0-9]+}", prefix, h.queryParamKey)
+
+	// Handler for teacher
+	router.Handle(prefix+"/teacher", require(auth.PermTeacherWrite, nil, h.teacher.Create)).Methods(http.MethodPost)
+	router.Handle(prefix+"/teacher", require(auth.PermTeacherWrite, nil, h.teacher.Update)).Methods(http.MethodPut)
+	router.Handle(teacherPath, require(auth.PermTeacherRead, nil, h.teacher.Get)).Methods(http.MethodGet)
+	router.Handle(teacherPath, require(auth.PermTeacherWrite, nil, h.teacher.Delete)).Methods(http.MethodDelete)
+
+	// Handler for the assignment of teachers to classes
+	router.Handle(teacherPath+"/classes", require(auth.PermTeacherRead, nil, h.teacher.GetClasses)).Methods(http.MethodGet)
+	router.Handle(teacherPath+"/classes", require(auth.PermTeacherWrite, nil, h.teacher.AssignClass)).Methods(http.MethodPut)
+	router.Handle(
+		fmt.Sprintf("%s/classes/{%s:[0-9]+}", teacherPath, classParamKey),
+		require(auth.PermTeacherWrite, nil, h.teacher.UnassignClass),
+	).Methods(http.MethodDelete)
+	router.Handle(
+		fmt.Sprintf("%s/class/{%s:[0-9]+}/teachers", prefix, h.queryParamKey),
+		require(auth.PermTeacherRead, nil, h.teacher.GetTeachersByClass),
+	).Methods(http.MethodGet)
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/auth"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// v2Handlers is the handler set of the enveloped API: 201 with Location on create,
+// 204 on delete and problem+json errors. Its GET routes are named, since HAL links are built from them.
+type v2Handlers struct {
+	student       StudentHandlerV2Interface
+	classInfo     ClassInfoHandlerV2Interface
+	term          TermHandlerInterface         // nil unless the router has WithTerms
+	grade         GradeHandlerInterface        // nil unless the router has WithGrades
+	class         ClassHandlerInterface        // nil unless the router has WithGrades
+	prerequisite  PrerequisiteHandlerInterface // nil unless the router has WithPrerequisites
+	attendance    AttendanceHandlerInterface   // nil unless the router has WithAttendance
+	schedule      ScheduleHandlerInterface     // nil unless the router has WithSchedule
+	contact       ContactHandlerInterface      // nil unless the router has WithContacts
+	assignment    AssignmentHandlerInterface   // nil unless the router has WithAssignments
+	rollUp        bool                         // whether assignment can set grades, with WithGrades
+	queryParamKey string
+}
+
+func (h v2Handlers) register(router *mux.Router, prefix string, require requireFunc) {
+	studentPath := fmt.Sprintf("%s/student/{%s:[0-9]+}", prefix, h.queryParamKey)
+	classInfoPath := fmt.Sprintf("%s/class_info/{%s:[0-9]+}", prefix, h.queryParamKey)
+	ownStudent := auth.StudentFromPath(h.queryParamKey)
+
+	// Handler for student
+	router.Handle(prefix+"/student", require(auth.PermStudentRead, nil, h.student.List)).
+		Methods(http.MethodGet).Name(routeStudents)
+	router.Handle(prefix+"/student", require(auth.PermStudentWrite, nil, h.student.Create)).Methods(http.MethodPost)
+	router.Handle(prefix+"/student", require(auth.PermStudentWrite, nil, h.student.Update)).Methods(http.MethodPut)
+	router.Handle(studentPath, require(auth.PermStudentRead, ownStudent, h.student.Get)).
+		Methods(http.MethodGet).Name(routeStudent)
+	router.Handle(studentPath, require(auth.PermStudentWrite, nil, h.student.Delete)).Methods(http.MethodDelete)
+	router.Handle(studentPath+"/status", require(auth.PermStudentWrite, nil, h.student.SetStatus)).Methods(http.MethodPut)
+	router.Handle(studentPath+"/status_history", require(auth.PermStudentRead, ownStudent, h.student.StatusHistory)).
+		Methods(http.MethodGet)
+
+	// Handler for the classes of a student
+	router.Handle(
+		studentPath+"/class_info",
+		require(auth.PermClassRead, ownStudent, h.classInfo.ListByStudent),
+	).Methods(http.MethodGet).Name(routeStudentClasses)
+	router.Handle(
+		studentPath+"/class_info",
+		require(auth.PermClassWrite, nil, h.classInfo.DeleteByStudent),
+	).Methods(http.MethodDelete)
+
+	// Handler for class_info, keyed by class id
+	if h.prerequisite != nil {
+		// Enrolling despite missing prerequisites takes its own permission, so these routes come first.
+		router.Handle(prefix+"/class_info", require(auth.PermPrerequisiteOverride, nil, h.classInfo.Create)).
+			Methods(http.MethodPost).Queries(overrideParam, "true")
+		router.Handle(classInfoPath, require(auth.PermPrerequisiteOverride, nil, h.classInfo.Update)).
+			Methods(http.MethodPut).Queries(overrideParam, "true")
+	}
+	router.Handle(prefix+"/class_info", require(auth.PermClassWrite, nil, h.classInfo.Create)).Methods(http.MethodPost)
+	router.Handle(classInfoPath, require(auth.PermClassRead, nil, h.classInfo.Get)).
+		Methods(http.MethodGet).Name(routeClassInfo)
+	router.Handle(classInfoPath, require(auth.PermClassWrite, nil, h.classInfo.Update)).Methods(http.MethodPut)
+	router.Handle(classInfoPath, require(auth.PermClassWrite, nil, h.classInfo.Delete)).Methods(http.MethodDelete)
+
+	if h.term != nil {
+		h.registerTerm(router, prefix, require)
+	}
+
+	if h.grade != nil {
+		h.registerGrade(router, prefix, require)
+	}
+
+	if h.prerequisite != nil {
+		h.registerPrerequisite(router, prefix, require)
+	}
+
+	if h.attendance != nil {
+		h.registerAttendance(router, prefix, require)
+	}
+
+	if h.schedule != nil {
+		h.registerSchedule(router, prefix, require)
+	}
+
+	if h.contact != nil {
+		h.registerContact(router, prefix, require)
+	}
+
+	if h.assignment != nil {
+		h.registerAssignment(router, prefix, require)
+	}
+}
+
+func (h v2Handlers) registerTerm(router *mux.Router, prefix string, require requireFunc) {
+	termPath := fmt.Sprintf("%s/term/{%s:[0-9]+}", prefix, h.queryParamKey)
+
+	// Handler for academic terms
+	router.Handle(prefix+"/term", require(auth.PermTermRead, nil, h.term.List)).Methods(http.MethodGet)
+	router.Handle(prefix+"/term", require(auth.PermTermWrite, nil, h.term.Create)).Methods(http.MethodPost)
+	router.Handle(prefix+"/term/current", require(auth.PermTermRead, nil, h.term.GetCurrent)).Methods(http.MethodGet)
+	router.Handle(termPath, require(auth.PermTermRead, nil, h.term.Get)).Methods(http.MethodGet).Name(routeTerm)
+	router.Handle(termPath, require(auth.PermTermWrite, nil, h.term.Update)).Methods(http.MethodPut)
+}
+
+func (h v2Handlers) registerGrade(router *mux.Router, prefix string, require requireFunc) {
+	studentPath := fmt.Sprintf("%s/student/{%s:[0-9]+}", prefix, h.queryParamKey)
+	gradePath := fmt.Sprintf("%s/class_info/{%s:[0-9]+}/grade", prefix, h.queryParamKey)
+	classPath := fmt.Sprintf("%s/class/{%s:[0-9]+}", prefix, h.queryParamKey)
+
+	// Handler for the grade of an enrollment
+	router.Handle(gradePath, require(auth.PermGradeRead, nil, h.grade.Get)).Methods(http.MethodGet).Name(routeGrade)
+	router.Handle(gradePath, require(auth.PermGradeWrite, nil, h.grade.Set)).Methods(http.MethodPut)
+	router.Handle(gradePath, require(auth.PermGradeWrite, nil, h.grade.Delete)).Methods(http.MethodDelete)
+
+	// Handler for transcripts
+	router.Handle(
+		studentPath+"/transcript",
+		require(auth.PermGradeRead, auth.StudentFromPath(h.queryParamKey), h.grade.Transcript),
+	).Methods(http.MethodGet).Name(routeTranscript)
+
+	// Handler for the class catalog, its credits and capacity
+	router.Handle(prefix+"/class", require(auth.PermClassRead, nil, h.class.List)).Methods(http.MethodGet)
+	router.Handle(classPath, require(auth.PermClassRead, nil, h.class.Get)).Methods(http.MethodGet)
+	router.Handle(classPath, require(auth.PermClassWrite, nil, h.class.Update)).Methods(http.MethodPut)
+	router.Handle(classPath+"/events", require(auth.PermClassRead, nil, h.class.Events)).Methods(http.MethodGet)
+}
+
+func (h v2Handlers) registerPrerequisite(router *mux.Router, prefix string, require requireFunc) {
+	studentPath := fmt.Sprintf("%s/student/{%s:[0-9]+}", prefix, h.queryParamKey)
+	prerequisitePath := fmt.Sprintf("%s/class/{%s:[0-9]+}/prerequisites", prefix, h.queryParamKey)
+
+	// Handler for the prerequisites of a class
+	router.Handle(prerequisitePath, require(auth.PermClassRead, nil, h.prerequisite.Get)).Methods(http.MethodGet)
+	router.Handle(prerequisitePath, require(auth.PermClassWrite, nil, h.prerequisite.Replace)).Methods(http.MethodPut)
+
+	// Handler for the eligibility of a student
+	router.Handle(
+		studentPath+"/eligibility",
+		require(auth.PermClassRead, auth.StudentFromPath(h.queryParamKey), h.prerequisite.Eligibility),
+	).Methods(http.MethodGet)
+}
+
+func (h v2Handlers) registerAttendance(router *mux.Router, prefix string, require requireFunc) {
+	studentPath := fmt.Sprintf("%s/student/{%s:[0-9]+}", prefix, h.queryParamKey)
+	classPath := fmt.Sprintf("%s/class/{%s:[0-9]+}", prefix, h.queryParamKey)
+	sessionPath := fmt.Sprintf("%s/session/{%s:[0-9]+}", prefix, h.queryParamKey)
+
+	// Handler for the sessions of a class
+	router.Handle(classPath+"/sessions", require(auth.PermAttendanceRead, nil, h.attendance.ListSessions)).
+		Methods(http.MethodGet)
+	router.Handle(classPath+"/sessions", require(auth.PermAttendanceWrite, nil, h.attendance.CreateSession)).
+		Methods(http.MethodPost)
+	router.Handle(sessionPath, require(auth.PermAttendanceRead, nil, h.attendance.GetSession)).
+		Methods(http.MethodGet).Name(routeSession)
+	router.Handle(sessionPath, require(auth.PermAttendanceWrite, nil, h.attendance.DeleteSession)).
+		Methods(http.MethodDelete)
+
+	// Handler for the attendance of a session
+	router.Handle(sessionPath+"/attendance", require(auth.PermAttendanceRead, nil, h.attendance.GetAttendance)).
+		Methods(http.MethodGet)
+	router.Handle(sessionPath+"/attendance", require(auth.PermAttendanceWrite, nil, h.attendance.MarkAttendance)).
+		Methods(http.MethodPut)
+
+	// Handler for attendance rate reports
+	router.Handle(
+		studentPath+"/attendance",
+		require(auth.PermAttendanceRead, auth.StudentFromPath(h.queryParamKey), h.attendance.StudentReport),
+	).Methods(http.MethodGet)
+	router.Handle(classPath+"/attendance", require(auth.PermAttendanceRead, nil, h.attendance.ClassReport)).
+		Methods(http.MethodGet)
+}
+
+func (h v2Handlers) registerSchedule(router *mux.Router, prefix string, require requireFunc) {
+	studentPath := fmt.Sprintf("%s/student/{%s:[0-9]+}", prefix, h.queryParamKey)
+	classPath := fmt.Sprintf("%s/class/{%s:[0-9]+}", prefix, h.queryParamKey)
+	roomPath := fmt.Sprintf("%s/room/{%s:[0-9]+}", prefix, h.queryParamKey)
+	slotPath := fmt.Sprintf("%s/schedule/{%s:[0-9]+}", prefix, h.queryParamKey)
+	ownStudent := auth.StudentFromPath(h.queryParamKey)
+
+	// Handler for rooms
+	router.Handle(prefix+"/room", require(auth.PermClassRead, nil, h.schedule.ListRooms)).Methods(http.MethodGet)
+	router.Handle(prefix+"/room", require(auth.PermClassWrite, nil, h.schedule.CreateRoom)).Methods(http.MethodPost)
+	router.Handle(roomPath, require(auth.PermClassRead, nil, h.schedule.GetRoom)).Methods(http.MethodGet).Name(routeRoom)
+	router.Handle(roomPath, require(auth.PermClassWrite, nil, h.schedule.UpdateRoom)).Methods(http.MethodPut)
+	router.Handle(roomPath, require(auth.PermClassWrite, nil, h.schedule.DeleteRoom)).Methods(http.MethodDelete)
+
+	// Handler for the weekly slots of a class
+	router.Handle(classPath+"/schedule", require(auth.PermClassRead, nil, h.schedule.ListClassSlots)).
+		Methods(http.MethodGet)
+	router.Handle(classPath+"/schedule", require(auth.PermClassWrite, nil, h.schedule.CreateSlot)).
+		Methods(http.MethodPost)
+	router.Handle(slotPath, require(auth.PermClassRead, nil, h.schedule.GetSlot)).
+		Methods(http.MethodGet).Name(routeScheduleSlot)
+	router.Handle(slotPath, require(auth.PermClassWrite, nil, h.schedule.UpdateSlot)).Methods(http.MethodPut)
+	router.Handle(slotPath, require(auth.PermClassWrite, nil, h.schedule.DeleteSlot)).Methods(http.MethodDelete)
+
+	// Handler for the schedule of a student
+	router.Handle(studentPath+"/schedule", require(auth.PermClassRead, ownStudent, h.schedule.StudentSchedule)).
+		Methods(http.MethodGet)
+	router.Handle(studentPath+"/schedule.ics", require(auth.PermClassRead, ownStudent, h.schedule.StudentScheduleICS)).
+		Methods(http.MethodGet)
+}
+
+func (h v2Handlers) registerContact(router *mux.Router, prefix string, require requireFunc) {
+	contactsPath := fmt.Sprintf("%s/student/{%s:[0-9]+}/contacts", prefix, h.queryParamKey)
+	contactPath := fmt.Sprintf("%s/{%s:[0-9]+}", contactsPath, contactParamKey)
+	ownStudent := auth.StudentFromPath(h.queryParamKey)
+
+	// Handler for the contacts of a student
+	router.Handle(contactsPath, require(auth.PermContactRead, ownStudent, h.contact.List)).Methods(http.MethodGet)
+	router.Handle(contactsPath, require(auth.PermContactWrite, nil, h.contact.Create)).Methods(http.MethodPost)
+	router.Handle(contactPath, require(auth.PermContactRead, ownStudent, h.contact.Get)).
+		Methods(http.MethodGet).Name(routeContact)
+	router.Handle(contactPath, require(auth.PermContactWrite, nil, h.contact.Update)).Methods(http.MethodPut)
+	router.Handle(contactPath, require(auth.PermContactWrite, nil, h.contact.Delete)).Methods(http.MethodDelete)
+}
+
+func (h v2Handlers) registerAssignment(router *mux.Router, prefix string, require requireFunc) {
+	classPath := fmt.Sprintf("%s/class/{%s:[0-9]+}", prefix, h.queryParamKey)
+	assignmentPath := fmt.Sprintf("%s/assignment/{%s:[0-9]+}", prefix, h.queryParamKey)
+	submissionPath := fmt.Sprintf("%s/submissions/{%s:[0-9]+}", assignmentPath, studentParamKey)
+
+	// Handler for the assignments of a class
+	router.Handle(classPath+"/assignments", require(auth.PermGradeRead, nil, h.assignment.List)).
+		Methods(http.MethodGet)
+	router.Handle(classPath+"/assignments", require(auth.PermGradeWrite, nil, h.assignment.Create)).
+		Methods(http.MethodPost)
+	router.Handle(assignmentPath, require(auth.PermGradeRead, nil, h.assignment.Get)).
+		Methods(http.MethodGet).Name(routeAssignment)
+	router.Handle(assignmentPath, require(auth.PermGradeWrite, nil, h.assignment.Update)).Methods(http.MethodPut)
+	router.Handle(assignmentPath, require(auth.PermGradeWrite, nil, h.assignment.Delete)).Methods(http.MethodDelete)
+
+	// Handler for the submissions of an assignment
+	router.Handle(assignmentPath+"/submissions", require(auth.PermGradeRead, nil, h.assignment.ListSubmissions)).
+		Methods(http.MethodGet)
+	router.Handle(
+		submissionPath,
+		require(auth.PermGradeRead, auth.StudentFromPath(studentParamKey), h.assignment.GetSubmission),
+	).Methods(http.MethodGet)
+	router.Handle(submissionPath, require(auth.PermGradeWrite, nil, h.assignment.SetSubmission)).Methods(http.MethodPut)
+	router.Handle(submissionPath, require(auth.PermGradeWrite, nil, h.assignment.DeleteSubmission)).
+		Methods(http.MethodDelete)
+
+	// Handler for the gradebook of a class
+	router.Handle(classPath+"/gradebook", require(auth.PermGradeRead, nil, h.assignment.Gradebook)).
+		Methods(http.MethodGet)
+	if h.rollUp {
+		router.Handle(classPath+"/gradebook/grades", require(auth.PermGradeWrite, nil, h.assignment.RollUp)).
+			Methods(http.MethodPost)
+	}
+}
@@ -0,0 +1,428 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/codec"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// weekdays names the days of the week by their number in schedules, from Monday (1) to Sunday (7).
+var weekdays = [...]string{"", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// ScheduleHandler serves the v2 room and schedule slot endpoints, and the weekly schedules of students.
+type ScheduleHandler struct {
+	roomStorage     repository.RoomPgRepo
+	scheduleStorage repository.SchedulePgRepo
+	queryParamKey   string
+	links           *links
+}
+
+// NewScheduleHandler creates a new ScheduleHandler with the given room and schedule storages.
+// Links are built from the named routes of router.
+func NewScheduleHandler(
+	roomStorage repository.RoomPgRepo,
+	scheduleStorage repository.SchedulePgRepo,
+	queryParamKey string,
+	router *mux.Router,
+) *ScheduleHandler {
+	return &ScheduleHandler{
+		roomStorage:     roomStorage,
+		scheduleStorage: scheduleStorage,
+		queryParamKey:   queryParamKey,
+		links:           newLinks(router, queryParamKey),
+	}
+}
+
+func (h *ScheduleHandler) ListRooms(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	rooms, err := h.roomStorage.List(req.Context())
+	if err != nil {
+		writeStorageError(w, req, err, "rooms")
+		return
+	}
+
+	if rooms == nil {
+		rooms = []models.Room{}
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: rooms})
+}
+
+func (h *ScheduleHandler) CreateRoom(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	var room models.Room
+	if !decodeBody(w, req, &room) {
+		return
+	}
+
+	if detail := validateRoom(room); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	id, err := h.roomStorage.Add(req.Context(), room)
+	if err != nil {
+		writeStorageError(w, req, err, "room")
+		return
+	}
+
+	room.RoomID = id
+
+	w.Header().Set("Location", h.links.room(id))
+	writeResponse(w, responseCodec, http.StatusCreated, models.Envelope{Data: room})
+}
+
+func (h *ScheduleHandler) GetRoom(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	roomID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	room, err := h.roomStorage.GetByID(req.Context(), roomID)
+	if err != nil {
+		writeStorageError(w, req, err, "room")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: room})
+}
+
+func (h *ScheduleHandler) UpdateRoom(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	roomID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var room models.Room
+	if !decodeBody(w, req, &room) {
+		return
+	}
+
+	if detail := validateRoom(room); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	if err := h.roomStorage.Update(req.Context(), roomID, room); err != nil {
+		writeStorageError(w, req, err, "room")
+		return
+	}
+
+	room.RoomID = roomID
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: room})
+}
+
+// DeleteRoom removes the room in the path. Rooms that classes are scheduled in cannot be removed.
+func (h *ScheduleHandler) DeleteRoom(w http.ResponseWriter, req *http.Request) {
+	roomID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	if err := h.roomStorage.Delete(req.Context(), roomID); err != nil {
+		if errors.Is(err, pkgErrors.ErrConflict) {
+			problem.Write(w, req, http.StatusConflict, "classes are scheduled in this room")
+			return
+		}
+
+		writeStorageError(w, req, err, "room")
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListClassSlots lists the weekly slots of the class in the path in every term.
+func (h *ScheduleHandler) ListClassSlots(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	slots, err := h.scheduleStorage.ListByClass(req.Context(), classID)
+	if err != nil {
+		writeStorageError(w, req, err, "class")
+		return
+	}
+
+	if slots == nil {
+		slots = []models.ScheduleSlot{}
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: slots})
+}
+
+// CreateSlot schedules the class in the path in a room every week of a term. Slots that overlap
+// another booking of the room, of a teacher of the class or of a student enrolled in it get 409.
+func (h *ScheduleHandler) CreateSlot(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var slot models.ScheduleSlot
+	if !decodeBody(w, req, &slot) {
+		return
+	}
+
+	if detail := validateSlot(slot); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	slot.ClassID = classID
+
+	id, err := h.scheduleStorage.AddSlot(req.Context(), slot)
+	if err != nil {
+		writeSlotError(w, req, err)
+		return
+	}
+
+	created, err := h.scheduleStorage.GetSlot(req.Context(), id)
+	if err != nil {
+		writeStorageError(w, req, err, "schedule_slot")
+		return
+	}
+
+	w.Header().Set("Location", h.links.scheduleSlot(id))
+	writeResponse(w, responseCodec, http.StatusCreated, models.Envelope{Data: created})
+}
+
+func (h *ScheduleHandler) GetSlot(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	slotID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	slot, err := h.scheduleStorage.GetSlot(req.Context(), slotID)
+	if err != nil {
+		writeStorageError(w, req, err, "schedule_slot")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: slot})
+}
+
+// UpdateSlot moves the slot in the path to another term, room, day or time. Its class cannot be changed.
+func (h *ScheduleHandler) UpdateSlot(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	slotID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var slot models.ScheduleSlot
+	if !decodeBody(w, req, &slot) {
+		return
+	}
+
+	if detail := validateSlot(slot); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	if err := h.scheduleStorage.UpdateSlot(req.Context(), slotID, slot); err != nil {
+		writeSlotError(w, req, err)
+		return
+	}
+
+	updated, err := h.scheduleStorage.GetSlot(req.Context(), slotID)
+	if err != nil {
+		writeStorageError(w, req, err, "schedule_slot")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: updated})
+}
+
+func (h *ScheduleHandler) DeleteSlot(w http.ResponseWriter, req *http.Request) {
+	slotID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	if err := h.scheduleStorage.DeleteSlot(req.Context(), slotID); err != nil {
+		writeStorageError(w, req, err, "schedule_slot")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// StudentSchedule returns the week of the student in the path, in the term given by the term query
+// parameter or in the current term. Besides the standard formats it can be exported as iCalendar.
+func (h *ScheduleHandler) StudentSchedule(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req, scheduleICS)
+	if !ok {
+		return
+	}
+
+	h.writeSchedule(w, req, responseCodec)
+}
+
+// StudentScheduleICS exports the week of the student in the path as iCalendar, whatever the Accept header,
+// so that calendar applications can subscribe to it.
+func (h *ScheduleHandler) StudentScheduleICS(w http.ResponseWriter, req *http.Request) {
+	h.writeSchedule(w, req, scheduleICS)
+}
+
+func (h *ScheduleHandler) writeSchedule(w http.ResponseWriter, req *http.Request, responseCodec codec.Codec) {
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	termID, ok := parseTerm(w, req)
+	if !ok {
+		return
+	}
+
+	term, slots, err := h.scheduleStorage.GetStudentSchedule(req.Context(), studentID, termID)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrNoCurrentTerm) {
+			problem.Write(w, req, http.StatusNotFound, "no academic term covers the current date, set term")
+			return
+		}
+
+		writeStorageError(w, req, err, "student or term")
+
+		return
+	}
+
+	schedule := buildWeekSchedule(studentID, term, slots)
+
+	if responseCodec == scheduleICS {
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="schedule-%d.ics"`, studentID))
+		writeResponse(w, responseCodec, http.StatusOK, schedule)
+
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: schedule})
+}
+
+// buildWeekSchedule lays slots, which are in weekly order, out on the days from Monday to Sunday.
+func buildWeekSchedule(studentID int64, term models.AcademicTerm, slots []models.ScheduleSlot) models.WeekSchedule {
+	schedule := models.WeekSchedule{
+		StudentID: studentID,
+		Term:      term,
+		Days:      make([]models.ScheduleDay, 0, 7),
+	}
+
+	for day := 1; day <= 7; day++ {
+		schedule.Days = append(schedule.Days, models.ScheduleDay{
+			DayOfWeek: day,
+			Day:       weekdays[day],
+			Slots:     []models.ScheduleSlot{},
+		})
+	}
+
+	for _, slot := range slots {
+		if slot.DayOfWeek < 1 || slot.DayOfWeek > 7 {
+			continue
+		}
+
+		day := &schedule.Days[slot.DayOfWeek-1]
+		day.Slots = append(day.Slots, slot)
+	}
+
+	return schedule
+}
+
+// writeSlotError writes the error of writing a slot: 409 for overlapping bookings and 422 for an unknown term or room.
+func writeSlotError(w http.ResponseWriter, req *http.Request, err error) {
+	if errors.Is(err, pkgErrors.ErrReferenceNotFound) {
+		problem.Write(w, req, http.StatusUnprocessableEntity, "the class, term or room of the slot does not exist")
+		return
+	}
+
+	writeStorageError(w, req, err, "schedule_slot")
+}
+
+// validateRoom returns why room cannot be stored, or "" when it can.
+func validateRoom(room models.Room) string {
+	switch {
+	case strings.TrimSpace(room.RoomName) == "":
+		return "room_name is required"
+	case room.Capacity != nil && *room.Capacity < 0:
+		return "capacity must not be negative"
+	}
+
+	return ""
+}
+
+// validateSlot returns why slot cannot be scheduled, or "" when it can.
+func validateSlot(slot models.ScheduleSlot) string {
+	switch {
+	case slot.TermID <= 0:
+		return "term_id is required"
+	case slot.RoomID <= 0:
+		return "room_id is required"
+	case slot.DayOfWeek < 1 || slot.DayOfWeek > 7:
+		return "day_of_week must be between 1 (Monday) and 7 (Sunday)"
+	}
+
+	start, err := time.Parse(models.TimeLayout, slot.StartTime)
+	if err != nil {
+		return "start_time must be a time formatted as HH:MM"
+	}
+
+	end, err := time.Parse(models.TimeLayout, slot.EndTime)
+	if err != nil {
+		return "end_time must be a time formatted as HH:MM"
+	}
+
+	if !end.After(start) {
+		return "end_time must be after start_time"
+	}
+
+	return ""
+}
@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newScheduleRouter(
+	roomStorage *mock_repository.MockRoomPgRepo,
+	scheduleStorage *mock_repository.MockSchedulePgRepo,
+) http.Handler {
+	return NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithSchedule(roomStorage, scheduleStorage))
+}
+
+func TestScheduleHandler_CreateSlot(t *testing.T) {
+	t.Parallel()
+	slot := models.ScheduleSlot{ClassID: 3, TermID: 2, RoomID: 5, DayOfWeek: 1, StartTime: "09:00", EndTime: "10:30"}
+	tests := []struct {
+		description      string
+		body             string
+		mock             func(m *mock_repository.MockSchedulePgRepo)
+		expectedCode     int
+		expectedLocation string
+	}{
+		{
+			description: "Created",
+			body:        `{"term_id": 2, "room_id": 5, "day_of_week": 1, "start_time": "09:00", "end_time": "10:30"}`,
+			mock: func(m *mock_repository.MockSchedulePgRepo) {
+				m.EXPECT().AddSlot(gomock.Any(), slot).Return(int64(11), nil)
+				created := slot
+				created.SlotID, created.ClassName, created.RoomName = 11, "Math", "A101"
+				m.EXPECT().GetSlot(gomock.Any(), int64(11)).Return(created, nil)
+			},
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/v2/schedule/11",
+		},
+		{
+			description:  "Day out of range",
+			body:         `{"term_id": 2, "room_id": 5, "day_of_week": 8, "start_time": "09:00", "end_time": "10:30"}`,
+			mock:         func(m *mock_repository.MockSchedulePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Malformed time",
+			body:         `{"term_id": 2, "room_id": 5, "day_of_week": 1, "start_time": "9am", "end_time": "10:30"}`,
+			mock:         func(m *mock_repository.MockSchedulePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Ends before it starts",
+			body:         `{"term_id": 2, "room_id": 5, "day_of_week": 1, "start_time": "10:30", "end_time": "09:00"}`,
+			mock:         func(m *mock_repository.MockSchedulePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Room missing",
+			body:         `{"term_id": 2, "day_of_week": 1, "start_time": "09:00", "end_time": "10:30"}`,
+			mock:         func(m *mock_repository.MockSchedulePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Unknown room",
+			body:        `{"term_id": 2, "room_id": 5, "day_of_week": 1, "start_time": "09:00", "end_time": "10:30"}`,
+			mock: func(m *mock_repository.MockSchedulePgRepo) {
+				m.EXPECT().AddSlot(gomock.Any(), slot).Return(int64(-1), pkgErrors.ErrReferenceNotFound)
+			},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			description: "Room booked",
+			body:        `{"term_id": 2, "room_id": 5, "day_of_week": 1, "start_time": "09:00", "end_time": "10:30"}`,
+			mock: func(m *mock_repository.MockSchedulePgRepo) {
+				m.EXPECT().AddSlot(gomock.Any(), slot).Return(int64(-1), pkgErrors.ErrRoomBooked)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			description: "Teacher booked",
+			body:        `{"term_id": 2, "room_id": 5, "day_of_week": 1, "start_time": "09:00", "end_time": "10:30"}`,
+			mock: func(m *mock_repository.MockSchedulePgRepo) {
+				m.EXPECT().AddSlot(gomock.Any(), slot).Return(int64(-1), pkgErrors.ErrTeacherBooked)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			description: "Student booked",
+			body:        `{"term_id": 2, "room_id": 5, "day_of_week": 1, "start_time": "09:00", "end_time": "10:30"}`,
+			mock: func(m *mock_repository.MockSchedulePgRepo) {
+				m.EXPECT().AddSlot(gomock.Any(), slot).Return(int64(-1), pkgErrors.ErrStudentBooked)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockSchedulePgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(http.MethodPost, "/v2/class/3/schedule", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			newScheduleRouter(mock_repository.NewMockRoomPgRepo(ctrl), mockRepo).ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			assert.Equal(t, tc.expectedLocation, rr.Header().Get("Location"))
+		})
+	}
+}
+
+func TestScheduleHandler_Rooms(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description  string
+		method       string
+		url          string
+		body         string
+		mock         func(m *mock_repository.MockRoomPgRepo)
+		expectedCode int
+	}{
+		{
+			description: "Created",
+			method:      http.MethodPost,
+			url:         "/v2/room",
+			body:        `{"room_name": "A101", "capacity": 30}`,
+			mock: func(m *mock_repository.MockRoomPgRepo) {
+				m.EXPECT().Add(gomock.Any(), gomock.Any()).Return(int64(5), nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			description:  "Name missing",
+			method:       http.MethodPost,
+			url:          "/v2/room",
+			body:         `{"room_name": " "}`,
+			mock:         func(m *mock_repository.MockRoomPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Negative capacity",
+			method:       http.MethodPut,
+			url:          "/v2/room/5",
+			body:         `{"room_name": "A101", "capacity": -1}`,
+			mock:         func(m *mock_repository.MockRoomPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Name taken",
+			method:      http.MethodPut,
+			url:         "/v2/room/5",
+			body:        `{"room_name": "A101"}`,
+			mock: func(m *mock_repository.MockRoomPgRepo) {
+				m.EXPECT().Update(gomock.Any(), int64(5), models.Room{RoomName: "A101"}).Return(pkgErrors.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			description: "Deleted",
+			method:      http.MethodDelete,
+			url:         "/v2/room/5",
+			mock: func(m *mock_repository.MockRoomPgRepo) {
+				m.EXPECT().Delete(gomock.Any(), int64(5)).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			description: "Still scheduled",
+			method:      http.MethodDelete,
+			url:         "/v2/room/5",
+			mock: func(m *mock_repository.MockRoomPgRepo) {
+				m.EXPECT().Delete(gomock.Any(), int64(5)).Return(pkgErrors.ErrConflict)
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockRoomPgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			newScheduleRouter(mockRepo, mock_repository.NewMockSchedulePgRepo(ctrl)).ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestScheduleHandler_StudentSchedule(t *testing.T) {
+	t.Parallel()
+	term := models.AcademicTerm{TermID: 2, Name: "Fall 2026", StartDate: "2026-09-02", EndDate: "2026-12-18"}
+	slots := []models.ScheduleSlot{
+		{SlotID: 11, ClassName: "Math", RoomName: "A101", DayOfWeek: 1, StartTime: "09:00", EndTime: "10:30"},
+		{SlotID: 12, ClassName: "Art, History", RoomName: "B2", DayOfWeek: 3, StartTime: "13:00", EndTime: "14:00"},
+	}
+	termID := int64(2)
+	tests := []struct {
+		description  string
+		url          string
+		accept       string
+		mock         func(m *mock_repository.MockSchedulePgRepo)
+		expectedCode int
+		expectedDays []int
+	}{
+		{
+			description: "Week view of the current term",
+			url:         "/v2/student/7/schedule",
+			mock: func(m *mock_repository.MockSchedulePgRepo) {
+				m.EXPECT().GetStudentSchedule(gomock.Any(), int64(7), nil).Return(term, slots, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedDays: []int{1, 0, 1, 0, 0, 0, 0},
+		},
+		{
+			description: "Week view of a term",
+			url:         "/v2/student/7/schedule?term=2",
+			mock: func(m *mock_repository.MockSchedulePgRepo) {
+				m.EXPECT().GetStudentSchedule(gomock.Any(), int64(7), &termID).Return(term, nil, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedDays: []int{0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			description:  "Malformed term",
+			url:          "/v2/student/7/schedule?term=last",
+			mock:         func(m *mock_repository.MockSchedulePgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "No current term",
+			url:         "/v2/student/7/schedule",
+			mock: func(m *mock_repository.MockSchedulePgRepo) {
+				m.EXPECT().GetStudentSchedule(gomock.Any(), int64(7), nil).Return(models.AcademicTerm{}, nil, pkgErrors.ErrNoCurrentTerm)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			description: "Negotiated as iCalendar",
+			url:         "/v2/student/7/schedule",
+			accept:      "text/calendar",
+			mock: func(m *mock_repository.MockSchedulePgRepo) {
+				m.EXPECT().GetStudentSchedule(gomock.Any(), int64(7), nil).Return(term, slots, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description: "Exported as iCalendar",
+			url:         "/v2/student/7/schedule.ics",
+			mock: func(m *mock_repository.MockSchedulePgRepo) {
+				m.EXPECT().GetStudentSchedule(gomock.Any(), int64(7), nil).Return(term, slots, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockSchedulePgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			rr := httptest.NewRecorder()
+			// act
+			newScheduleRouter(mock_repository.NewMockRoomPgRepo(ctrl), mockRepo).ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+			if tc.expectedDays == nil {
+				assert.Equal(t, "text/calendar", rr.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="schedule-7.ics"`, rr.Header().Get("Content-Disposition"))
+				assert.Equal(t, 2, strings.Count(rr.Body.String(), "BEGIN:VEVENT\r\n"))
+				return
+			}
+			var actual struct {
+				Data models.WeekSchedule `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			require.Len(t, actual.Data.Days, 7)
+			for i, day := range actual.Data.Days {
+				assert.Equal(t, i+1, day.DayOfWeek)
+				assert.Len(t, day.Slots, tc.expectedDays[i])
+			}
+			assert.Equal(t, "Monday", actual.Data.Days[0].Day)
+		})
+	}
+}
+
+func TestRenderScheduleICS(t *testing.T) {
+	t.Parallel()
+	// arrange
+	schedule := buildWeekSchedule(7, models.AcademicTerm{
+		TermID: 2, Name: "Fall 2026", StartDate: "2026-09-02", EndDate: "2026-12-18",
+	}, []models.ScheduleSlot{
+		{SlotID: 11, ClassName: "Math", RoomName: "A101", DayOfWeek: 1, StartTime: "09:00", EndTime: "10:30"},
+		{SlotID: 12, ClassName: "Art, History; " + strings.Repeat("é", 40), RoomName: "B2", DayOfWeek: 3, StartTime: "13:00", EndTime: "14:00"},
+	})
+	now := time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)
+	// act
+	content, err := renderScheduleICS(schedule, now)
+	// assert
+	require.NoError(t, err)
+	ics := string(content)
+	// The term starts on a Wednesday, so the Monday slot first meets on the following Monday.
+	assert.Contains(t, ics, "\r\nUID:slot-11-student-7@crud-go-backend\r\nDTSTAMP:20261019T083000Z\r\n"+
+		"DTSTART:20260907T090000\r\nDTEND:20260907T103000\r\nRRULE:FREQ=WEEKLY;UNTIL=20261218T235959\r\n")
+	assert.Contains(t, ics, "DTSTART:20260902T130000\r\n")
+	assert.Contains(t, ics, `SUMMARY:Art\, History\; é`)
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), icsLineOctets)
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.Contains(t, unfolded, `SUMMARY:Art\, History\; `+strings.Repeat("é", 40)+"\r\n")
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/codec"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// scheduleICS renders student schedules as iCalendar (RFC 5545) files. It is only offered by the schedule endpoints.
+var scheduleICS codec.Codec = scheduleICSCodec{}
+
+type scheduleICSCodec struct{}
+
+func (scheduleICSCodec) Name() string        { return "iCalendar" }
+func (scheduleICSCodec) ContentType() string { return "text/calendar" }
+
+func (scheduleICSCodec) Marshal(v interface{}) ([]byte, error) {
+	schedule, ok := v.(models.WeekSchedule)
+	if !ok {
+		return nil, fmt.Errorf("cannot render %T as iCalendar", v)
+	}
+
+	return renderScheduleICS(schedule, time.Now())
+}
+
+func (scheduleICSCodec) Unmarshal([]byte, interface{}) error {
+	return errors.New("iCalendar request bodies are not supported")
+}
+
+const (
+	icsDateTimeLayout = "20060102T150405"
+	// icsLineOctets is the longest content line of RFC 5545, without its CRLF.
+	icsLineOctets = 75
+)
+
+// renderScheduleICS writes one weekly recurring event per slot, from its first day in the term to the
+// end of the term. Times are floating, so that calendars show them in the local time of the school.
+func renderScheduleICS(schedule models.WeekSchedule, now time.Time) ([]byte, error) {
+	termStart, err := time.Parse(models.DateLayout, schedule.Term.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid term start: %w", err)
+	}
+
+	termEnd, err := time.Parse(models.DateLayout, schedule.Term.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid term end: %w", err)
+	}
+
+	var b strings.Builder
+
+	line := func(name, value string) {
+		writeICSLine(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//"+serverName+"//schedule//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("X-WR-CALNAME", escapeICSText(fmt.Sprintf("Schedule of student %d, %s", schedule.StudentID, schedule.Term.Name)))
+
+	for _, day := range schedule.Days {
+		for _, slot := range day.Slots {
+			start, end, err := slotTimes(slot)
+			if err != nil {
+				return nil, err
+			}
+
+			// The first meeting is on the first matching weekday on or after the start of the term.
+			first := termStart.AddDate(0, 0, (slot.DayOfWeek-isoWeekday(termStart)+7)%7)
+			if first.After(termEnd) {
+				continue
+			}
+
+			line("BEGIN", "VEVENT")
+			line("UID", fmt.Sprintf("slot-%d-student-%d@%s", slot.SlotID, schedule.StudentID, serverName))
+			line("DTSTAMP", now.UTC().Format(icsDateTimeLayout)+"Z")
+			line("DTSTART", first.Add(start).Format(icsDateTimeLayout))
+			line("DTEND", first.Add(end).Format(icsDateTimeLayout))
+			line("RRULE", "FREQ=WEEKLY;UNTIL="+termEnd.Format("20060102")+"T235959")
+			line("SUMMARY", escapeICSText(slot.ClassName))
+			line("LOCATION", escapeICSText(slot.RoomName))
+			line("END", "VEVENT")
+		}
+	}
+
+	line("END", "VCALENDAR")
+
+	return []byte(b.String()), nil
+}
+
+// slotTimes returns the start and end of slot as offsets from midnight.
+func slotTimes(slot models.ScheduleSlot) (start, end time.Duration, err error) {
+	midnight, _ := time.Parse(models.TimeLayout, "00:00")
+
+	startTime, err := time.Parse(models.TimeLayout, slot.StartTime)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid start of slot %d: %w", slot.SlotID, err)
+	}
+
+	endTime, err := time.Parse(models.TimeLayout, slot.EndTime)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid end of slot %d: %w", slot.SlotID, err)
+	}
+
+	return startTime.Sub(midnight), endTime.Sub(midnight), nil
+}
+
+// isoWeekday numbers the weekday of t from Monday (1) to Sunday (7), like slots do.
+func isoWeekday(t time.Time) int {
+	return (int(t.Weekday())+6)%7 + 1
+}
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escapeICSText escapes a TEXT property value.
+func escapeICSText(value string) string {
+	return icsTextEscaper.Replace(value)
+}
+
+// writeICSLine writes a content line ended by CRLF, folding it so that no line is longer than
+// icsLineOctets octets. Continuation lines start with a space, and UTF-8 sequences are never split.
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsLineOctets
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+
+		line = line[cut:]
+		limit = icsLineOctets - 1
+	}
+
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// StartupHandler answers every request while the server waits for its dependencies,
+// so that probes get a clear "not ready" instead of a refused connection.
+type StartupHandler struct {
+	reason atomic.Value
+}
+
+// NewStartupHandler creates a new StartupHandler.
+func NewStartupHandler() *StartupHandler {
+	h := &StartupHandler{}
+	h.reason.Store("starting")
+
+	return h
+}
+
+// SetReason records why the server is not ready yet, e.g. the category of the last connection failure.
+func (h *StartupHandler) SetReason(reason string) {
+	h.reason.Store(reason)
+}
+
+func (h *StartupHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	reason, _ := h.reason.Load().(string)
+
+	w.Header().Set("Retry-After", "1")
+	http.Error(w, "not ready: "+reason, http.StatusServiceUnavailable)
+}
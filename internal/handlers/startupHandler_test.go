@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartupHandler(t *testing.T) {
+	t.Parallel()
+
+	startupHandler := NewStartupHandler()
+
+	for _, path := range []string{"/healthz", "/readyz", "/student/1"} {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		// act
+		startupHandler.ServeHTTP(rr, req)
+		// assert
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Equal(t, "not ready: starting\n", rr.Body.String())
+	}
+
+	startupHandler.SetReason("waiting for database (refused)")
+
+	req, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	startupHandler.ServeHTTP(rr, req)
+
+	assert.Equal(t, "not ready: waiting for database (refused)\n", rr.Body.String())
+}
@@ -4,10 +4,8 @@ import (
 	"CRUD_Go_Backend/internal/handlers/models"
 	"CRUD_Go_Backend/internal/pkg/pkgErrors"
 	"CRUD_Go_Backend/internal/repository"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 
@@ -29,24 +27,26 @@ func NewStudentHandler(studentStorage repository.StudentPgRepo, queryParamKey st
 }
 
 func (h *StudentHandler) Create(w http.ResponseWriter, req *http.Request) {
-	body, err := io.ReadAll(req.Body)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to read request body: %v", err), http.StatusInternalServerError)
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
 		return
 	}
 
 	var studentReq models.StudentRequest
-
-	if err = json.Unmarshal(body, &studentReq); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to unmarshal JSON: %v", err), http.StatusBadRequest)
+	if !decodeBody(w, req, &studentReq) {
 		return
 	}
 
 	if studentReq.StudentName == "" || studentReq.Grade < 0 {
-		http.Error(w, fmt.Sprintf("Failed Student name is empty or Grade is negative: %v", err), http.StatusBadRequest)
+		http.Error(w, "Failed Student name is empty or Grade is negative", http.StatusBadRequest)
 		return
 	}
 
+	// v1 cannot set the profile or the status of a student, which only v2 validates.
+	studentReq = legacyStudent(studentReq)
+
+	var err error
+
 	studentReq.StudentID, err = h.studentStorage.Add(req.Context(), studentReq)
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrInvalidName) {
@@ -59,35 +59,16 @@ func (h *StudentHandler) Create(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	userInfoJSON, err := json.Marshal(studentReq)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to marshal JSON response: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusOK)
-
-	_, err = w.Write(userInfoJSON)
-	if err != nil {
-		http.Error(w, "Failed to write response", http.StatusInternalServerError)
-		return
-	}
+	writeResponse(w, responseCodec, http.StatusOK, legacyStudent(studentReq))
 }
 
 func (h *StudentHandler) Update(w http.ResponseWriter, req *http.Request) {
-	body, err := io.ReadAll(req.Body)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to read request body: %v", err), http.StatusInternalServerError)
-		return
-	}
-
 	var student models.StudentRequest // 1
-	if err := json.Unmarshal(body, &student); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to unmarshal JSON: %v", err), http.StatusBadRequest)
+	if !decodeBody(w, req, &student) {
 		return
 	}
 
-	err = h.studentStorage.Update(req.Context(), student.StudentID, student)
+	err := h.studentStorage.Update(req.Context(), student.StudentID, student)
 	if err != nil {
 		if errors.Is(err, pkgErrors.ErrNotFound) {
 			http.Error(w, "Student with such student_id not found", http.StatusNotFound)
@@ -111,6 +92,11 @@ func (h *StudentHandler) Update(w http.ResponseWriter, req *http.Request) {
 }
 
 func (h *StudentHandler) Get(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
 	key, ok := mux.Vars(req)[h.queryParamKey]
 	if !ok {
 		http.Error(w, "Invalid request. Missing query parameter.", http.StatusBadRequest)
@@ -135,19 +121,7 @@ func (h *StudentHandler) Get(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	userInfoJSON, err := json.Marshal(userInfo)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to marshal JSON response: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusOK)
-
-	_, err = w.Write(userInfoJSON)
-	if err != nil {
-		http.Error(w, "Failed to write response", http.StatusInternalServerError)
-		return
-	}
+	writeResponse(w, responseCodec, http.StatusOK, legacyStudent(userInfo))
 }
 
 func (h *StudentHandler) Delete(w http.ResponseWriter, req *http.Request) {
@@ -185,3 +159,12 @@ func (h *StudentHandler) Delete(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, "Failed to write response", http.StatusInternalServerError)
 	}
 }
+
+// legacyStudent keeps the v1 representation of student to its id, name and grade.
+func legacyStudent(student models.StudentRequest) models.StudentRequest {
+	return models.StudentRequest{
+		StudentID:   student.StudentID,
+		StudentName: student.StudentName,
+		Grade:       student.Grade,
+	}
+}
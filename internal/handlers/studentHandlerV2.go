@@ -0,0 +1,435 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/codec"
+	"CRUD_Go_Backend/internal/pkg/pii"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+)
+
+// Limits of student names and status change reasons, in characters.
+const (
+	maxStudentName  = 200
+	maxStatusReason = 500
+)
+
+// studentNumber matches external student numbers: up to 32 letters, digits and dashes, starting with a letter or digit.
+var studentNumber = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]{0,31}$`)
+
+// StudentHandlerV2 serves the v2 student contract: enveloped bodies, 201 with Location on create,
+// the updated resource on update, 204 on delete, and problem+json errors. Reads support sparse
+// fieldsets (?fields=) and included classes (?include=classes), and render application/hal+json on request.
+type StudentHandlerV2 struct {
+	studentStorage   repository.StudentPgRepo
+	classInfoStorage repository.ClassInfoPgRepo
+	queryParamKey    string
+	links            *links
+}
+
+// NewStudentHandlerV2 creates a new StudentHandlerV2 with the given storage services.
+// Links are built from the named routes of router.
+func NewStudentHandlerV2(
+	studentStorage repository.StudentPgRepo,
+	classInfoStorage repository.ClassInfoPgRepo,
+	queryParamKey string,
+	router *mux.Router,
+) *StudentHandlerV2 {
+	return &StudentHandlerV2{
+		studentStorage:   studentStorage,
+		classInfoStorage: classInfoStorage,
+		queryParamKey:    queryParamKey,
+		links:            newLinks(router, queryParamKey),
+	}
+}
+
+func (h *StudentHandlerV2) Create(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req, codec.HAL)
+	if !ok {
+		return
+	}
+
+	var student models.StudentRequest
+	if !decodeBody(w, req, &student) {
+		return
+	}
+
+	student.StudentName = displayName(student)
+	if detail := validateStudent(student); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	if student.Status != "" && student.Status != models.StudentApplicant && student.Status != models.StudentActive {
+		problem.Write(w, req, http.StatusBadRequest, "status must be applicant or active for a new student")
+		return
+	}
+
+	studentID, err := h.studentStorage.Add(req.Context(), student)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrConflict) {
+			problem.Write(w, req, http.StatusConflict, "student_number is taken")
+			return
+		}
+
+		problem.Write(w, req, http.StatusInternalServerError, fmt.Sprintf("failed to add student: %v", err))
+
+		return
+	}
+
+	created, err := h.studentStorage.GetByID(req.Context(), studentID)
+	if err != nil {
+		writeStorageError(w, req, err, "student")
+		return
+	}
+
+	w.Header().Set("Location", h.links.student(studentID))
+	h.writeStudent(w, responseCodec, http.StatusCreated, created, nil, nil)
+}
+
+func (h *StudentHandlerV2) Get(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req, codec.HAL)
+	if !ok {
+		return
+	}
+
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	fields, ok := parseFields(w, req, repository.StudentColumns)
+	if !ok {
+		return
+	}
+
+	include, ok := parseInclude(w, req, includeClasses)
+	if !ok {
+		return
+	}
+
+	student, err := h.studentStorage.GetByIDWithFields(req.Context(), studentID, fields)
+	if err != nil {
+		writeStorageError(w, req, err, "student")
+		return
+	}
+
+	var classes *[]models.ClassInfo
+
+	if include[includeClasses] {
+		classesInfo, err := h.classInfoStorage.GetByStudentID(req.Context(), studentID)
+		if err != nil {
+			writeStorageError(w, req, err, "class_info")
+			return
+		}
+
+		classes = nonNilClasses(classesInfo)
+	}
+
+	h.writeStudent(w, responseCodec, http.StatusOK, student, fields, classes)
+}
+
+func (h *StudentHandlerV2) List(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req, codec.HAL)
+	if !ok {
+		return
+	}
+
+	p, ok := parsePage(w, req)
+	if !ok {
+		return
+	}
+
+	fields, ok := parseFields(w, req, repository.StudentColumns)
+	if !ok {
+		return
+	}
+
+	include, ok := parseInclude(w, req, includeClasses)
+	if !ok {
+		return
+	}
+
+	students, err := h.studentStorage.List(req.Context(), p.limit, p.offset, fields)
+	if err != nil {
+		writeStorageError(w, req, err, "students")
+		return
+	}
+
+	total, err := h.studentStorage.Count(req.Context())
+	if err != nil {
+		writeStorageError(w, req, err, "students")
+		return
+	}
+
+	var classesByStudent map[int64][]models.ClassInfo
+
+	if include[includeClasses] && len(students) > 0 {
+		studentIDs := utils.Map(students, func(s models.StudentRequest) int64 { return s.StudentID })
+
+		if classesByStudent, err = h.classInfoStorage.GetByStudentIDs(req.Context(), studentIDs); err != nil {
+			writeStorageError(w, req, err, "class_info")
+			return
+		}
+	}
+
+	// classesOf is nil unless the classes are included.
+	classesOf := func(studentID int64) *[]models.ClassInfo {
+		if !include[includeClasses] {
+			return nil
+		}
+
+		return nonNilClasses(classesByStudent[studentID])
+	}
+
+	if responseCodec != codec.HAL {
+		views := utils.Map(students, func(s models.StudentRequest) models.StudentView {
+			return studentView(s, fields, classesOf(s.StudentID))
+		})
+		writeResponse(w, responseCodec, http.StatusOK, listEnvelope(req, views, len(views), total, p))
+
+		return
+	}
+
+	var collection models.StudentCollection
+	collection.Links = halPageLinks(h.links.students(), req, total, p)
+	collection.ListMeta = models.ListMeta{Total: total, Count: len(students), Limit: p.limit, Offset: p.offset}
+	collection.Embedded.Students = utils.Map(students, func(s models.StudentRequest) models.StudentResource {
+		return h.links.studentResource(s, fields, classesOf(s.StudentID))
+	})
+
+	writeResponse(w, responseCodec, http.StatusOK, collection)
+}
+
+func (h *StudentHandlerV2) Update(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req, codec.HAL)
+	if !ok {
+		return
+	}
+
+	var student models.StudentRequest
+	if !decodeBody(w, req, &student) {
+		return
+	}
+
+	student.StudentName = displayName(student)
+	if detail := validateStudent(student); detail != "" {
+		problem.Write(w, req, http.StatusBadRequest, detail)
+		return
+	}
+
+	if err := h.studentStorage.UpdateProfile(req.Context(), student.StudentID, student); err != nil {
+		if errors.Is(err, pkgErrors.ErrConflict) {
+			problem.Write(w, req, http.StatusConflict, "student_number is taken")
+			return
+		}
+
+		writeStorageError(w, req, err, "student")
+
+		return
+	}
+
+	updated, err := h.studentStorage.GetByID(req.Context(), student.StudentID)
+	if err != nil {
+		writeStorageError(w, req, err, "student")
+		return
+	}
+
+	h.writeStudent(w, responseCodec, http.StatusOK, updated, nil, nil)
+}
+
+func (h *StudentHandlerV2) Delete(w http.ResponseWriter, req *http.Request) {
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	if err := h.studentStorage.Delete(req.Context(), studentID); err != nil {
+		writeStorageError(w, req, err, "student")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetStatus moves the student in the path to another status and records the change. Changes that
+// models.CanChangeStudentStatus does not allow get 409.
+func (h *StudentHandlerV2) SetStatus(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var change models.StudentStatusChange
+	if !decodeBody(w, req, &change) {
+		return
+	}
+
+	switch {
+	case !models.IsStudentStatus(change.ToStatus):
+		problem.Write(w, req, http.StatusBadRequest, fmt.Sprintf(
+			"to_status must be %s, %s, %s or %s",
+			models.StudentApplicant, models.StudentActive, models.StudentGraduated, models.StudentWithdrawn,
+		))
+
+		return
+	case utf8.RuneCountInString(change.Reason) > maxStatusReason:
+		problem.Write(w, req, http.StatusBadRequest, fmt.Sprintf("reason must be at most %d characters", maxStatusReason))
+		return
+	}
+
+	recorded, err := h.studentStorage.SetStatus(req.Context(), studentID, change.ToStatus, change.Reason)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrStatusTransition) {
+			problem.Write(w, req, http.StatusConflict, err.Error())
+			return
+		}
+
+		writeStorageError(w, req, err, "student")
+
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: recorded})
+}
+
+// StatusHistory lists the statuses of the student in the path, oldest first.
+func (h *StudentHandlerV2) StatusHistory(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	studentID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	changes, err := h.studentStorage.StatusHistory(req.Context(), studentID)
+	if err != nil {
+		writeStorageError(w, req, err, "student")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: changes})
+}
+
+// displayName returns the student_name of student, which defaults to its first and last name when both are set.
+func displayName(student models.StudentRequest) string {
+	firstName, lastName := strings.TrimSpace(student.FirstName), strings.TrimSpace(student.LastName)
+	if strings.TrimSpace(student.StudentName) != "" || firstName == "" || lastName == "" {
+		return student.StudentName
+	}
+
+	return firstName + " " + lastName
+}
+
+// validateStudent returns why the profile of student cannot be stored, or "" when it can.
+func validateStudent(student models.StudentRequest) string {
+	for _, name := range []struct{ field, value string }{
+		{"student_name", student.StudentName},
+		{"first_name", student.FirstName},
+		{"last_name", student.LastName},
+		{"preferred_name", student.PreferredName},
+	} {
+		if utf8.RuneCountInString(name.value) > maxStudentName {
+			return fmt.Sprintf("%s must be at most %d characters", name.field, maxStudentName)
+		}
+	}
+
+	switch {
+	case strings.TrimSpace(student.StudentName) == "" || student.Grade < 0:
+		return "student_name, or first_name and last_name, must not be empty and grade must not be negative"
+	case student.Email != "" && !validEmail(student.Email):
+		return "email must be an address such as jane@example.com"
+	case student.StudentNumber != "" && !studentNumber.MatchString(student.StudentNumber):
+		return "student_number must be up to 32 letters, digits and dashes"
+	}
+
+	if student.DateOfBirth != "" {
+		born, err := time.Parse(models.DateLayout, student.DateOfBirth)
+		if err != nil {
+			return "date_of_birth must be formatted as " + models.DateLayout
+		}
+
+		if born.After(time.Now()) {
+			return "date_of_birth must not be in the future"
+		}
+	}
+
+	return ""
+}
+
+// writeStudent renders the requested fields of student as a HAL resource or as an envelope.
+// classes is nil unless the classes are included.
+func (h *StudentHandlerV2) writeStudent(
+	w http.ResponseWriter,
+	responseCodec codec.Codec,
+	status int,
+	student models.StudentRequest,
+	fields []string,
+	classes *[]models.ClassInfo,
+) {
+	if responseCodec == codec.HAL {
+		writeResponse(w, responseCodec, status, h.links.studentResource(student, fields, classes))
+		return
+	}
+
+	writeResponse(w, responseCodec, status, models.Envelope{Data: studentView(student, fields, classes)})
+}
+
+// nonNilClasses returns a pointer to classesInfo, so that a student without classes renders an empty list.
+func nonNilClasses(classesInfo []models.ClassInfo) *[]models.ClassInfo {
+	if classesInfo == nil {
+		classesInfo = []models.ClassInfo{}
+	}
+
+	return &classesInfo
+}
+
+// writeStorageError maps repository errors to 404, 409 or 500 problems.
+func writeStorageError(w http.ResponseWriter, req *http.Request, err error, resource string) {
+	switch {
+	case errors.Is(err, pkgErrors.ErrNotFound):
+		problem.Write(w, req, http.StatusNotFound, resource+" not found")
+		return
+	case errors.Is(err, pkgErrors.ErrTermClosed):
+		problem.Write(w, req, http.StatusConflict, "the academic term of this "+resource+" is closed")
+		return
+	case errors.Is(err, pkgErrors.ErrNoCurrentTerm):
+		problem.Write(w, req, http.StatusConflict, "no academic term covers the current date, set term_id")
+		return
+	case errors.Is(err, pkgErrors.ErrConflict):
+		problem.Write(w, req, http.StatusConflict, resource+" conflicts with an existing record")
+		return
+	case errors.Is(err, pkgErrors.ErrWaitlisted):
+		problem.Write(w, req, http.StatusConflict, resource+" is waitlisted")
+		return
+	case errors.Is(err, pkgErrors.ErrRoomBooked),
+		errors.Is(err, pkgErrors.ErrTeacherBooked),
+		errors.Is(err, pkgErrors.ErrStudentBooked):
+		problem.Write(w, req, http.StatusConflict, err.Error())
+		return
+	}
+
+	// Database errors can echo values and schema names, so they are logged masked and not returned.
+	slog.ErrorContext(req.Context(), "failed to access "+resource, "error", pii.Error(err))
+	problem.Write(w, req, http.StatusInternalServerError, "failed to access "+resource)
+}
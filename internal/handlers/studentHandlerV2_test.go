@@ -0,0 +1,515 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// v2Routes returns a router with the named v2 routes that links are built from.
+func v2Routes() *mux.Router {
+	return NewRouter(nil, nil, NewHealthHandler(nil, ""), "id")
+}
+
+func TestStudentHandlerV2_Create(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	type mockExpected struct {
+		result int64
+		error  error
+	}
+	tests := []struct {
+		description          string
+		body                 string
+		mockArguments        *models.StudentRequest
+		mockExpectedEntities mockExpected
+		result               models.StudentRequest
+		expectedCode         int
+		expectedLocation     string
+	}{
+		{
+			description:          "Created",
+			body:                 `{"student_name": "Test", "grade": 90}`,
+			mockArguments:        &models.StudentRequest{StudentName: "Test", Grade: 90},
+			mockExpectedEntities: mockExpected{result: 7},
+			result:               models.StudentRequest{StudentID: 7, StudentName: "Test", Grade: 90, Status: models.StudentActive},
+			expectedCode:         http.StatusCreated,
+			expectedLocation:     "/v2/student/7",
+		},
+		{
+			description: "Created with a profile",
+			body: `{"first_name": "Jane", "last_name": "Doe", "preferred_name": "JD", "date_of_birth": "2010-04-02",
+				"email": "jane@example.com", "student_number": "S-2026-001", "status": "applicant"}`,
+			mockArguments: &models.StudentRequest{
+				StudentName: "Jane Doe", FirstName: "Jane", LastName: "Doe", PreferredName: "JD", DateOfBirth: "2010-04-02",
+				Email: "jane@example.com", StudentNumber: "S-2026-001", Status: models.StudentApplicant,
+			},
+			mockExpectedEntities: mockExpected{result: 7},
+			result: models.StudentRequest{
+				StudentID: 7, StudentName: "Jane Doe", FirstName: "Jane", LastName: "Doe", PreferredName: "JD",
+				DateOfBirth: "2010-04-02", Email: "jane@example.com", StudentNumber: "S-2026-001", Status: models.StudentApplicant,
+			},
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/v2/student/7",
+		},
+		{
+			description:  "Name missing",
+			body:         `{"first_name": "Jane", "grade": 90}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Malformed email",
+			body:         `{"student_name": "Test", "email": "Jane <jane@example.com>"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Born in the future",
+			body:         `{"student_name": "Test", "date_of_birth": "2999-01-01"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Malformed student number",
+			body:         `{"student_name": "Test", "student_number": "S 1"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Created as graduated",
+			body:         `{"student_name": "Test", "status": "graduated"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:          "Student number taken",
+			body:                 `{"student_name": "Test", "student_number": "S-1"}`,
+			mockArguments:        &models.StudentRequest{StudentName: "Test", StudentNumber: "S-1"},
+			mockExpectedEntities: mockExpected{result: -1, error: pkgErrors.ErrConflict},
+			expectedCode:         http.StatusConflict,
+		},
+		{
+			description:          "Failed database unable to add",
+			body:                 `{"student_name": "Test", "grade": 90}`,
+			mockArguments:        &models.StudentRequest{StudentName: "Test", Grade: 90},
+			mockExpectedEntities: mockExpected{error: assert.AnError},
+			expectedCode:         http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+			studentHandler := NewStudentHandlerV2(mockRepo, nil, queryParamKey, v2Routes())
+			if tc.mockArguments != nil {
+				mockRepo.EXPECT().Add(gomock.Any(), *tc.mockArguments).Return(tc.mockExpectedEntities.result, tc.mockExpectedEntities.error)
+			}
+			if tc.expectedCode == http.StatusCreated {
+				mockRepo.EXPECT().GetByID(gomock.Any(), tc.mockExpectedEntities.result).Return(tc.result, nil)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/v2/student", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			// act
+			studentHandler.Create(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusCreated {
+				assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+				return
+			}
+			assert.Equal(t, tc.expectedLocation, rr.Header().Get("Location"))
+
+			var actual struct {
+				Data models.StudentRequest `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, tc.result, actual.Data)
+		})
+	}
+}
+
+func TestStudentHandlerV2_Get(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	type mockExpected struct {
+		result models.StudentRequest
+		error  error
+	}
+	tests := []struct {
+		description          string
+		mockExpectedEntities mockExpected
+		expectedCode         int
+	}{
+		{
+			description:          "Student exists",
+			mockExpectedEntities: mockExpected{result: models.StudentRequest{StudentID: 1, StudentName: "Test", Grade: 90}},
+			expectedCode:         http.StatusOK,
+		},
+		{
+			description:          "Student not found",
+			mockExpectedEntities: mockExpected{error: pkgErrors.ErrNotFound},
+			expectedCode:         http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+			studentHandler := NewStudentHandlerV2(mockRepo, nil, queryParamKey, v2Routes())
+			mockRepo.EXPECT().GetByIDWithFields(gomock.Any(), int64(1), gomock.Nil()).Return(tc.mockExpectedEntities.result, tc.mockExpectedEntities.error)
+
+			req, err := http.NewRequest(http.MethodGet, "/v2/student/1", nil)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{queryParamKey: "1"})
+			rr := httptest.NewRecorder()
+			// act
+			studentHandler.Get(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+				return
+			}
+
+			var actual struct {
+				Data models.StudentRequest `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, tc.mockExpectedEntities.result, actual.Data)
+		})
+	}
+}
+
+func TestStudentHandlerV2_List(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	students := []models.StudentRequest{
+		{StudentID: 3, StudentName: "C", Grade: 70},
+		{StudentID: 4, StudentName: "D", Grade: 60},
+	}
+	tests := []struct {
+		description   string
+		query         string
+		mockArguments []int64
+		expectedCode  int
+		expectedMeta  models.ListMeta
+		expectedLinks models.ListLinks
+	}{
+		{
+			description:   "Middle page",
+			query:         "?limit=2&offset=2",
+			mockArguments: []int64{2, 2},
+			expectedCode:  http.StatusOK,
+			expectedMeta:  models.ListMeta{Total: 6, Count: 2, Limit: 2, Offset: 2},
+			expectedLinks: models.ListLinks{
+				Self: "/v2/student?limit=2&offset=2",
+				Next: "/v2/student?limit=2&offset=4",
+				Prev: "/v2/student?limit=2&offset=0",
+			},
+		},
+		{
+			description:   "Default page",
+			mockArguments: []int64{defaultPageLimit, 0},
+			expectedCode:  http.StatusOK,
+			expectedMeta:  models.ListMeta{Total: 2, Count: 2, Limit: defaultPageLimit, Offset: 0},
+			expectedLinks: models.ListLinks{Self: "/v2/student?limit=20&offset=0"},
+		},
+		{
+			description:  "Limit too large",
+			query:        "?limit=1000",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Negative offset",
+			query:        "?offset=-1",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+			studentHandler := NewStudentHandlerV2(mockRepo, nil, queryParamKey, v2Routes())
+			if tc.mockArguments != nil {
+				mockRepo.EXPECT().List(gomock.Any(), tc.mockArguments[0], tc.mockArguments[1], gomock.Nil()).Return(students, nil)
+				mockRepo.EXPECT().Count(gomock.Any()).Return(tc.expectedMeta.Total, nil)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/v2/student"+tc.query, nil)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			// act
+			studentHandler.List(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+				return
+			}
+
+			var actual struct {
+				Data  []models.StudentRequest `json:"data"`
+				Meta  models.ListMeta         `json:"meta"`
+				Links models.ListLinks        `json:"links"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, students, actual.Data)
+			assert.Equal(t, tc.expectedMeta, actual.Meta)
+			assert.Equal(t, tc.expectedLinks, actual.Links)
+		})
+	}
+}
+
+func TestStudentHandlerV2_Update(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	student := models.StudentRequest{StudentID: 1, StudentName: "Renamed", Grade: 95}
+	tests := []struct {
+		description       string
+		mockExpectedError error
+		expectedCode      int
+	}{
+		{
+			description:  "Returns the updated student",
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:       "Student not found",
+			mockExpectedError: pkgErrors.ErrNotFound,
+			expectedCode:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			jsonData, err := json.Marshal(student)
+			require.NoError(t, err)
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+			studentHandler := NewStudentHandlerV2(mockRepo, nil, queryParamKey, v2Routes())
+			mockRepo.EXPECT().UpdateProfile(gomock.Any(), student.StudentID, student).Return(tc.mockExpectedError)
+			if tc.mockExpectedError == nil {
+				mockRepo.EXPECT().GetByID(gomock.Any(), student.StudentID).Return(student, nil)
+			}
+
+			req, err := http.NewRequest(http.MethodPut, "/v2/student", bytes.NewReader(jsonData))
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			// act
+			studentHandler.Update(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+				return
+			}
+
+			var actual struct {
+				Data models.StudentRequest `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, student, actual.Data)
+		})
+	}
+}
+
+func TestStudentHandlerV2_Delete(t *testing.T) {
+	t.Parallel()
+	var (
+		queryParamKey = "id"
+	)
+	tests := []struct {
+		description       string
+		mockExpectedError error
+		expectedCode      int
+	}{
+		{
+			description:  "Deleted",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			description:       "Student not found",
+			mockExpectedError: pkgErrors.ErrNotFound,
+			expectedCode:      http.StatusNotFound,
+		},
+		{
+			description:       "Unable to delete",
+			mockExpectedError: assert.AnError,
+			expectedCode:      http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+			studentHandler := NewStudentHandlerV2(mockRepo, nil, queryParamKey, v2Routes())
+			mockRepo.EXPECT().Delete(gomock.Any(), int64(1)).Return(tc.mockExpectedError)
+
+			req, err := http.NewRequest(http.MethodDelete, "/v2/student/1", nil)
+			require.NoError(t, err)
+			req = mux.SetURLVars(req, map[string]string{queryParamKey: "1"})
+			rr := httptest.NewRecorder()
+			// act
+			studentHandler.Delete(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code == http.StatusNoContent {
+				assert.Empty(t, rr.Body.String())
+				return
+			}
+			assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestStudentHandlerV2_SetStatus(t *testing.T) {
+	t.Parallel()
+	changedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	active := models.StudentActive
+	tests := []struct {
+		description  string
+		body         string
+		mock         func(m *mock_repository.MockStudentPgRepo)
+		expectedCode int
+	}{
+		{
+			description: "Graduated",
+			body:        `{"to_status": "graduated", "reason": "Completed the program"}`,
+			mock: func(m *mock_repository.MockStudentPgRepo) {
+				m.EXPECT().SetStatus(gomock.Any(), int64(7), models.StudentGraduated, "Completed the program").
+					Return(models.StudentStatusChange{
+						StudentID: 7, FromStatus: &active, ToStatus: models.StudentGraduated,
+						Reason: "Completed the program", ChangedAt: changedAt,
+					}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:  "Unknown status",
+			body:         `{"to_status": "expelled"}`,
+			mock:         func(m *mock_repository.MockStudentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "Reason too long",
+			body:         `{"to_status": "withdrawn", "reason": "` + strings.Repeat("a", maxStatusReason+1) + `"}`,
+			mock:         func(m *mock_repository.MockStudentPgRepo) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description: "Transition not allowed",
+			body:        `{"to_status": "applicant"}`,
+			mock: func(m *mock_repository.MockStudentPgRepo) {
+				m.EXPECT().SetStatus(gomock.Any(), int64(7), models.StudentApplicant, "").
+					Return(models.StudentStatusChange{}, fmt.Errorf("%w: from graduated to applicant", pkgErrors.ErrStatusTransition))
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			description: "Student not found",
+			body:        `{"to_status": "active"}`,
+			mock: func(m *mock_repository.MockStudentPgRepo) {
+				m.EXPECT().SetStatus(gomock.Any(), int64(7), models.StudentActive, "").
+					Return(models.StudentStatusChange{}, pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+			tc.mock(mockRepo)
+			req := httptest.NewRequest(http.MethodPut, "/v2/student/7/status", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			NewRouter(mockRepo, nil, NewHealthHandler(nil, ""), "id").ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestStudentHandlerV2_StatusHistory(t *testing.T) {
+	t.Parallel()
+	// arrange
+	createdAt := time.Date(2026, 9, 1, 8, 0, 0, 0, time.UTC)
+	applicant := models.StudentApplicant
+	history := []models.StudentStatusChange{
+		{StudentID: 7, ToStatus: models.StudentApplicant, ChangedAt: createdAt},
+		{StudentID: 7, FromStatus: &applicant, ToStatus: models.StudentActive, Reason: "Admitted", ChangedAt: createdAt.AddDate(0, 0, 7)},
+	}
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+	mockRepo.EXPECT().StatusHistory(gomock.Any(), int64(7)).Return(history, nil)
+	req := httptest.NewRequest(http.MethodGet, "/v2/student/7/status_history", nil)
+	rr := httptest.NewRecorder()
+	// act
+	NewRouter(mockRepo, nil, NewHealthHandler(nil, ""), "id").ServeHTTP(rr, req)
+	// assert
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"data": [
+		{"student_id": 7, "from_status": null, "to_status": "applicant", "reason": "", "changed_at": "2026-09-01T08:00:00Z"},
+		{"student_id": 7, "from_status": "applicant", "to_status": "active", "reason": "Admitted", "changed_at": "2026-09-08T08:00:00Z"}
+	]}`, rr.Body.String())
+}
+
+func TestWriteStorageError_HidesDatabaseErrors(t *testing.T) {
+	t.Parallel()
+	// arrange
+	err := fmt.Errorf("add student: %w", &pgconn.PgError{
+		Code:           "23505",
+		Message:        `duplicate key value violates unique constraint "student_email_key"`,
+		Detail:         "Key (email)=(jane.doe@example.com) already exists.",
+		ConstraintName: "student_email_key",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v2/student", nil)
+	rr := httptest.NewRecorder()
+	// act
+	writeStorageError(rr, req, err, "student")
+	// assert
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), `"detail":"failed to access student"`)
+	assert.NotContains(t, rr.Body.String(), "jane.doe")
+	assert.NotContains(t, rr.Body.String(), "student_email_key")
+}
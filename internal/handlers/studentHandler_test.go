@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -77,6 +78,7 @@ func TestStudentHandler_Get(t *testing.T) {
 				assert.Equal(t, tc.expectedHTTPErrorResponse, rr.Body.String())
 				return
 			}
+			assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
 			var actual models.StudentRequest
 			err = json.Unmarshal(rr.Body.Bytes(), &actual)
 			require.NoError(t, err)
@@ -154,6 +156,25 @@ func TestStudentHandler_Create(t *testing.T) {
 	}
 }
 
+func TestStudentHandler_CreateIgnoresProfile(t *testing.T) {
+	t.Parallel()
+	// arrange
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockStudentPgRepo(ctrl)
+	studentHandler := NewStudentHandler(mockRepo, "id")
+	mockRepo.EXPECT().Add(gomock.Any(), models.StudentRequest{StudentName: "Test", Grade: 90}).Return(int64(1), nil)
+	body := `{"student_name": "Test", "grade": 90, "status": "graduated", "email": "not an address",
+		"date_of_birth": "04/02/2010", "student_number": "S 1"}`
+	req, err := http.NewRequest(http.MethodPost, "/student", strings.NewReader(body))
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	// act
+	studentHandler.Create(rr, req)
+	// assert
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"student_id": 1, "student_name": "Test", "grade": 90}`, rr.Body.String())
+}
+
 func TestStudentHandler_Delete(t *testing.T) {
 	t.Parallel()
 	var (
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// classParamKey is the path variable of the class in teacher assignment routes.
+const classParamKey = "class_id"
+
+// TeacherHandler handles teacher-related HTTP requests and the assignment of teachers to classes.
+type TeacherHandler struct {
+	teacherStorage repository.TeacherPgRepo
+	queryParamKey  string
+}
+
+// NewTeacherHandler creates a new TeacherHandler with the given teacher storage service.
+func NewTeacherHandler(teacherStorage repository.TeacherPgRepo, queryParamKey string) *TeacherHandler {
+	return &TeacherHandler{
+		teacherStorage: teacherStorage,
+		queryParamKey:  queryParamKey,
+	}
+}
+
+func (h *TeacherHandler) Create(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	var teacherReq models.TeacherRequest
+	if !decodeBody(w, req, &teacherReq) {
+		return
+	}
+
+	if teacherReq.TeacherName == "" {
+		http.Error(w, "Failed Teacher name is empty", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+
+	teacherReq.TeacherID, err = h.teacherStorage.Add(req.Context(), teacherReq)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to add teacher: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, teacherReq)
+}
+
+func (h *TeacherHandler) Update(w http.ResponseWriter, req *http.Request) {
+	var teacher models.TeacherRequest
+	if !decodeBody(w, req, &teacher) {
+		return
+	}
+
+	if teacher.TeacherName == "" {
+		http.Error(w, "Failed Teacher name is empty", http.StatusBadRequest)
+		return
+	}
+
+	err := h.teacherStorage.Update(req.Context(), teacher.TeacherID, teacher)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrNotFound) {
+			http.Error(w, "Teacher with such teacher_id not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to update teacherByID: %v", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	_, err = w.Write([]byte("Successfully Updated Teacher Info"))
+	if err != nil {
+		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+	}
+}
+
+func (h *TeacherHandler) Get(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	teacherID, ok := plainPathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	teacher, err := h.teacherStorage.GetByID(req.Context(), teacherID)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrNotFound) {
+			http.Error(w, "Teacher not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to get record by TeacherByID: %v", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, teacher)
+}
+
+func (h *TeacherHandler) Delete(w http.ResponseWriter, req *http.Request) {
+	teacherID, ok := plainPathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	err := h.teacherStorage.Delete(req.Context(), teacherID)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrNotFound) {
+			http.Error(w, "Teacher with such teacher_id not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to delete teacherByID: %v", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	_, err = w.Write([]byte("Successfully Deleted Teacher Info"))
+	if err != nil {
+		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+	}
+}
+
+// GetClasses lists the classes of the teacher in the path, with the teacher's role in each.
+func (h *TeacherHandler) GetClasses(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	teacherID, ok := plainPathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.teacherStorage.GetClassesByTeacherID(req.Context(), teacherID)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrNotFound) {
+			http.Error(w, "Teacher not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to get classes of teacher: %v", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, assignments)
+}
+
+// AssignClass assigns the teacher in the path to the class named in the body, with a lead or assistant role.
+func (h *TeacherHandler) AssignClass(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	teacherID, ok := plainPathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var assignment models.TeacherAssignment
+	if !decodeBody(w, req, &assignment) {
+		return
+	}
+
+	if assignment.ClassName == "" {
+		http.Error(w, "Failed Class name is empty", http.StatusBadRequest)
+		return
+	}
+
+	if assignment.Role != models.TeacherRoleLead && assignment.Role != models.TeacherRoleAssistant {
+		http.Error(
+			w,
+			fmt.Sprintf("Unknown role %q, expected %s or %s", assignment.Role, models.TeacherRoleLead, models.TeacherRoleAssistant),
+			http.StatusBadRequest,
+		)
+
+		return
+	}
+
+	assignment.TeacherID = teacherID
+
+	assignment, err := h.teacherStorage.AssignClass(req.Context(), assignment)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrReferenceNotFound) {
+			http.Error(w, "Teacher with such teacher_id not found", http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, pkgErrors.ErrConflict) {
+			http.Error(w, "Class already has a lead teacher", http.StatusConflict)
+			return
+		}
+
+		if errors.Is(err, pkgErrors.ErrTeacherBooked) {
+			http.Error(w, "Teacher teaches another class at the same time", http.StatusConflict)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to assign teacher to class: %v", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, assignment)
+}
+
+func (h *TeacherHandler) UnassignClass(w http.ResponseWriter, req *http.Request) {
+	teacherID, ok := plainPathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	classID, ok := plainPathID(w, req, classParamKey)
+	if !ok {
+		return
+	}
+
+	err := h.teacherStorage.UnassignClass(req.Context(), teacherID, classID)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrNotFound) {
+			http.Error(w, "Teacher is not assigned to such class", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to unassign teacher from class: %v", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	_, err = w.Write([]byte("Successfully Unassigned Teacher"))
+	if err != nil {
+		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+	}
+}
+
+// GetTeachersByClass lists the teachers of the class in the path, lead first.
+func (h *TeacherHandler) GetTeachersByClass(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	classID, ok := plainPathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.teacherStorage.GetTeachersByClassID(req.Context(), classID)
+	if err != nil {
+		if errors.Is(err, pkgErrors.ErrNotFound) {
+			http.Error(w, "Class not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("Failed to get teachers of class: %v", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, assignments)
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTeacherHandler_Get(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description               string
+		path                      string
+		mock                      func(m *mock_repository.MockTeacherPgRepo)
+		expectedCode              int
+		expectedTeacher           models.TeacherRequest
+		expectedHTTPErrorResponse string
+	}{
+		{
+			description: "Teacher exists",
+			path:        "/teacher/3",
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				m.EXPECT().GetByID(gomock.Any(), int64(3)).Return(models.TeacherRequest{TeacherID: 3, TeacherName: "Smith"}, nil)
+			},
+			expectedCode:    http.StatusOK,
+			expectedTeacher: models.TeacherRequest{TeacherID: 3, TeacherName: "Smith"},
+		},
+		{
+			description: "Teacher not found",
+			path:        "/v1/teacher/4",
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				m.EXPECT().GetByID(gomock.Any(), int64(4)).Return(models.TeacherRequest{}, pkgErrors.ErrNotFound)
+			},
+			expectedCode:              http.StatusNotFound,
+			expectedHTTPErrorResponse: "Teacher not found\n",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockTeacherPgRepo(ctrl)
+			tc.mock(mockRepo)
+			router := NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithTeachers(mockRepo))
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				assert.Equal(t, tc.expectedHTTPErrorResponse, rr.Body.String())
+				return
+			}
+			var actual models.TeacherRequest
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, tc.expectedTeacher, actual)
+		})
+	}
+}
+
+func TestTeacherHandler_AssignClass(t *testing.T) {
+	t.Parallel()
+	assignment := models.TeacherAssignment{ClassName: "Math", TeacherID: 3, Role: models.TeacherRoleLead}
+	tests := []struct {
+		description               string
+		body                      models.TeacherAssignment
+		mock                      func(m *mock_repository.MockTeacherPgRepo)
+		expectedCode              int
+		expectedHTTPErrorResponse string
+	}{
+		{
+			description: "Assigned",
+			body:        models.TeacherAssignment{ClassName: "Math", Role: models.TeacherRoleLead},
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				stored := assignment
+				stored.ClassID, stored.TeacherName = 7, "Smith"
+				m.EXPECT().AssignClass(gomock.Any(), assignment).Return(stored, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description:               "Unknown role",
+			body:                      models.TeacherAssignment{ClassName: "Math", Role: "substitute"},
+			mock:                      func(m *mock_repository.MockTeacherPgRepo) {},
+			expectedCode:              http.StatusBadRequest,
+			expectedHTTPErrorResponse: "Unknown role \"substitute\", expected lead or assistant\n",
+		},
+		{
+			description:               "Empty class name",
+			body:                      models.TeacherAssignment{Role: models.TeacherRoleAssistant},
+			mock:                      func(m *mock_repository.MockTeacherPgRepo) {},
+			expectedCode:              http.StatusBadRequest,
+			expectedHTTPErrorResponse: "Failed Class name is empty\n",
+		},
+		{
+			description: "Class already has a lead",
+			body:        models.TeacherAssignment{ClassName: "Math", Role: models.TeacherRoleLead},
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				m.EXPECT().AssignClass(gomock.Any(), assignment).Return(models.TeacherAssignment{}, pkgErrors.ErrConflict)
+			},
+			expectedCode:              http.StatusConflict,
+			expectedHTTPErrorResponse: "Class already has a lead teacher\n",
+		},
+		{
+			description: "Teacher not found",
+			body:        models.TeacherAssignment{ClassName: "Math", Role: models.TeacherRoleLead},
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				m.EXPECT().AssignClass(gomock.Any(), assignment).Return(models.TeacherAssignment{}, pkgErrors.ErrReferenceNotFound)
+			},
+			expectedCode:              http.StatusNotFound,
+			expectedHTTPErrorResponse: "Teacher with such teacher_id not found\n",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockTeacherPgRepo(ctrl)
+			tc.mock(mockRepo)
+			router := NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithTeachers(mockRepo))
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPut, "/teacher/3/classes", bytes.NewReader(body))
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				assert.Equal(t, tc.expectedHTTPErrorResponse, rr.Body.String())
+				return
+			}
+			var actual models.TeacherAssignment
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, int64(7), actual.ClassID)
+			assert.Equal(t, "Smith", actual.TeacherName)
+		})
+	}
+}
+
+func TestTeacherHandler_ClassRoutes(t *testing.T) {
+	t.Parallel()
+	assignments := []models.TeacherAssignment{
+		{ClassID: 7, ClassName: "Math", TeacherID: 3, TeacherName: "Smith", Role: models.TeacherRoleLead},
+		{ClassID: 7, ClassName: "Math", TeacherID: 4, TeacherName: "Jones", Role: models.TeacherRoleAssistant},
+	}
+	tests := []struct {
+		description  string
+		method       string
+		path         string
+		mock         func(m *mock_repository.MockTeacherPgRepo)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			description: "Teachers of a class",
+			method:      http.MethodGet,
+			path:        "/class/7/teachers",
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				m.EXPECT().GetTeachersByClassID(gomock.Any(), int64(7)).Return(assignments, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"teacher_name":"Jones"`,
+		},
+		{
+			description: "Class without teachers",
+			method:      http.MethodGet,
+			path:        "/class/8/teachers",
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				m.EXPECT().GetTeachersByClassID(gomock.Any(), int64(8)).Return([]models.TeacherAssignment{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "[]",
+		},
+		{
+			description: "Class not found",
+			method:      http.MethodGet,
+			path:        "/class/9/teachers",
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				m.EXPECT().GetTeachersByClassID(gomock.Any(), int64(9)).Return(nil, pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "Class not found\n",
+		},
+		{
+			description: "Classes of a teacher",
+			method:      http.MethodGet,
+			path:        "/v1/teacher/3/classes",
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				m.EXPECT().GetClassesByTeacherID(gomock.Any(), int64(3)).Return(assignments[:1], nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"role":"lead"`,
+		},
+		{
+			description: "Unassign a class",
+			method:      http.MethodDelete,
+			path:        "/teacher/3/classes/7",
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				m.EXPECT().UnassignClass(gomock.Any(), int64(3), int64(7)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "Successfully Unassigned Teacher",
+		},
+		{
+			description: "Unassign a class the teacher does not teach",
+			method:      http.MethodDelete,
+			path:        "/teacher/3/classes/8",
+			mock: func(m *mock_repository.MockTeacherPgRepo) {
+				m.EXPECT().UnassignClass(gomock.Any(), int64(3), int64(8)).Return(pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+			expectedBody: "Teacher is not assigned to such class\n",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockTeacherPgRepo(ctrl)
+			tc.mock(mockRepo)
+			router := NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithTeachers(mockRepo))
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			assert.True(t, strings.Contains(rr.Body.String(), tc.expectedBody), rr.Body.String())
+		})
+	}
+}
+
+func TestRouter_TeacherRoutesNeedStorage(t *testing.T) {
+	t.Parallel()
+	// arrange
+	router := NewRouter(nil, nil, NewHealthHandler(nil, ""), "id")
+	req := httptest.NewRequest(http.MethodGet, "/teacher/3", nil)
+	rr := httptest.NewRecorder()
+	// act
+	router.ServeHTTP(rr, req)
+	// assert
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
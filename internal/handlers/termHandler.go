@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	"CRUD_Go_Backend/internal/repository"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// termStatusOrder ranks the term statuses. A term can only move to a status of equal or higher rank.
+var termStatusOrder = map[string]int{
+	models.TermStatusPlanned: 0,
+	models.TermStatusOpen:    1,
+	models.TermStatusClosed:  2,
+}
+
+// TermHandler serves the v2 academic term endpoints.
+type TermHandler struct {
+	termStorage   repository.AcademicTermPgRepo
+	queryParamKey string
+	links         *links
+}
+
+// NewTermHandler creates a new TermHandler with the given academic term storage.
+// Links are built from the named routes of router.
+func NewTermHandler(termStorage repository.AcademicTermPgRepo, queryParamKey string, router *mux.Router) *TermHandler {
+	return &TermHandler{
+		termStorage:   termStorage,
+		queryParamKey: queryParamKey,
+		links:         newLinks(router, queryParamKey),
+	}
+}
+
+func (h *TermHandler) Create(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	var term models.AcademicTerm
+	if !decodeBody(w, req, &term) {
+		return
+	}
+
+	if term.Status == "" {
+		term.Status = models.TermStatusPlanned
+	}
+
+	if !validateTerm(w, req, term) {
+		return
+	}
+
+	var err error
+
+	term.TermID, err = h.termStorage.Add(req.Context(), term)
+	if err != nil {
+		writeStorageError(w, req, err, "academic_term")
+		return
+	}
+
+	w.Header().Set("Location", h.links.term(term.TermID))
+	writeResponse(w, responseCodec, http.StatusCreated, models.Envelope{Data: term})
+}
+
+func (h *TermHandler) Get(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	termID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	term, err := h.termStorage.GetByID(req.Context(), termID)
+	if err != nil {
+		writeStorageError(w, req, err, "academic_term")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: term})
+}
+
+// GetCurrent returns the term whose dates cover today.
+func (h *TermHandler) GetCurrent(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	term, err := h.termStorage.GetCurrent(req.Context())
+	if err != nil {
+		writeStorageError(w, req, err, "current academic_term")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: term})
+}
+
+func (h *TermHandler) List(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	terms, err := h.termStorage.List(req.Context())
+	if err != nil {
+		writeStorageError(w, req, err, "academic_term")
+		return
+	}
+
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: terms})
+}
+
+// Update replaces the term in the path. Statuses only move forward, and a closed term cannot be changed.
+func (h *TermHandler) Update(w http.ResponseWriter, req *http.Request) {
+	responseCodec, ok := negotiate(w, req)
+	if !ok {
+		return
+	}
+
+	termID, ok := pathID(w, req, h.queryParamKey)
+	if !ok {
+		return
+	}
+
+	var term models.AcademicTerm
+	if !decodeBody(w, req, &term) {
+		return
+	}
+
+	if !validateTerm(w, req, term) {
+		return
+	}
+
+	current, err := h.termStorage.GetByID(req.Context(), termID)
+	if err != nil {
+		writeStorageError(w, req, err, "academic_term")
+		return
+	}
+
+	if termStatusOrder[term.Status] < termStatusOrder[current.Status] {
+		problem.Write(w, req, http.StatusConflict, fmt.Sprintf("academic_term cannot go from %s back to %s", current.Status, term.Status))
+		return
+	}
+
+	if err := h.termStorage.Update(req.Context(), termID, term); err != nil {
+		writeStorageError(w, req, err, "academic_term")
+		return
+	}
+
+	term.TermID = termID
+	writeResponse(w, responseCodec, http.StatusOK, models.Envelope{Data: term})
+}
+
+// validateTerm checks the name, dates and status of term. On failure it writes 400 and returns false.
+func validateTerm(w http.ResponseWriter, req *http.Request, term models.AcademicTerm) bool {
+	if term.Name == "" {
+		problem.Write(w, req, http.StatusBadRequest, "name must not be empty")
+		return false
+	}
+
+	if _, ok := termStatusOrder[term.Status]; !ok {
+		problem.Write(w, req, http.StatusBadRequest, fmt.Sprintf(
+			"status must be %s, %s or %s", models.TermStatusPlanned, models.TermStatusOpen, models.TermStatusClosed,
+		))
+
+		return false
+	}
+
+	start, startErr := time.Parse(models.DateLayout, term.StartDate)
+	end, endErr := time.Parse(models.DateLayout, term.EndDate)
+
+	if startErr != nil || endErr != nil {
+		problem.Write(w, req, http.StatusBadRequest, "start_date and end_date must be dates formatted as YYYY-MM-DD")
+		return false
+	}
+
+	if end.Before(start) {
+		problem.Write(w, req, http.StatusBadRequest, "end_date must not be before start_date")
+		return false
+	}
+
+	return true
+}
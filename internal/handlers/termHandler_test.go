@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/problem"
+	mock_repository "CRUD_Go_Backend/internal/repository/mocks"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTermHandler_Create(t *testing.T) {
+	t.Parallel()
+	term := models.AcademicTerm{Name: "Fall 2026", StartDate: "2026-09-01", EndDate: "2026-12-20", Status: models.TermStatusPlanned}
+	tests := []struct {
+		description      string
+		body             string
+		mock             func(m *mock_repository.MockAcademicTermPgRepo)
+		expectedCode     int
+		expectedLocation string
+		expectedDetail   string
+	}{
+		{
+			description: "Created as planned",
+			body:        `{"name": "Fall 2026", "start_date": "2026-09-01", "end_date": "2026-12-20"}`,
+			mock: func(m *mock_repository.MockAcademicTermPgRepo) {
+				m.EXPECT().Add(gomock.Any(), term).Return(int64(4), nil)
+			},
+			expectedCode:     http.StatusCreated,
+			expectedLocation: "/v2/term/4",
+		},
+		{
+			description:    "Invalid date",
+			body:           `{"name": "Fall 2026", "start_date": "2026-09-01", "end_date": "20 Dec 2026"}`,
+			mock:           func(m *mock_repository.MockAcademicTermPgRepo) {},
+			expectedCode:   http.StatusBadRequest,
+			expectedDetail: "start_date and end_date must be dates formatted as YYYY-MM-DD",
+		},
+		{
+			description:    "Ends before it starts",
+			body:           `{"name": "Fall 2026", "start_date": "2026-09-01", "end_date": "2026-08-01"}`,
+			mock:           func(m *mock_repository.MockAcademicTermPgRepo) {},
+			expectedCode:   http.StatusBadRequest,
+			expectedDetail: "end_date must not be before start_date",
+		},
+		{
+			description: "Overlaps another term",
+			body:        `{"name": "Fall 2026", "start_date": "2026-09-01", "end_date": "2026-12-20"}`,
+			mock: func(m *mock_repository.MockAcademicTermPgRepo) {
+				m.EXPECT().Add(gomock.Any(), term).Return(int64(-1), pkgErrors.ErrConflict)
+			},
+			expectedCode:   http.StatusConflict,
+			expectedDetail: "academic_term conflicts with an existing record",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockAcademicTermPgRepo(ctrl)
+			tc.mock(mockRepo)
+			router := NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithTerms(mockRepo))
+			req := httptest.NewRequest(http.MethodPost, "/v2/term", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusCreated {
+				var actual problem.Details
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+				assert.Equal(t, tc.expectedDetail, actual.Detail)
+				return
+			}
+			assert.Equal(t, tc.expectedLocation, rr.Header().Get("Location"))
+		})
+	}
+}
+
+func TestTermHandler_Update(t *testing.T) {
+	t.Parallel()
+	open := models.AcademicTerm{TermID: 4, Name: "Fall 2026", StartDate: "2026-09-01", EndDate: "2026-12-20", Status: models.TermStatusOpen}
+	tests := []struct {
+		description  string
+		status       string
+		mock         func(m *mock_repository.MockAcademicTermPgRepo)
+		expectedCode int
+	}{
+		{
+			description: "Closed",
+			status:      models.TermStatusClosed,
+			mock: func(m *mock_repository.MockAcademicTermPgRepo) {
+				m.EXPECT().GetByID(gomock.Any(), int64(4)).Return(open, nil)
+				m.EXPECT().Update(gomock.Any(), int64(4), gomock.Any()).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			description: "Moved back to planned",
+			status:      models.TermStatusPlanned,
+			mock: func(m *mock_repository.MockAcademicTermPgRepo) {
+				m.EXPECT().GetByID(gomock.Any(), int64(4)).Return(open, nil)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			description: "Already closed",
+			status:      models.TermStatusClosed,
+			mock: func(m *mock_repository.MockAcademicTermPgRepo) {
+				m.EXPECT().GetByID(gomock.Any(), int64(4)).Return(open, nil)
+				m.EXPECT().Update(gomock.Any(), int64(4), gomock.Any()).Return(pkgErrors.ErrTermClosed)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			description: "Term not found",
+			status:      models.TermStatusOpen,
+			mock: func(m *mock_repository.MockAcademicTermPgRepo) {
+				m.EXPECT().GetByID(gomock.Any(), int64(4)).Return(models.AcademicTerm{}, pkgErrors.ErrNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockAcademicTermPgRepo(ctrl)
+			tc.mock(mockRepo)
+			router := NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithTerms(mockRepo))
+			body := `{"name": "Fall 2026", "start_date": "2026-09-01", "end_date": "2026-12-20", "status": "` + tc.status + `"}`
+			req := httptest.NewRequest(http.MethodPut, "/v2/term/4", strings.NewReader(body))
+			rr := httptest.NewRecorder()
+			// act
+			router.ServeHTTP(rr, req)
+			// assert
+			require.Equal(t, tc.expectedCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+				return
+			}
+			var actual struct {
+				Data models.AcademicTerm `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, tc.status, actual.Data.Status)
+		})
+	}
+}
+
+func TestTermHandler_GetCurrent(t *testing.T) {
+	t.Parallel()
+	// arrange
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockAcademicTermPgRepo(ctrl)
+	mockRepo.EXPECT().GetCurrent(gomock.Any()).Return(models.AcademicTerm{}, pkgErrors.ErrNotFound)
+	router := NewRouter(nil, nil, NewHealthHandler(nil, ""), "id", WithTerms(mockRepo))
+	req := httptest.NewRequest(http.MethodGet, "/v2/term/current", nil)
+	rr := httptest.NewRecorder()
+	// act
+	router.ServeHTTP(rr, req)
+	// assert
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/codec"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// transcriptPDF renders transcripts as printable PDF documents. It is only offered by the transcript endpoint.
+var transcriptPDF codec.Codec = transcriptPDFCodec{}
+
+type transcriptPDFCodec struct{}
+
+func (transcriptPDFCodec) Name() string        { return "PDF" }
+func (transcriptPDFCodec) ContentType() string { return "application/pdf" }
+
+func (transcriptPDFCodec) Marshal(v interface{}) ([]byte, error) {
+	transcript, ok := v.(models.Transcript)
+	if !ok {
+		return nil, fmt.Errorf("cannot render %T as PDF", v)
+	}
+
+	return renderTranscriptPDF(transcript)
+}
+
+func (transcriptPDFCodec) Unmarshal([]byte, interface{}) error {
+	return errors.New("PDF request bodies are not supported")
+}
+
+// transcriptColumns are the widths in millimetres and headings of the class table.
+var transcriptColumns = []struct {
+	width   float64
+	heading string
+	align   string
+}{
+	{100, "Class", "L"},
+	{25, "Credits", "R"},
+	{25, "Score", "R"},
+	{20, "Grade", "C"},
+}
+
+// renderTranscriptPDF lays out transcript on A4 pages with the standard Helvetica font.
+func renderTranscriptPDF(transcript models.Transcript) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Transcript of student %d", transcript.StudentID), true)
+	pdf.SetCreator(serverName, true)
+
+	// The core fonts are encoded as cp1252, so class and term names are translated from UTF-8.
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, fmt.Sprintf("Transcript of student %d", transcript.StudentID), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	if len(transcript.Terms) == 0 {
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.CellFormat(0, 7, "No enrollments.", "", 1, "L", false, 0, "")
+	}
+
+	for _, term := range transcript.Terms {
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, 8, tr(fmt.Sprintf("%s (%s to %s, %s)", term.Name, term.StartDate, term.EndDate, term.Status)),
+			"", 1, "L", false, 0, "")
+
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetFillColor(230, 230, 230)
+
+		for _, column := range transcriptColumns {
+			pdf.CellFormat(column.width, 7, column.heading, "1", 0, column.align, true, 0, "")
+		}
+
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 10)
+
+		for _, class := range term.Classes {
+			score, letter := "-", "-"
+			if class.Score != nil {
+				score, letter = formatNumber(*class.Score), class.Letter
+			}
+
+			for i, value := range []string{tr(class.ClassName), formatNumber(class.Credits), score, tr(letter)} {
+				pdf.CellFormat(transcriptColumns[i].width, 7, value, "1", 0, transcriptColumns[i].align, false, 0, "")
+			}
+
+			pdf.Ln(-1)
+		}
+
+		pdf.SetFont("Helvetica", "I", 10)
+		pdf.CellFormat(0, 7, "Term GPA: "+formatGPA(term.GPA, term.Credits), "", 1, "R", false, 0, "")
+		pdf.Ln(3)
+	}
+
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, 8, "Cumulative GPA: "+formatGPA(transcript.GPA, transcript.Credits), "", 1, "L", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatGPA(gpa *float64, credits float64) string {
+	if gpa == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.2f over %s credits", *gpa, formatNumber(credits))
+}
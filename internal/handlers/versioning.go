@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/auth"
+	"CRUD_Go_Backend/internal/pkg/metrics"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Client labels of the API version metric for callers that are not API keys.
+const (
+	anonymousClient = "anonymous"
+	userClient      = "user"
+)
+
+// Deprecation announces that an API version is deprecated and, when Sunset is set, when it will be removed.
+type Deprecation struct {
+	Since  time.Time
+	Sunset time.Time
+	// Link points at migration notes.
+	Link string
+}
+
+// WithDeprecation marks every route of version (for example "v1") as deprecated.
+func WithDeprecation(version string, deprecation Deprecation) RouterOption {
+	return func(o *routerOptions) {
+		if o.deprecations == nil {
+			o.deprecations = make(map[string]Deprecation)
+		}
+
+		o.deprecations[version] = deprecation
+	}
+}
+
+// requireFunc wraps a handler with a permission check.
+type requireFunc func(permission auth.Permission, owner auth.OwnerFunc, handler http.HandlerFunc) http.Handler
+
+// apiVersion is a group of routes with its own handler set, mounted under /<name>.
+type apiVersion struct {
+	name string
+	// legacy also mounts the routes without a prefix, for clients that predate versioning.
+	legacy   bool
+	register func(router *mux.Router, prefix string, require requireFunc)
+}
+
+// versionClient is the client label of the API version metric. API keys are issued by admins, so their
+// subjects are a bounded set; JWT subjects are not and share one label.
+func versionClient(principal auth.Principal) string {
+	switch principal.Method {
+	case auth.MethodAPIKey:
+		return principal.Subject
+	case auth.MethodJWT:
+		return userClient
+	default:
+		return anonymousClient
+	}
+}
+
+// versionMiddleware counts the requests of each client to version and sets the
+// Deprecation (RFC 9745), Sunset (RFC 8594) and Link headers when it is deprecated.
+func versionMiddleware(version string, deprecation *Deprecation) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			principal, _ := auth.PrincipalFromContext(req.Context())
+			metrics.ObserveAPIVersion(version, versionClient(principal), deprecation != nil)
+
+			if deprecation != nil {
+				// JWT subjects are not metric labels, so the users of a deprecated version are logged instead.
+				if principal.Method == auth.MethodJWT {
+					slog.InfoContext(req.Context(), "deprecated API version used", "version", version, "subject", principal.Subject)
+				}
+
+				w.Header().Set("Deprecation", fmt.Sprintf("@%d", deprecation.Since.Unix()))
+
+				if !deprecation.Sunset.IsZero() {
+					w.Header().Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+				}
+
+				if deprecation.Link != "" {
+					w.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"", deprecation.Link))
+				}
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"CRUD_Go_Backend/internal/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionClient(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		description string
+		principal   auth.Principal
+		expected    string
+	}{
+		{
+			description: "API key",
+			principal:   auth.Principal{Subject: "apikey:3", Name: "reporting", Method: auth.MethodAPIKey},
+			expected:    "apikey:3",
+		},
+		{
+			description: "JWT subjects share a label",
+			principal:   auth.Principal{Subject: "jane@example.com", Method: auth.MethodJWT},
+			expected:    userClient,
+		},
+		{
+			description: "Anonymous",
+			principal:   auth.Principal{},
+			expected:    anonymousClient,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// act
+			client := versionClient(tc.principal)
+			// assert
+			assert.Equal(t, tc.expected, client)
+		})
+	}
+}
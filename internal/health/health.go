@@ -0,0 +1,117 @@
+package health
+
+import (
+	"context"
+	"encoding/xml"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	defaultCheckTimeout = 2 * time.Second
+)
+
+// Check is a single dependency probe. Run must return a non-nil error when the dependency is unusable.
+type Check struct {
+	Name    string
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+// Result is the outcome of one Check.
+type Result struct {
+	Name      string  `json:"name" xml:"name"`
+	Status    string  `json:"status" xml:"status"`
+	LatencyMs float64 `json:"latency_ms" xml:"latency_ms"`
+	Error     string  `json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// Report aggregates the results of all checks.
+type Report struct {
+	XMLName  xml.Name `json:"-" xml:"health"`
+	Status   string   `json:"status" xml:"status"`
+	Draining bool     `json:"draining" xml:"draining"`
+	Checks   []Result `json:"checks" xml:"checks>check"`
+}
+
+// Checker runs the readiness checks and tracks whether the process is draining for shutdown.
+type Checker struct {
+	checks   []Check
+	draining atomic.Bool
+}
+
+// NewChecker creates a Checker for the given checks.
+func NewChecker(checks ...Check) *Checker {
+	return &Checker{checks: checks}
+}
+
+// SetDraining marks the process as shutting down, after which it is never ready again.
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+// Draining reports whether SetDraining has been called.
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Run executes all checks concurrently, each bounded by its own timeout.
+func (c *Checker) Run(ctx context.Context) Report {
+	results := make([]Result, len(c.checks))
+
+	var wg sync.WaitGroup
+
+	for i, check := range c.checks {
+		wg.Add(1)
+
+		go func(i int, check Check) {
+			defer wg.Done()
+
+			results[i] = runCheck(ctx, check)
+		}(i, check)
+	}
+
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Draining: c.Draining(), Checks: results}
+	if report.Draining {
+		report.Status = StatusDown
+	}
+
+	for _, result := range results {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+func runCheck(ctx context.Context, check Check) Result {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = defaultCheckTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(ctx)
+	result := Result{
+		Name:      check.Name,
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
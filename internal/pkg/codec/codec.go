@@ -0,0 +1,248 @@
+// Package codec encodes and decodes request and response bodies as JSON, XML, MessagePack or CBOR,
+// chosen from the Accept and Content-Type headers.
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec converts values to and from one media type.
+type Codec interface {
+	// Name is the human readable format name used in error messages, such as "JSON".
+	Name() string
+	ContentType() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+var (
+	JSON        Codec = jsonCodec{}
+	XML         Codec = xmlCodec{}
+	MessagePack Codec = msgpackCodec{}
+	CBOR        Codec = cborCodec{}
+	// HAL is JSON with the application/hal+json media type. It is only negotiated where
+	// a handler passes it to Negotiate, since the representation has to be built for it.
+	HAL Codec = halCodec{}
+)
+
+var (
+	// ErrNotAcceptable means that no codec matches the Accept header.
+	ErrNotAcceptable = errors.New("not acceptable")
+	// ErrUnsupportedMediaType means that no codec matches the Content-Type header.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+type mediaCodec struct {
+	mediaType string
+	codec     Codec
+}
+
+// codecs lists every supported media type, in order of preference for wildcard Accept ranges.
+var codecs = []mediaCodec{
+	{"application/json", JSON},
+	{"application/xml", XML},
+	{"text/xml", XML},
+	{"application/msgpack", MessagePack},
+	{"application/vnd.msgpack", MessagePack},
+	{"application/x-msgpack", MessagePack},
+	{"application/cbor", CBOR},
+}
+
+// MediaTypes returns the supported media types.
+func MediaTypes() []string {
+	types := make([]string, 0, len(codecs))
+	for _, c := range codecs {
+		types = append(types, c.mediaType)
+	}
+
+	return types
+}
+
+// Negotiate picks the response codec for an Accept header. An empty header accepts JSON.
+// extensions are offered in addition to the standard codecs, after them for wildcard ranges.
+func Negotiate(accept string, extensions ...Codec) (Codec, error) {
+	if strings.TrimSpace(accept) == "" {
+		return JSON, nil
+	}
+
+	type acceptRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	var ranges []acceptRange
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		if quality > 0 {
+			ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	candidates := codecs
+	mediaTypes := MediaTypes()
+
+	for _, extension := range extensions {
+		candidates = append(candidates[:len(candidates):len(candidates)], mediaCodec{extension.ContentType(), extension})
+		mediaTypes = append(mediaTypes, extension.ContentType())
+	}
+
+	for _, r := range ranges {
+		for _, c := range candidates {
+			if matchesRange(r.mediaType, c.mediaType) {
+				return c.codec, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q, supported types are %s", ErrNotAcceptable, accept, strings.Join(mediaTypes, ", "))
+}
+
+// ForContentType picks the request codec for a Content-Type header. An empty header is read as JSON,
+// which is what clients sent before other formats were supported.
+func ForContentType(contentType string) (Codec, error) {
+	if strings.TrimSpace(contentType) == "" {
+		return JSON, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil {
+		for _, c := range codecs {
+			if c.mediaType == mediaType {
+				return c.codec, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q, supported types are %s", ErrUnsupportedMediaType, contentType, strings.Join(MediaTypes(), ", "))
+}
+
+func matchesRange(acceptRange, mediaType string) bool {
+	if acceptRange == "*/*" || acceptRange == mediaType {
+		return true
+	}
+
+	rangeType, rangeSubtype, _ := strings.Cut(acceptRange, "/")
+	typ, _, _ := strings.Cut(mediaType, "/")
+
+	return rangeSubtype == "*" && rangeType == typ
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                               { return "JSON" }
+func (jsonCodec) ContentType() string                        { return "application/json" }
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+type halCodec struct{}
+
+func (halCodec) Name() string                               { return "HAL" }
+func (halCodec) ContentType() string                        { return "application/hal+json" }
+func (halCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (halCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+type xmlCodec struct{}
+
+func (xmlCodec) Name() string        { return "XML" }
+func (xmlCodec) ContentType() string { return "application/xml" }
+
+// Marshal wraps slices in an <items> root element, since a document needs a single root.
+func (xmlCodec) Marshal(v interface{}) ([]byte, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Slice {
+		content, err := xml.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]byte(xml.Header), content...), nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	encoder := xml.NewEncoder(&buf)
+	root := xml.StartElement{Name: xml.Name{Local: "items"}}
+
+	if err := encoder.EncodeToken(root); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		if err := encoder.Encode(value.Index(i).Interface()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := encoder.EncodeToken(root.End()); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (xmlCodec) Unmarshal(data []byte, v interface{}) error { return xml.Unmarshal(data, v) }
+
+// msgpackCodec uses the json struct tags, so that field names match across formats.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string        { return "MessagePack" }
+func (msgpackCodec) ContentType() string { return "application/msgpack" }
+
+func (msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := msgpack.NewEncoder(&buf)
+	encoder.SetCustomStructTag("json")
+
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	decoder := msgpack.NewDecoder(bytes.NewReader(data))
+	decoder.SetCustomStructTag("json")
+
+	return decoder.Decode(v)
+}
+
+// cborCodec falls back to the json struct tags when a field has no cbor tag.
+type cborCodec struct{}
+
+func (cborCodec) Name() string                               { return "CBOR" }
+func (cborCodec) ContentType() string                        { return "application/cbor" }
+func (cborCodec) Marshal(v interface{}) ([]byte, error)      { return cbor.Marshal(v) }
+func (cborCodec) Unmarshal(data []byte, v interface{}) error { return cbor.Unmarshal(data, v) }
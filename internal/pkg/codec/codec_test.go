@@ -0,0 +1,163 @@
+package codec
+
+import (
+	"testing"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		accept   string
+		expected Codec
+		wantErr  bool
+	}{
+		{accept: "", expected: JSON},
+		{accept: "*/*", expected: JSON},
+		{accept: "application/xml", expected: XML},
+		{accept: "text/xml; charset=utf-8", expected: XML},
+		{accept: "application/cbor, application/json;q=0.5", expected: CBOR},
+		{accept: "application/json;q=0.2, application/msgpack", expected: MessagePack},
+		{accept: "text/html, application/*;q=0.1", expected: JSON},
+		{accept: "application/json;q=0, application/cbor;q=0.1", expected: CBOR},
+		{accept: "text/html", wantErr: true},
+		{accept: "application/json;q=0", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.accept, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := Negotiate(tc.accept)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrNotAcceptable)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestNegotiate_Extensions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		accept   string
+		expected Codec
+	}{
+		{accept: "application/hal+json", expected: HAL},
+		{accept: "application/hal+json;q=0.5, application/xml", expected: XML},
+		{accept: "application/*", expected: JSON},
+		{accept: "*/*", expected: JSON},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.accept, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := Negotiate(tc.accept, HAL)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	_, err := Negotiate("application/hal+json")
+	assert.ErrorIs(t, err, ErrNotAcceptable)
+}
+
+func TestForContentType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		contentType string
+		expected    Codec
+		wantErr     bool
+	}{
+		{contentType: "", expected: JSON},
+		{contentType: "application/json; charset=utf-8", expected: JSON},
+		{contentType: "application/x-msgpack", expected: MessagePack},
+		{contentType: "application/cbor", expected: CBOR},
+		{contentType: "text/plain", wantErr: true},
+		{contentType: "not a type", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.contentType, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := ForContentType(tc.contentType)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrUnsupportedMediaType)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []Codec{JSON, XML, MessagePack, CBOR} {
+		c := c
+		t.Run(c.Name(), func(t *testing.T) {
+			t.Parallel()
+
+			expected := models.StudentRequest{StudentID: 1, StudentName: "Test", Grade: 90}
+
+			content, err := c.Marshal(expected)
+			require.NoError(t, err)
+
+			var actual models.StudentRequest
+			require.NoError(t, c.Unmarshal(content, &actual))
+
+			actual.XMLName = expected.XMLName
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestXML_List(t *testing.T) {
+	t.Parallel()
+
+	content, err := XML.Marshal([]models.ClassInfo{
+		{ID: 1, StudentID: 7, ClassName: "Math"},
+		{ID: 2, StudentID: 7, ClassName: "Art"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<items><class_info><id>1</id><student_id>7</student_id><class_name>Math</class_name><term_id>0</term_id></class_info>`+
+		`<class_info><id>2</id><student_id>7</student_id><class_name>Art</class_name><term_id>0</term_id></class_info></items>`, string(content))
+}
+
+func TestBinaryCodecs_UseJSONNames(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []Codec{MessagePack, CBOR} {
+		c := c
+		t.Run(c.Name(), func(t *testing.T) {
+			t.Parallel()
+
+			content, err := c.Marshal(models.ClassInfo{ID: 1, StudentID: 7, ClassName: "Math"})
+			require.NoError(t, err)
+
+			var fields map[string]interface{}
+			require.NoError(t, c.Unmarshal(content, &fields))
+			assert.Equal(t, "Math", fields["class_name"])
+			assert.NotContains(t, fields, "XMLName")
+		})
+	}
+}
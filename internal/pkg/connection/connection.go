@@ -4,9 +4,13 @@ package connection
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"CRUD_Go_Backend/internal/config"
+	"CRUD_Go_Backend/internal/health"
+	"CRUD_Go_Backend/internal/pkg/tracing"
+	"CRUD_Go_Backend/internal/repository/migrations"
 
 	"github.com/georgysavva/scany/pgxscan"
 	"github.com/jackc/pgconn"
@@ -14,8 +18,14 @@ import (
 	"github.com/jackc/pgx/v4/pgxpool"
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = tracing.Tracer("pgx")
+
 type DBops interface {
 	GetPool(_ context.Context) *pgxpool.Pool
 	Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error
@@ -23,14 +33,27 @@ type DBops interface {
 	ExecQueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row
 	ExecQuery(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error)
 	Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	// InTx runs fn in a transaction, which is committed when fn returns nil and rolled back otherwise.
+	// Called inside a transaction, it runs fn in a savepoint.
+	InTx(ctx context.Context, fn func(tx DBops) error) error
+}
+
+// querier is what Database needs from a pool or a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
 }
 
 type Database struct {
 	cluster *pgxpool.Pool
+	// conn runs the queries: the pool, or the transaction of a Database passed to an InTx callback.
+	conn querier
 }
 
 func newDatabase(cluster *pgxpool.Pool) *Database {
-	return &Database{cluster: cluster}
+	return &Database{cluster: cluster, conn: cluster}
 }
 
 func (db Database) GetPool(ctx context.Context) *pgxpool.Pool {
@@ -38,47 +61,171 @@ func (db Database) GetPool(ctx context.Context) *pgxpool.Pool {
 }
 
 func (db Database) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	return pgxscan.Get(ctx, db.cluster, dest, query, args...)
+	ctx, span := startQuerySpan(ctx, "pgx.Get", query)
+	defer span.End()
+
+	err := pgxscan.Get(ctx, db.conn, dest, query, args...)
+	endQuerySpan(span, err)
+
+	return err
 }
 
 func (db Database) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
-	return db.cluster.Exec(ctx, query, args...)
+	ctx, span := startQuerySpan(ctx, "pgx.Exec", query)
+	defer span.End()
+
+	command, err := db.conn.Exec(ctx, query, args...)
+	if err == nil {
+		span.SetAttributes(attribute.Int64("db.rows_affected", command.RowsAffected()))
+	}
+
+	endQuerySpan(span, err)
+
+	return command, err
 }
 
 func (db Database) ExecQueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
-	return db.cluster.QueryRow(ctx, query, args...)
+	ctx, span := startQuerySpan(ctx, "pgx.QueryRow", query)
+
+	return tracedRow{row: db.conn.QueryRow(ctx, query, args...), span: span}
 }
 
 func (db Database) ExecQuery(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
-	return db.cluster.Query(ctx, query, args...)
+	ctx, span := startQuerySpan(ctx, "pgx.Query", query)
+
+	rows, err := db.conn.Query(ctx, query, args...)
+	if err != nil {
+		endQuerySpan(span, err)
+		span.End()
+
+		return nil, err
+	}
+
+	return &tracedRows{Rows: rows, span: span}, nil
 }
 func (db Database) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	return pgxscan.Select(ctx, db.cluster, dest, query, args...)
+	ctx, span := startQuerySpan(ctx, "pgx.Select", query)
+	defer span.End()
+
+	err := pgxscan.Select(ctx, db.conn, dest, query, args...)
+	endQuerySpan(span, err)
+
+	return err
+}
+
+func (db Database) InTx(ctx context.Context, fn func(tx DBops) error) error {
+	ctx, span := tracer.Start(ctx, "pgx.Tx", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	err := db.conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return fn(Database{cluster: db.cluster, conn: tx})
+	})
+	endQuerySpan(span, err)
+
+	return err
+}
+
+func startQuerySpan(ctx context.Context, name string, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBStatement(tracing.SanitizeSQL(query)),
+		),
+	)
+}
+
+func endQuerySpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// tracedRow ends the QueryRow span once the row has been scanned, because pgx defers the query until Scan.
+type tracedRow struct {
+	row  pgx.Row
+	span trace.Span
+}
+
+func (r tracedRow) Scan(dest ...interface{}) error {
+	defer r.span.End()
+
+	err := r.row.Scan(dest...)
+	endQuerySpan(r.span, err)
+
+	return err
+}
+
+// tracedRows ends the Query span once the rows have been read or closed, because the rows are
+// streamed from the connection while the caller iterates over them.
+type tracedRows struct {
+	pgx.Rows
+	span  trace.Span
+	count int64
+	ended bool
+}
+
+func (r *tracedRows) Next() bool {
+	if r.Rows.Next() {
+		r.count++
+		return true
+	}
+
+	r.end()
+
+	return false
 }
 
-func GenerateDsn(cfg config.DatabaseConfig) string {
-	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName)
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	r.end()
 }
+
+func (r *tracedRows) end() {
+	if r.ended {
+		return
+	}
+
+	r.ended = true
+	r.span.SetAttributes(attribute.Int64("db.rows_returned", r.count))
+	endQuerySpan(r.span, r.Rows.Err())
+	r.span.End()
+}
+
 func NewDB(ctx context.Context, cfg config.DatabaseConfig) (*Database, error) {
-	pool, err := pgxpool.Connect(ctx, GenerateDsn(cfg))
+	poolConfig, err := pgxpool.ParseConfig(generatePoolDsn(cfg))
 	if err != nil {
-		return nil, fmt.Errorf("could not create connection pool: %v", err)
+		return nil, fmt.Errorf("could not parse connection config: %v", err)
+	}
+
+	applyPoolConfig(poolConfig, cfg)
+
+	pool, err := pgxpool.ConnectConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not create connection pool: %w", err)
 	}
 
 	return newDatabase(pool), nil
 }
-func MigrationUp(cfg config.DatabaseConfig) error {
+
+// migrationDir is the root of migrations.FS, which holds the embedded goose migrations.
+const migrationDir = "."
+
+func MigrationUp(ctx context.Context, cfg config.DatabaseConfig) error {
 	db, err := sql.Open("postgres", GenerateDsn(cfg))
 	if err != nil {
 		return err
 	}
+	defer db.Close()
+
+	goose.SetBaseFS(migrations.FS)
 
-	if err := goose.Up(db, "./internal/repository/migrations"); err != nil {
+	if err := goose.UpContext(ctx, db, migrationDir); err != nil {
 		return fmt.Errorf("goose migration up failed: %v", err)
 	}
 
-	return db.Close()
+	return nil
 }
 
 func MigrationDownAndCloseSql(cfg config.DatabaseConfig) error {
@@ -87,9 +234,69 @@ func MigrationDownAndCloseSql(cfg config.DatabaseConfig) error {
 		return err
 	}
 
-	if err := goose.Down(db, "./internal/repository/migrations"); err != nil {
+	goose.SetBaseFS(migrations.FS)
+
+	if err := goose.Reset(db, migrationDir); err != nil {
 		return fmt.Errorf("goose migration down failed: %v", err)
 	}
 
 	return db.Close()
 }
+
+// LatestMigrationVersion returns the highest version among the embedded migrations.
+func LatestMigrationVersion() (int64, error) {
+	goose.SetBaseFS(migrations.FS)
+
+	collected, err := goose.CollectMigrations(migrationDir, 0, goose.MaxVersion)
+	if err != nil {
+		return 0, fmt.Errorf("could not collect migrations: %v", err)
+	}
+
+	latest, err := collected.Last()
+	if err != nil {
+		return 0, err
+	}
+
+	return latest.Version, nil
+}
+
+// MigrationCheck returns a readiness probe that fails until the database is at the embedded migration version.
+func MigrationCheck(cfg config.DatabaseConfig) (health.Check, func() error, error) {
+	latest, err := LatestMigrationVersion()
+	if err != nil {
+		return health.Check{}, nil, err
+	}
+
+	db, err := sql.Open("postgres", GenerateDsn(cfg))
+	if err != nil {
+		return health.Check{}, nil, err
+	}
+
+	check := health.Check{
+		Name: "migrations",
+		Run: func(ctx context.Context) error {
+			current, err := goose.GetDBVersionContext(ctx, db)
+			if err != nil {
+				return err
+			}
+
+			if current != latest {
+				return fmt.Errorf("%w: database is at %d, expected %d", ErrSchemaOutdated, current, latest)
+			}
+
+			return nil
+		},
+	}
+
+	return check, db.Close, nil
+}
+
+// PingCheck returns a readiness probe that acquires a pool connection and pings Postgres.
+func PingCheck(db DBops) health.Check {
+	return health.Check{
+		Name: "postgres",
+		Run: func(ctx context.Context) error {
+			return db.GetPool(ctx).Ping(ctx)
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// fakeRows yields remaining rows and then fails with err.
+type fakeRows struct {
+	pgx.Rows
+	remaining int
+	err       error
+	closed    int
+}
+
+func (r *fakeRows) Next() bool {
+	if r.remaining == 0 {
+		return false
+	}
+
+	r.remaining--
+
+	return true
+}
+
+func (r *fakeRows) Close()     { r.closed++ }
+func (r *fakeRows) Err() error { return r.err }
+
+func TestTracedRows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description    string
+		rows           *fakeRows
+		next           int
+		expectedRows   int64
+		expectedStatus codes.Code
+		// endsWithNext is whether the span already ends when Next runs out of rows, before Close.
+		endsWithNext bool
+	}{
+		{
+			description:    "Read to the end",
+			rows:           &fakeRows{remaining: 2},
+			next:           3,
+			expectedRows:   2,
+			expectedStatus: codes.Unset,
+			endsWithNext:   true,
+		},
+		{
+			description:    "Closed early",
+			rows:           &fakeRows{remaining: 2},
+			next:           1,
+			expectedRows:   1,
+			expectedStatus: codes.Unset,
+		},
+		{
+			description:    "Failed while reading",
+			rows:           &fakeRows{remaining: 1, err: assert.AnError},
+			next:           2,
+			expectedRows:   1,
+			expectedStatus: codes.Error,
+			endsWithNext:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			_, span := provider.Tracer("test").Start(context.Background(), "pgx.Query")
+			rows := &tracedRows{Rows: tc.rows, span: span}
+			// act
+			for i := 0; i < tc.next; i++ {
+				rows.Next()
+			}
+
+			assert.Equal(t, tc.endsWithNext, len(recorder.Ended()) == 1)
+			rows.Close()
+			rows.Close()
+			// assert
+			require.Len(t, recorder.Ended(), 1)
+			ended := recorder.Ended()[0]
+			assert.Contains(t, ended.Attributes(), attribute.Int64("db.rows_returned", tc.expectedRows))
+			assert.Equal(t, tc.expectedStatus, ended.Status().Code)
+			assert.Equal(t, 2, tc.rows.closed)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package connection
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"CRUD_Go_Backend/internal/config"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Connection string keys that only pgxpool understands. They must not reach lib/pq,
+// which would forward them to Postgres as unknown run-time parameters.
+const (
+	poolMaxConns          = "pool_max_conns"
+	poolMinConns          = "pool_min_conns"
+	poolMaxConnLifetime   = "pool_max_conn_lifetime"
+	poolMaxConnIdleTime   = "pool_max_conn_idle_time"
+	poolHealthCheckPeriod = "pool_health_check_period"
+)
+
+var poolKeys = []string{poolMaxConns, poolMinConns, poolMaxConnLifetime, poolMaxConnIdleTime, poolHealthCheckPeriod}
+
+// GenerateDsn builds a connection string accepted by both pgx and lib/pq.
+// With cfg.URL set the URL is used and the optional keys are added only when it lacks them;
+// otherwise a keyword/value string is built with every value quoted and escaped.
+func GenerateDsn(cfg config.DatabaseConfig) string {
+	if cfg.URL != "" {
+		return urlDsn(cfg, false)
+	}
+
+	params := append([][2]string{
+		{"host", cfg.Host},
+		{"port", strconv.Itoa(cfg.Port)},
+		{"user", cfg.User},
+		{"password", cfg.Password},
+		{"dbname", cfg.DBName},
+	}, options(cfg)...)
+
+	parts := make([]string, 0, len(params))
+	for _, param := range params {
+		parts = append(parts, param[0]+"="+quoteDsnValue(param[1]))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// generatePoolDsn is GenerateDsn for pgxpool, which keeps any pool_* parameters given in cfg.URL.
+func generatePoolDsn(cfg config.DatabaseConfig) string {
+	if cfg.URL == "" {
+		return GenerateDsn(cfg)
+	}
+
+	return urlDsn(cfg, true)
+}
+
+// applyPoolConfig sets the pool limits from cfg, except those already given as pool_* parameters in cfg.URL,
+// which take precedence. Zero values keep the pgxpool defaults.
+func applyPoolConfig(poolConfig *pgxpool.Config, cfg config.DatabaseConfig) {
+	fromURL := map[string]bool{}
+	if u, err := url.Parse(cfg.URL); cfg.URL != "" && err == nil {
+		for _, key := range poolKeys {
+			fromURL[key] = u.Query().Has(key)
+		}
+	}
+
+	if cfg.MaxConns > 0 && !fromURL[poolMaxConns] {
+		poolConfig.MaxConns = int32(cfg.MaxConns)
+	}
+
+	if cfg.MinConns > 0 && !fromURL[poolMinConns] {
+		poolConfig.MinConns = int32(cfg.MinConns)
+	}
+
+	if cfg.MaxConnLifetime > 0 && !fromURL[poolMaxConnLifetime] {
+		poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+
+	if cfg.MaxConnIdleTime > 0 && !fromURL[poolMaxConnIdleTime] {
+		poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+
+	if cfg.HealthCheckPeriod > 0 && !fromURL[poolHealthCheckPeriod] {
+		poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+}
+
+func urlDsn(cfg config.DatabaseConfig, keepPoolKeys bool) string {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return cfg.URL
+	}
+
+	query := u.Query()
+	if !keepPoolKeys {
+		for _, key := range poolKeys {
+			query.Del(key)
+		}
+	}
+
+	for _, option := range options(cfg) {
+		if !query.Has(option[0]) {
+			query.Set(option[0], option[1])
+		}
+	}
+
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// options returns the optional connection parameters as key/value pairs.
+func options(cfg config.DatabaseConfig) [][2]string {
+	sslMode := cfg.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	result := [][2]string{{"sslmode", sslMode}}
+
+	for _, option := range [][2]string{
+		{"sslrootcert", cfg.SSLRootCert},
+		{"sslcert", cfg.SSLCert},
+		{"sslkey", cfg.SSLKey},
+		{"application_name", cfg.ApplicationName},
+	} {
+		if option[1] != "" {
+			result = append(result, option)
+		}
+	}
+
+	if cfg.StatementTimeout > 0 {
+		result = append(result, [2]string{"statement_timeout", fmt.Sprint(cfg.StatementTimeout.Milliseconds())})
+	}
+
+	return result
+}
+
+// quoteDsnValue quotes a keyword/value connection string value as libpq expects:
+// the value is wrapped in single quotes and backslashes and single quotes are escaped.
+func quoteDsnValue(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+
+	return "'" + escaped + "'"
+}
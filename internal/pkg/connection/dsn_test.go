@@ -0,0 +1,98 @@
+package connection
+
+import (
+	"testing"
+	"time"
+
+	"CRUD_Go_Backend/internal/config"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateDsn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		cfg         config.DatabaseConfig
+		expected    string
+	}{
+		{
+			description: "Escapes every component",
+			cfg: config.DatabaseConfig{
+				Host:             "localhost",
+				Port:             5432,
+				User:             "postgres",
+				Password:         `p@ss word's \\ end`,
+				DBName:           "test db",
+				SSLMode:          "verify-full",
+				SSLRootCert:      "/etc/ssl/ca.pem",
+				ApplicationName:  "crud",
+				StatementTimeout: 5 * time.Second,
+			},
+			expected: `host='localhost' port='5432' user='postgres' password='p@ss word\'s \\\\ end' dbname='test db' ` +
+				`sslmode='verify-full' sslrootcert='/etc/ssl/ca.pem' application_name='crud' statement_timeout='5000'`,
+		},
+		{
+			description: "URL keeps its own parameters and drops pool ones",
+			cfg: config.DatabaseConfig{
+				URL:             "postgres://app:secret@db:5433/school?sslmode=require&pool_max_conns=4",
+				SSLMode:         "disable",
+				ApplicationName: "crud",
+			},
+			expected: "postgres://app:secret@db:5433/school?application_name=crud&sslmode=require",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, GenerateDsn(tc.cfg))
+		})
+	}
+}
+
+func TestGenerateDsn_ParsedByPgx(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DatabaseConfig{
+		Host:             "localhost",
+		Port:             5432,
+		User:             "postgres",
+		Password:         `it's a "secret" \ with spaces`,
+		DBName:           "test",
+		SSLMode:          "disable",
+		StatementTimeout: time.Second,
+		MaxConns:         7,
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(generatePoolDsn(cfg))
+	require.NoError(t, err)
+
+	applyPoolConfig(poolConfig, cfg)
+
+	assert.Equal(t, cfg.Password, poolConfig.ConnConfig.Password)
+	assert.Equal(t, "1000", poolConfig.ConnConfig.RuntimeParams["statement_timeout"])
+	assert.Equal(t, int32(7), poolConfig.MaxConns)
+}
+
+func TestApplyPoolConfig_URLTakesPrecedence(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DatabaseConfig{
+		URL:      "postgres://app:secret@db:5432/school?pool_max_conns=3",
+		MaxConns: 10,
+		MinConns: 2,
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(generatePoolDsn(cfg))
+	require.NoError(t, err)
+
+	applyPoolConfig(poolConfig, cfg)
+
+	assert.Equal(t, int32(3), poolConfig.MaxConns)
+	assert.Equal(t, int32(2), poolConfig.MinConns)
+}
@@ -0,0 +1,188 @@
+package connection
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"CRUD_Go_Backend/internal/config"
+
+	"github.com/jackc/pgconn"
+	"github.com/lib/pq"
+)
+
+// Categories reported for a failed connection attempt.
+const (
+	FailureDNS             = "dns"
+	FailureRefused         = "refused"
+	FailureAuth            = "auth_failed"
+	FailureDatabaseMissing = "database_missing"
+	FailureTLS             = "tls"
+	FailureTimeout         = "timeout"
+	FailureSchemaOutdated  = "schema_outdated"
+	FailureUnknown         = "unknown"
+)
+
+// ErrSchemaOutdated is returned by the migration check while the database is not at the embedded version.
+var ErrSchemaOutdated = errors.New("database schema is not at the expected migration version")
+
+// Postgres SQLSTATE codes used by ClassifyError.
+const (
+	sqlStateInvalidAuthorization = "28000"
+	sqlStateInvalidPassword      = "28P01"
+	sqlStateInvalidCatalogName   = "3D000"
+)
+
+// RetryPolicy bounds an exponential backoff with full jitter.
+type RetryPolicy struct {
+	Deadline       time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Attempt describes a failed try, as passed to the Retry notify callback.
+type Attempt struct {
+	Number   int
+	Err      error
+	Category string
+	Wait     time.Duration
+}
+
+// Retry calls op until it succeeds, ctx is cancelled or the policy deadline passes.
+// notify is called after every failed attempt that will be retried.
+func Retry(ctx context.Context, policy RetryPolicy, op func(ctx context.Context) error, notify func(Attempt)) error {
+	if policy.Deadline > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, policy.Deadline)
+		defer cancel()
+	}
+
+	var (
+		backoff = policy.InitialBackoff
+		lastErr error
+	)
+
+	for attempt := 1; ; attempt++ {
+		err := op(ctx)
+		if err == nil {
+			return nil
+		}
+
+		// An attempt cut short by the deadline says nothing about the dependency, so report the previous failure.
+		if ctx.Err() != nil {
+			if lastErr == nil {
+				lastErr = err
+			}
+
+			return fmt.Errorf("giving up after %d attempts (%s): %w", attempt, ClassifyError(lastErr), lastErr)
+		}
+
+		lastErr = err
+		category := ClassifyError(err)
+		wait := time.Duration(rand.Int63n(int64(backoff) + 1)) //nolint:gosec // jitter does not need a secure source
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			wait = time.Until(deadline)
+		}
+
+		if notify != nil {
+			notify(Attempt{Number: attempt, Err: err, Category: category, Wait: wait})
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("giving up after %d attempts (%s): %w", attempt, category, err)
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// ClassifyError maps a connection error from pgx or lib/pq to one of the Failure* categories.
+func ClassifyError(err error) string {
+	var (
+		pgErr      *pgconn.PgError
+		pqErr      *pq.Error
+		dnsErr     *net.DNSError
+		certErr    *tls.CertificateVerificationError
+		unknownCA  x509.UnknownAuthorityError
+		hostErr    x509.HostnameError
+		invalidErr x509.CertificateInvalidError
+		recordErr  tls.RecordHeaderError
+		netErr     net.Error
+	)
+
+	switch {
+	case errors.Is(err, ErrSchemaOutdated):
+		return FailureSchemaOutdated
+	case errors.As(err, &pgErr):
+		return classifySQLState(pgErr.Code)
+	case errors.As(err, &pqErr):
+		return classifySQLState(string(pqErr.Code))
+	case errors.As(err, &dnsErr):
+		return FailureDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return FailureRefused
+	case errors.As(err, &certErr), errors.As(err, &unknownCA), errors.As(err, &hostErr),
+		errors.As(err, &invalidErr), errors.As(err, &recordErr):
+		return FailureTLS
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return FailureTimeout
+	}
+
+	// Some failures only surface as text, e.g. when the server does not support SSL.
+	message := strings.ToLower(err.Error())
+
+	switch {
+	case strings.Contains(message, "tls") || strings.Contains(message, "ssl") || strings.Contains(message, "x509"):
+		return FailureTLS
+	case strings.Contains(message, "connection refused"):
+		return FailureRefused
+	case strings.Contains(message, "no such host"):
+		return FailureDNS
+	case strings.Contains(message, "password authentication failed"):
+		return FailureAuth
+	case strings.Contains(message, "does not exist") && strings.Contains(message, "database"):
+		return FailureDatabaseMissing
+	}
+
+	return FailureUnknown
+}
+
+func classifySQLState(code string) string {
+	switch code {
+	case sqlStateInvalidAuthorization, sqlStateInvalidPassword:
+		return FailureAuth
+	case sqlStateInvalidCatalogName:
+		return FailureDatabaseMissing
+	default:
+		return FailureUnknown
+	}
+}
+
+// NewDBWithRetry is NewDB retried according to policy.
+func NewDBWithRetry(ctx context.Context, cfg config.DatabaseConfig, policy RetryPolicy, notify func(Attempt)) (*Database, error) {
+	var database *Database
+
+	err := Retry(ctx, policy, func(ctx context.Context) error {
+		var err error
+
+		database, err = NewDB(ctx, cfg)
+
+		return err
+	}, notify)
+
+	return database, err
+}
@@ -0,0 +1,92 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+
+	tests := []struct {
+		description string
+		err         error
+		expected    string
+	}{
+		{"DNS", &net.DNSError{Err: "no such host", Name: "postgres"}, FailureDNS},
+		{"Refused", fmt.Errorf("failed to connect: %w", refused), FailureRefused},
+		{"Auth via pgx", &pgconn.PgError{Code: sqlStateInvalidPassword}, FailureAuth},
+		{"Auth via lib/pq", &pq.Error{Code: sqlStateInvalidAuthorization}, FailureAuth},
+		{"Database missing", &pgconn.PgError{Code: sqlStateInvalidCatalogName}, FailureDatabaseMissing},
+		{"TLS", errors.New("server refused TLS connection"), FailureTLS},
+		{"Timeout", context.DeadlineExceeded, FailureTimeout},
+		{"Schema", fmt.Errorf("%w: database is at 1, expected 2", ErrSchemaOutdated), FailureSchemaOutdated},
+		{"Unknown", assert.AnError, FailureUnknown},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, ClassifyError(tc.err))
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{Deadline: time.Second, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+
+	t.Run("Succeeds after failures", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls    int
+			attempts []Attempt
+		)
+
+		err := Retry(context.Background(), policy, func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return &pgconn.PgError{Code: sqlStateInvalidCatalogName}
+			}
+
+			return nil
+		}, func(attempt Attempt) {
+			attempts = append(attempts, attempt)
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		require.Len(t, attempts, 2)
+		assert.Equal(t, FailureDatabaseMissing, attempts[0].Category)
+		assert.LessOrEqual(t, attempts[1].Wait, 2*time.Millisecond)
+	})
+
+	t.Run("Gives up at the deadline", func(t *testing.T) {
+		t.Parallel()
+
+		shortPolicy := RetryPolicy{Deadline: 20 * time.Millisecond, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+		err := Retry(context.Background(), shortPolicy, func(context.Context) error {
+			return assert.AnError
+		}, nil)
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Contains(t, err.Error(), FailureUnknown)
+	})
+}
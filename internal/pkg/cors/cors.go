@@ -0,0 +1,190 @@
+// Package cors answers CORS preflight requests for every route of a mux router
+// and adds the CORS response headers to requests from allowed origins.
+package cors
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"CRUD_Go_Backend/internal/pkg/problem"
+
+	"github.com/gorilla/mux"
+)
+
+// Options is the CORS policy.
+type Options struct {
+	// AllowedOrigins are exact origins, "*", or path.Match patterns such as "https://*.example.com".
+	AllowedOrigins []string
+	AllowedMethods []string
+	// AllowedHeaders lists the request headers a browser may send; "*" allows any.
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// Policy applies Options to requests.
+type Policy struct {
+	options        Options
+	allowedHeaders map[string]bool
+	anyHeader      bool
+}
+
+// New creates a new Policy.
+func New(options Options) *Policy {
+	headers := make(map[string]bool, len(options.AllowedHeaders))
+	anyHeader := false
+
+	for _, header := range options.AllowedHeaders {
+		if header == "*" {
+			anyHeader = true
+		}
+
+		headers[http.CanonicalHeaderKey(header)] = true
+	}
+
+	methods := make([]string, 0, len(options.AllowedMethods))
+	for _, method := range options.AllowedMethods {
+		methods = append(methods, strings.ToUpper(method))
+	}
+
+	options.AllowedMethods = methods
+
+	return &Policy{
+		options:        options,
+		allowedHeaders: headers,
+		anyHeader:      anyHeader,
+	}
+}
+
+// Middleware answers preflight requests itself, before authentication, and decorates the responses to
+// other cross-origin requests. router is used to find the methods registered for the preflighted path;
+// it must have a route that matches OPTIONS on every path so that the middleware runs for preflights.
+func (p *Policy) Middleware(router *mux.Router) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			origin := req.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+
+			if req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != "" {
+				p.preflight(w, req, router, origin)
+				return
+			}
+
+			if p.originAllowed(origin) {
+				p.setOriginHeaders(w, origin)
+
+				if len(p.options.ExposedHeaders) > 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(p.options.ExposedHeaders, ", "))
+				}
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+func (p *Policy) preflight(w http.ResponseWriter, req *http.Request, router *mux.Router, origin string) {
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+	routeMethods := p.routeMethods(req, router)
+	if len(routeMethods) == 0 {
+		problem.Write(w, req, http.StatusNotFound, "no route for "+req.URL.Path)
+		return
+	}
+
+	if !p.originAllowed(origin) {
+		problem.Write(w, req, http.StatusForbidden, "origin "+origin+" is not allowed")
+		return
+	}
+
+	requestedMethod := strings.ToUpper(req.Header.Get("Access-Control-Request-Method"))
+	if !contains(routeMethods, requestedMethod) {
+		problem.Write(w, req, http.StatusForbidden, "method "+requestedMethod+" is not allowed on "+req.URL.Path)
+		return
+	}
+
+	for _, header := range strings.Split(req.Header.Get("Access-Control-Request-Headers"), ",") {
+		header = http.CanonicalHeaderKey(strings.TrimSpace(header))
+		if header != "" && !p.anyHeader && !p.allowedHeaders[header] {
+			problem.Write(w, req, http.StatusForbidden, "header "+header+" is not allowed")
+			return
+		}
+	}
+
+	p.setOriginHeaders(w, origin)
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(routeMethods, ", "))
+
+	if requested := req.Header.Get("Access-Control-Request-Headers"); requested != "" {
+		w.Header().Set("Access-Control-Allow-Headers", requested)
+	}
+
+	if p.options.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(p.options.MaxAge.Seconds())))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// routeMethods returns the allowed methods that have a route for the request path.
+func (p *Policy) routeMethods(req *http.Request, router *mux.Router) []string {
+	var methods []string
+
+	for _, method := range p.options.AllowedMethods {
+		if method == http.MethodOptions {
+			continue
+		}
+
+		probe := req.Clone(req.Context())
+		probe.Method = method
+
+		var match mux.RouteMatch
+		if router.Match(probe, &match) && match.MatchErr == nil {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
+
+func (p *Policy) setOriginHeaders(w http.ResponseWriter, origin string) {
+	// Echo the origin rather than "*" so that credentialed requests work and caches key on Vary: Origin.
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+
+	if p.options.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (p *Policy) originAllowed(origin string) bool {
+	for _, allowed := range p.options.AllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+
+		if ok, _ := path.Match(allowed, origin); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,137 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRouter() *mux.Router {
+	policy := New(Options{
+		AllowedOrigins:   []string{"https://admin.example.com", "https://*.staging.example.com"},
+		AllowedMethods:   []string{"get", "post", "put", "delete"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"RateLimit-Remaining"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})
+
+	router := mux.NewRouter()
+	router.Use(policy.Middleware(router))
+
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+	router.HandleFunc("/student", ok).Methods(http.MethodPost, http.MethodPut)
+	router.HandleFunc("/student/{id:[0-9]+}", ok).Methods(http.MethodGet)
+	router.PathPrefix("/").Methods(http.MethodOptions).HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})
+
+	return router
+}
+
+func TestPolicy_Preflight(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description     string
+		path            string
+		origin          string
+		method          string
+		headers         string
+		expectedCode    int
+		expectedMethods string
+	}{
+		{
+			description:     "allowed origin",
+			path:            "/student",
+			origin:          "https://admin.example.com",
+			method:          http.MethodPut,
+			headers:         "authorization, content-type",
+			expectedCode:    http.StatusNoContent,
+			expectedMethods: "POST, PUT",
+		},
+		{
+			description:     "origin pattern",
+			path:            "/student/1",
+			origin:          "https://admin.staging.example.com",
+			method:          http.MethodGet,
+			expectedCode:    http.StatusNoContent,
+			expectedMethods: "GET",
+		},
+		{
+			description:  "unknown origin",
+			path:         "/student/1",
+			origin:       "https://evil.example.com",
+			method:       http.MethodGet,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:  "method without route",
+			path:         "/student/1",
+			origin:       "https://admin.example.com",
+			method:       http.MethodDelete,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:  "header not allowed",
+			path:         "/student",
+			origin:       "https://admin.example.com",
+			method:       http.MethodPost,
+			headers:      "X-Debug",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			description:  "unknown path",
+			path:         "/teacher",
+			origin:       "https://admin.example.com",
+			method:       http.MethodGet,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			// arrange
+			req := httptest.NewRequest(http.MethodOptions, tc.path, nil)
+			req.Header.Set("Origin", tc.origin)
+			req.Header.Set("Access-Control-Request-Method", tc.method)
+			if tc.headers != "" {
+				req.Header.Set("Access-Control-Request-Headers", tc.headers)
+			}
+			rr := httptest.NewRecorder()
+			// act
+			newTestRouter().ServeHTTP(rr, req)
+			// assert
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			if tc.expectedCode != http.StatusNoContent {
+				assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+				return
+			}
+			assert.Equal(t, tc.origin, rr.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tc.expectedMethods, rr.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, "600", rr.Header().Get("Access-Control-Max-Age"))
+		})
+	}
+}
+
+func TestPolicy_ActualRequest(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/student/1", nil)
+	req.Header.Set("Origin", "https://admin.example.com")
+	rr := httptest.NewRecorder()
+
+	newTestRouter().ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "https://admin.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "RateLimit-Remaining", rr.Header().Get("Access-Control-Expose-Headers"))
+	assert.Equal(t, "Origin", rr.Header().Get("Vary"))
+}
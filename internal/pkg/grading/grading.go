@@ -0,0 +1,90 @@
+// Package grading converts numeric scores to letter grades on configurable scales and computes
+// credit-weighted grade point averages.
+package grading
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrUnknownScale is returned when a grade names a scale that is not configured.
+var ErrUnknownScale = errors.New("unknown grading scale")
+
+// Band maps every score of at least MinScore to a letter worth Points grade points.
+type Band struct {
+	Letter   string
+	MinScore float64
+	Points   float64
+}
+
+// Scale is a named list of bands, ordered by descending MinScore. The last band starts at 0,
+// so that every score in 0..100 has a letter.
+type Scale struct {
+	Name  string
+	Bands []Band
+}
+
+// Grade returns the band of score: the first one whose MinScore it reaches.
+func (s Scale) Grade(score float64) Band {
+	for _, band := range s.Bands {
+		if score >= band.MinScore {
+			return band
+		}
+	}
+
+	return s.Bands[len(s.Bands)-1]
+}
+
+// Scales are the configured scales, one of which is the default.
+type Scales struct {
+	byName      map[string]Scale
+	defaultName string
+}
+
+// NewScales creates Scales from scales. defaultName must be the name of one of them.
+func NewScales(defaultName string, scales ...Scale) Scales {
+	byName := make(map[string]Scale, len(scales))
+	for _, scale := range scales {
+		byName[scale.Name] = scale
+	}
+
+	return Scales{byName: byName, defaultName: defaultName}
+}
+
+// Lookup returns the scale called name, or the default scale when name is empty.
+func (s Scales) Lookup(name string) (Scale, error) {
+	if name == "" {
+		name = s.defaultName
+	}
+
+	scale, ok := s.byName[name]
+	if !ok {
+		return Scale{}, fmt.Errorf("%w: %q", ErrUnknownScale, name)
+	}
+
+	return scale, nil
+}
+
+// Weighted is a grade worth Points grade points in a class of Credits credits.
+type Weighted struct {
+	Points  float64
+	Credits float64
+}
+
+// GPA returns the credit-weighted average of grades, rounded to two decimals, and the credits it covers.
+// ok is false when no grade carries credits.
+func GPA(grades []Weighted) (gpa, credits float64, ok bool) {
+	var points float64
+
+	for _, grade := range grades {
+		points += grade.Points * grade.Credits
+		credits += grade.Credits
+	}
+
+	if credits <= 0 {
+		return 0, 0, false
+	}
+
+	return math.Round(points/credits*100) / 100, credits, true
+}
@@ -0,0 +1,102 @@
+package grading
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var simple = Scale{
+	Name: "simple",
+	Bands: []Band{
+		{Letter: "A", MinScore: 90, Points: 4},
+		{Letter: "B", MinScore: 80, Points: 3},
+		{Letter: "C", MinScore: 70, Points: 2},
+		{Letter: "F", MinScore: 0, Points: 0},
+	},
+}
+
+func TestScale_Grade(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		score    float64
+		expected string
+	}{
+		{score: 100, expected: "A"},
+		{score: 90, expected: "A"},
+		{score: 89.99, expected: "B"},
+		{score: 70, expected: "C"},
+		{score: 0, expected: "F"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.expected, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, simple.Grade(tc.score).Letter)
+		})
+	}
+}
+
+func TestScales_Lookup(t *testing.T) {
+	t.Parallel()
+
+	scales := NewScales("simple", simple)
+
+	scale, err := scales.Lookup("")
+	require.NoError(t, err)
+	assert.Equal(t, "simple", scale.Name)
+
+	_, err = scales.Lookup("ib")
+	assert.ErrorIs(t, err, ErrUnknownScale)
+}
+
+func TestGPA(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		grades   []Weighted
+		gpa      float64
+		credits  float64
+		expected bool
+	}{
+		{
+			name:     "weighted by credits",
+			grades:   []Weighted{{Points: 4, Credits: 3}, {Points: 3, Credits: 1}},
+			gpa:      3.75,
+			credits:  4,
+			expected: true,
+		},
+		{
+			name:     "rounded",
+			grades:   []Weighted{{Points: 4, Credits: 1}, {Points: 3.7, Credits: 1}, {Points: 3.3, Credits: 1}},
+			gpa:      3.67,
+			credits:  3,
+			expected: true,
+		},
+		{
+			name:   "no credits",
+			grades: []Weighted{{Points: 4, Credits: 0}},
+		},
+		{
+			name: "no grades",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gpa, credits, ok := GPA(tc.grades)
+
+			assert.Equal(t, tc.expected, ok)
+			assert.Equal(t, tc.gpa, gpa)
+			assert.Equal(t, tc.credits, credits)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"CRUD_Go_Backend/internal/config"
+	"io"
+	"log/slog"
+)
+
+// New builds a structured logger from the logging configuration.
+func New(w io.Writer, cfg config.LogConfig) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(cfg.Level)}
+
+	if cfg.Format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// Setup installs the logger as the slog default, which also routes the standard log package through it.
+func Setup(w io.Writer, cfg config.LogConfig) *slog.Logger {
+	logger := New(w, cfg)
+	slog.SetDefault(logger)
+
+	return logger
+}
+
+func parseLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of HTTP requests by route template, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by route template and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	apiVersionRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_api_version_requests_total",
+		Help: "Number of API requests by version, client and whether the version is deprecated.",
+	}, []string{"version", "client", "deprecated"})
+)
+
+// Handler exposes the default Prometheus registry.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveAPIVersion counts a request to an API version. client should be a stable caller
+// identity from a bounded set, such as an API key, so that the callers of old versions can be found.
+func ObserveAPIVersion(version, client string, deprecated bool) {
+	apiVersionRequestsTotal.WithLabelValues(version, client, strconv.FormatBool(deprecated)).Inc()
+}
+
+// Middleware records request count and latency labelled by the matched mux route template,
+// so that path variables do not create unbounded label values.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		route := "unmatched"
+		if current := mux.CurrentRoute(req); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		recorder := &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+		start := time.Now()
+
+		next.ServeHTTP(recorder, req)
+
+		requestDuration.WithLabelValues(route, req.Method).Observe(time.Since(start).Seconds())
+		requestsTotal.WithLabelValues(route, req.Method, strconv.Itoa(recorder.Status)).Inc()
+	})
+}
+
+// StatusRecorder remembers the status code written by the wrapped handler.
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status int
+}
+
+func (r *StatusRecorder) WriteHeader(status int) {
+	r.Status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,74 @@
+// Package pii masks personal data before it is written to logs.
+package pii
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgconn"
+)
+
+const mask = "***"
+
+// Name keeps the first character of each word of name, such as "J*** D***" for "Jane Doe".
+func Name(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		r, _ := utf8.DecodeRuneInString(word)
+		words[i] = string(r) + mask
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Email keeps the first character of the local part and the domain, such as "j***@example.com".
+func Email(email string) string {
+	if email == "" {
+		return ""
+	}
+
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 {
+		return mask
+	}
+
+	r, _ := utf8.DecodeRuneInString(email)
+
+	return string(r) + mask + email[at:]
+}
+
+// Phone keeps the last two digits of phone, such as "***67" for "+442071234567".
+func Phone(phone string) string {
+	if phone == "" {
+		return ""
+	}
+
+	if len(phone) <= 4 {
+		return mask
+	}
+
+	return mask + phone[len(phone)-2:]
+}
+
+// Error describes err without the values Postgres echoes in its messages and details, such as the email
+// of a unique violation. Postgres errors keep their SQLSTATE and the table, column and constraint involved.
+func Error(err error) string {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err.Error()
+	}
+
+	parts := []string{"SQLSTATE " + pgErr.Code}
+	for _, part := range []struct{ name, value string }{
+		{"table", pgErr.TableName},
+		{"column", pgErr.ColumnName},
+		{"constraint", pgErr.ConstraintName},
+	} {
+		if part.value != "" {
+			parts = append(parts, part.name+" "+part.value)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,67 @@
+package pii
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		mask        func(string) string
+		value       string
+		expected    string
+	}{
+		{"name", Name, "Jane  Doe", "J*** D***"},
+		{"non-ASCII name", Name, "Émile Zola", "É*** Z***"},
+		{"email", Email, "jane.doe@example.com", "j***@example.com"},
+		{"email without local part", Email, "@example.com", "***"},
+		{"phone", Phone, "+442071234567", "***67"},
+		{"short phone", Phone, "+123", "***"},
+		{"empty email", Email, "", ""},
+		{"empty phone", Phone, "", ""},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, tc.mask(tc.value))
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	t.Parallel()
+
+	unique := &pgconn.PgError{
+		Code:           "23505",
+		Message:        `duplicate key value violates unique constraint "student_email_key"`,
+		Detail:         "Key (email)=(jane.doe@example.com) already exists.",
+		TableName:      "student",
+		ConstraintName: "student_email_key",
+	}
+
+	tests := []struct {
+		description string
+		err         error
+		expected    string
+	}{
+		{"Postgres error", fmt.Errorf("add student: %w", unique), "SQLSTATE 23505, table student, constraint student_email_key"},
+		{"Postgres error without names", &pgconn.PgError{Code: "22007", Message: `invalid input syntax for type date: "1990-13-45"`}, "SQLSTATE 22007"},
+		{"other error", assert.AnError, assert.AnError.Error()},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, Error(tc.err))
+		})
+	}
+}
@@ -3,9 +3,22 @@ package pkgErrors
 import "errors"
 
 var (
-	ErrNotFound         = errors.New("Not Found")
-	ErrDbConfigNotFound = errors.New("one or more database configuration parameters are empty")
-	ErrInvalidName      = errors.New("Invalid Data")
-	ErrForeignKey       = errors.New("ERROR: insert or update on table \"class_info\" violates foreign key constraint \"fk_student\" (SQLSTATE 23503)")
-	ErrParse            = errors.New("Could not get DB_PORT:")
+	ErrNotFound          = errors.New("Not Found")
+	ErrDbConfigNotFound  = errors.New("one or more database configuration parameters are empty")
+	ErrInvalidName       = errors.New("Invalid Data")
+	ErrForeignKey        = errors.New("ERROR: insert or update on table \"class_info\" violates foreign key constraint \"fk_student\" (SQLSTATE 23503)")
+	ErrReferenceNotFound = errors.New("Referenced record not found")
+	ErrUnknownField      = errors.New("Unknown field")
+	ErrConflict          = errors.New("Conflicts with an existing record")
+	ErrTermClosed        = errors.New("Academic term is closed")
+	ErrNoCurrentTerm     = errors.New("No academic term covers the current date")
+	ErrWaitlisted        = errors.New("Enrollment is waitlisted")
+	ErrPrerequisiteCycle = errors.New("Prerequisites would form a cycle")
+	ErrNotEnrolled       = errors.New("Student is not enrolled in the class")
+	ErrRoomBooked        = errors.New("Room is booked at the same time")
+	ErrTeacherBooked     = errors.New("Teacher teaches another class at the same time")
+	ErrStudentBooked     = errors.New("Student has another class at the same time")
+	ErrScoreAboveMax     = errors.New("Score is above the maximum points of the assignment")
+	ErrStatusTransition  = errors.New("Status cannot change this way")
+	ErrParse             = errors.New("Could not get DB_PORT:")
 )
@@ -0,0 +1,76 @@
+// Package problem writes RFC 7807 "application/problem+json" error responses,
+// the standard error format of the middleware chain.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const ContentType = "application/problem+json"
+
+// Details is the body of a problem response. Extensions carries optional extra members.
+type Details struct {
+	Type       string                 `json:"type"`
+	Title      string                 `json:"title"`
+	Status     int                    `json:"status"`
+	Detail     string                 `json:"detail,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON flattens Extensions into the top-level object, as RFC 7807 requires.
+func (d Details) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(d.Extensions)+5)
+	for key, value := range d.Extensions {
+		members[key] = value
+	}
+
+	members["type"] = d.Type
+	members["title"] = d.Title
+	members["status"] = d.Status
+
+	if d.Detail != "" {
+		members["detail"] = d.Detail
+	}
+
+	if d.Instance != "" {
+		members["instance"] = d.Instance
+	}
+
+	return json.Marshal(members)
+}
+
+// New creates a problem of type "about:blank" titled after the status code.
+func New(status int, detail string) Details {
+	return Details{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Write sends a problem with the given status and detail.
+func Write(w http.ResponseWriter, req *http.Request, status int, detail string) {
+	WriteDetails(w, req, New(status, detail))
+}
+
+// WriteDetails sends p, filling Instance with the request path when it is empty.
+func WriteDetails(w http.ResponseWriter, req *http.Request, p Details) {
+	if p.Instance == "" && req != nil {
+		p.Instance = req.URL.Path
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+
+	_, _ = w.Write(body)
+}
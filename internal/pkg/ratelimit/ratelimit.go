@@ -0,0 +1,186 @@
+// Package ratelimit throttles clients with token buckets and reports their state
+// in the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"CRUD_Go_Backend/internal/auth"
+	"CRUD_Go_Backend/internal/pkg/problem"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/time/rate"
+)
+
+// idleTimeout is how long an unused bucket is kept. A bucket idle this long is full again,
+// so dropping it does not change any client's limit.
+const idleTimeout = 10 * time.Minute
+
+// Limit is a token bucket refilled at RequestsPerSecond that holds at most Burst requests.
+type Limit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// RouteLimit overrides the default Limit for requests matching Method and the mux route template Path.
+// Method "*" matches every method.
+type RouteLimit struct {
+	Method string
+	Path   string
+	Limit  Limit
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter keeps one bucket per client for the default limit, and one per client and route
+// for every route with its own limit.
+type Limiter struct {
+	defaultLimit Limit
+	routes       map[string]Limit
+	key          func(*http.Request) string
+	now          func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// New creates a new Limiter that keys clients by principal.
+func New(defaultLimit Limit, routes []RouteLimit) *Limiter {
+	byRoute := make(map[string]Limit, len(routes))
+	for _, route := range routes {
+		byRoute[route.Method+" "+route.Path] = route.Limit
+	}
+
+	return &Limiter{
+		defaultLimit: defaultLimit,
+		routes:       byRoute,
+		key:          clientKey,
+		now:          time.Now,
+		buckets:      make(map[string]*bucket),
+	}
+}
+
+// NewPerIP creates a Limiter that keys every request by remote IP address, whoever it claims to be.
+// It runs before authentication, so that requests without valid credentials are throttled too.
+func NewPerIP(limit Limit) *Limiter {
+	limiter := New(limit, nil)
+	limiter.key = ipKey
+
+	return limiter
+}
+
+// Middleware takes a token for every request and rejects the request with 429 when the bucket is empty.
+// A Limiter from New must run after authentication so that authenticated clients are keyed by principal
+// rather than IP.
+func (l *Limiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		routeKey, limit := l.limitFor(req)
+		now := l.now()
+
+		limiter := l.bucket(l.key(req)+"|"+routeKey, limit, now)
+		allowed := limiter.AllowN(now, 1)
+		tokens := limiter.TokensAt(now)
+
+		header := w.Header()
+		header.Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		header.Set("RateLimit-Remaining", strconv.Itoa(int(math.Max(0, math.Floor(tokens)))))
+
+		if !allowed {
+			retryAfter := secondsUntil(1-tokens, limit.RequestsPerSecond)
+			header.Set("RateLimit-Reset", strconv.Itoa(retryAfter))
+			header.Set("Retry-After", strconv.Itoa(retryAfter))
+			problem.Write(w, req, http.StatusTooManyRequests,
+				fmt.Sprintf("rate limit of %g requests per second exceeded, retry in %d seconds", limit.RequestsPerSecond, retryAfter))
+
+			return
+		}
+
+		header.Set("RateLimit-Reset", strconv.Itoa(secondsUntil(float64(limit.Burst)-tokens, limit.RequestsPerSecond)))
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// limitFor returns the bucket name and limit of the matched route. Routes without
+// their own limit share the "*" bucket.
+func (l *Limiter) limitFor(req *http.Request) (string, Limit) {
+	route := mux.CurrentRoute(req)
+	if route == nil {
+		return "*", l.defaultLimit
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return "*", l.defaultLimit
+	}
+
+	for _, key := range []string{req.Method + " " + template, "* " + template} {
+		if limit, ok := l.routes[key]; ok {
+			return key, limit
+		}
+	}
+
+	return "*", l.defaultLimit
+}
+
+func (l *Limiter) bucket(key string, limit Limit, now time.Time) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > idleTimeout {
+		for k, b := range l.buckets {
+			if now.Sub(b.lastSeen) > idleTimeout {
+				delete(l.buckets, k)
+			}
+		}
+
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.Burst)}
+		l.buckets[key] = b
+	}
+
+	b.lastSeen = now
+
+	return b.limiter
+}
+
+// clientKey identifies the caller by principal subject, which is "apikey:<id>" for API keys,
+// and falls back to the remote IP address.
+func clientKey(req *http.Request) string {
+	if principal, ok := auth.PrincipalFromContext(req.Context()); ok {
+		return principal.Subject
+	}
+
+	return ipKey(req)
+}
+
+func ipKey(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+func secondsUntil(tokens, perSecond float64) int {
+	if tokens <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(tokens / perSecond))
+}
@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"CRUD_Go_Backend/internal/auth"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRouter(limiter *Limiter) *mux.Router {
+	router := mux.NewRouter()
+	router.Use(limiter.Middleware)
+
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+	router.HandleFunc("/student", ok).Methods(http.MethodPost)
+	router.HandleFunc("/student/{id:[0-9]+}", ok).Methods(http.MethodGet)
+
+	return router
+}
+
+func request(method, path, remoteAddr, subject string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = remoteAddr
+
+	if subject != "" {
+		req = req.WithContext(auth.WithPrincipal(req.Context(), auth.Principal{Subject: subject}))
+	}
+
+	return req
+}
+
+func TestLimiter_Middleware(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	limiter := New(Limit{RequestsPerSecond: 1, Burst: 2}, []RouteLimit{
+		{Method: http.MethodPost, Path: "/student", Limit: Limit{RequestsPerSecond: 0.5, Burst: 1}},
+	})
+	limiter.now = func() time.Time { return now }
+	router := newTestRouter(limiter)
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	t.Run("default limit per client", func(t *testing.T) {
+		first := serve(request(http.MethodGet, "/student/1", "10.0.0.1:1234", ""))
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", first.Header().Get("RateLimit-Reset"))
+
+		// path variables share the route bucket
+		assert.Equal(t, http.StatusOK, serve(request(http.MethodGet, "/student/2", "10.0.0.1:4321", "")).Code)
+
+		rejected := serve(request(http.MethodGet, "/student/3", "10.0.0.1:1234", ""))
+		assert.Equal(t, http.StatusTooManyRequests, rejected.Code)
+		assert.Equal(t, "0", rejected.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "1", rejected.Header().Get("Retry-After"))
+
+		// another client has its own bucket
+		assert.Equal(t, http.StatusOK, serve(request(http.MethodGet, "/student/1", "10.0.0.2:1234", "")).Code)
+	})
+
+	t.Run("principal is keyed by subject", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(request(http.MethodGet, "/student/1", "10.0.0.3:1", "apikey:1")).Code)
+		assert.Equal(t, http.StatusOK, serve(request(http.MethodGet, "/student/1", "10.0.0.4:1", "apikey:1")).Code)
+		assert.Equal(t, http.StatusTooManyRequests, serve(request(http.MethodGet, "/student/1", "10.0.0.5:1", "apikey:1")).Code)
+	})
+
+	t.Run("route override", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(request(http.MethodPost, "/student", "10.0.0.6:1", "")).Code)
+
+		rejected := serve(request(http.MethodPost, "/student", "10.0.0.6:1", ""))
+		assert.Equal(t, http.StatusTooManyRequests, rejected.Code)
+		assert.Equal(t, "1", rejected.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "2", rejected.Header().Get("Retry-After"))
+
+		// the default bucket of the same client is untouched
+		assert.Equal(t, http.StatusOK, serve(request(http.MethodGet, "/student/1", "10.0.0.6:1", "")).Code)
+
+		now = now.Add(2 * time.Second)
+		assert.Equal(t, http.StatusOK, serve(request(http.MethodPost, "/student", "10.0.0.6:1", "")).Code)
+	})
+}
+
+func TestLimiter_PerIP(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	limiter := NewPerIP(Limit{RequestsPerSecond: 1, Burst: 1})
+	limiter.now = func() time.Time { return now }
+	router := newTestRouter(limiter)
+
+	serve := func(req *http.Request) int {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		return rr.Code
+	}
+
+	// arrange
+	assert.Equal(t, http.StatusOK, serve(request(http.MethodGet, "/student/1", "10.0.0.1:1", "apikey:1")))
+
+	// act
+	// a different principal from the same address shares the bucket
+	code := serve(request(http.MethodGet, "/student/1", "10.0.0.1:2", "apikey:2"))
+
+	// assert
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	assert.Equal(t, http.StatusOK, serve(request(http.MethodGet, "/student/1", "10.0.0.2:1", "apikey:1")))
+}
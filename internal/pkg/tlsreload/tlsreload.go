@@ -0,0 +1,172 @@
+// Package tlsreload serves TLS with a certificate and client CA bundle that are reloaded
+// from disk without restarting the server.
+package tlsreload
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Options names the files to load and how to verify clients.
+type Options struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is a PEM bundle of CAs that sign client certificates. Required unless ClientAuth is tls.NoClientCert.
+	ClientCAFile string
+	ClientAuth   tls.ClientAuthType
+}
+
+// Reloader holds the current certificate and client CA pool.
+type Reloader struct {
+	options Options
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+	modTimes map[string]time.Time
+}
+
+// New loads the files once and fails if they cannot be used.
+func New(options Options) (*Reloader, error) {
+	r := &Reloader{options: options}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload reads the files again. On error the previous certificate stays in use.
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.options.CertFile, r.options.KeyFile)
+	if err != nil {
+		return fmt.Errorf("could not load TLS certificate: %w", err)
+	}
+
+	var clientCA *x509.CertPool
+
+	if r.options.ClientCAFile != "" {
+		bundle, err := os.ReadFile(r.options.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("could not read client CA bundle: %w", err)
+		}
+
+		clientCA = x509.NewCertPool()
+		if !clientCA.AppendCertsFromPEM(bundle) {
+			return fmt.Errorf("client CA bundle %s contains no PEM certificates", r.options.ClientCAFile)
+		}
+	}
+
+	modTimes, err := r.currentModTimes()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cert = &cert
+	r.clientCA = clientCA
+	r.modTimes = modTimes
+
+	return nil
+}
+
+// TLSConfig returns a server configuration that picks up reloaded files on every handshake.
+// It advertises HTTP/2 and HTTP/1.1 through ALPN.
+func (r *Reloader) TLSConfig() *tls.Config {
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		ClientAuth: r.options.ClientAuth,
+	}
+
+	config := base.Clone()
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		current := base.Clone()
+		current.Certificates = []tls.Certificate{*r.cert}
+		current.ClientCAs = r.clientCA
+
+		return current, nil
+	}
+
+	return config
+}
+
+// Watch reloads the files whenever one of their modification times changes, checking every interval
+// until ctx is done. A failed reload, for example of a half-written file, is logged and retried on the next tick.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := r.changed()
+		if err != nil {
+			slog.Warn("could not check TLS files for changes", "error", err)
+			continue
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err := r.Reload(); err != nil {
+			slog.Error("TLS reload failed, keeping the previous certificate", "error", err)
+			continue
+		}
+
+		slog.Info("TLS certificate reloaded", "cert_file", r.options.CertFile)
+	}
+}
+
+func (r *Reloader) changed() (bool, error) {
+	modTimes, err := r.currentModTimes()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for file, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[file]) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *Reloader) currentModTimes() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, 3)
+
+	for _, file := range []string{r.options.CertFile, r.options.KeyFile, r.options.ClientCAFile} {
+		if file == "" {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not stat TLS file: %w", err)
+		}
+
+		modTimes[file] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
@@ -0,0 +1,113 @@
+package tlsreload
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCert writes a self-signed certificate for commonName and its key, and returns their paths.
+func writeCert(t *testing.T, dir, commonName string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func servedCommonName(t *testing.T, config *tls.Config) string {
+	t.Helper()
+
+	current, err := config.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(current.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+
+	return leaf.Subject.CommonName
+}
+
+func TestReloader(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "first")
+
+	reloader, err := New(Options{CertFile: certFile, KeyFile: keyFile, ClientCAFile: certFile, ClientAuth: tls.RequireAndVerifyClientCert})
+	require.NoError(t, err)
+
+	config := reloader.TLSConfig()
+	assert.Equal(t, []string{"h2", "http/1.1"}, config.NextProtos)
+	assert.Equal(t, "first", servedCommonName(t, config))
+
+	current, err := config.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, current.ClientAuth)
+	assert.NotNil(t, current.ClientCAs)
+
+	t.Run("reload keeps the old certificate on error", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(keyFile, []byte("garbage"), 0o600))
+		assert.Error(t, reloader.Reload())
+		assert.Equal(t, "first", servedCommonName(t, config))
+	})
+
+	t.Run("watch picks up changed files", func(t *testing.T) {
+		writeCert(t, dir, "second")
+		future := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(certFile, future, future))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go reloader.Watch(ctx, 10*time.Millisecond)
+
+		assert.Eventually(t, func() bool {
+			return servedCommonName(t, config) == "second"
+		}, 2*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestNew_InvalidCABundle(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "server")
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("not pem"), 0o600))
+
+	_, err := New(Options{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile})
+	assert.Error(t, err)
+}
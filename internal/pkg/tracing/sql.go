@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SanitizeSQL collapses whitespace and replaces string and numeric literals with "?",
+// so that statements can be attached to spans without leaking values.
+// Positional parameters such as $1 are kept as is.
+func SanitizeSQL(query string) string {
+	var (
+		builder strings.Builder
+		runes   = []rune(query)
+		space   bool
+	)
+
+	builder.Grow(len(query))
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		case r == '\'':
+			for i++; i < len(runes); i++ {
+				if runes[i] != '\'' {
+					continue
+				}
+
+				if i+1 < len(runes) && runes[i+1] == '\'' {
+					i++
+					continue
+				}
+
+				break
+			}
+
+			r = '?'
+		case unicode.IsDigit(r) && !isIdentifierTail(runes, i):
+			for i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '.') {
+				i++
+			}
+
+			r = '?'
+		}
+
+		if space && builder.Len() > 0 {
+			builder.WriteRune(' ')
+		}
+
+		space = false
+
+		builder.WriteRune(r)
+	}
+
+	return strings.TrimSuffix(builder.String(), ";")
+}
+
+// isIdentifierTail reports whether the digit at position i belongs to an identifier or a $n parameter.
+func isIdentifierTail(runes []rune, i int) bool {
+	if i == 0 {
+		return false
+	}
+
+	prev := runes[i-1]
+
+	return prev == '$' || prev == '_' || unicode.IsLetter(prev) || unicode.IsDigit(prev)
+}
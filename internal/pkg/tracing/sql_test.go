@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeSQL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		query       string
+		expected    string
+	}{
+		{
+			description: "Keeps positional parameters",
+			query:       `SELECT id, student_id, class_name FROM class_info WHERE student_id=$1;`,
+			expected:    `SELECT id, student_id, class_name FROM class_info WHERE student_id=$1`,
+		},
+		{
+			description: "Collapses whitespace",
+			query: `
+		UPDATE student
+		SET student_name = $2, grade = $3
+		WHERE student_id = $1
+	`,
+			expected: `UPDATE student SET student_name = $2, grade = $3 WHERE student_id = $1`,
+		},
+		{
+			description: "Replaces literals",
+			query:       `SELECT * FROM student WHERE student_name = 'O''Brien' AND grade > 90.5 LIMIT 10`,
+			expected:    `SELECT * FROM student WHERE student_name = ? AND grade > ? LIMIT ?`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, SanitizeSQL(tc.query))
+		})
+	}
+}
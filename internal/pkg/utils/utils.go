@@ -9,3 +9,14 @@ func Map[T1, T2 any](items []T1, mapper func(item T1) T2) []T2 {
 
 	return result
 }
+
+// Contains сообщает, есть ли `value` среди `items`
+func Contains[T comparable](items []T, value T) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
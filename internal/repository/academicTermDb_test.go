@@ -0,0 +1,161 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+// addCurrentTerm creates an open term around today, which class_info rows default to.
+func addCurrentTerm(ctx context.Context, t *testing.T, db *postgres.TDB) int64 {
+	t.Helper()
+
+	today := time.Now()
+	termRepo := NewAcademicTermStorage(db.DB)
+	termID, err := termRepo.Add(ctx, models.AcademicTerm{
+		Name:      "Current",
+		StartDate: today.AddDate(0, -1, 0).Format(models.DateLayout),
+		EndDate:   today.AddDate(0, 1, 0).Format(models.DateLayout),
+		Status:    models.TermStatusOpen,
+	})
+	require.NoError(t, err)
+
+	return termID
+}
+
+func TestAcademicTerm(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Current", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termRepo := NewAcademicTermStorage(db.DB)
+		_, err := termRepo.Add(ctx, models.AcademicTerm{
+			Name: "Past", StartDate: "2001-09-01", EndDate: "2001-12-20", Status: models.TermStatusClosed,
+		})
+		require.NoError(t, err)
+		currentID := addCurrentTerm(ctx, t, db)
+		//act
+		current, err := termRepo.GetCurrent(ctx)
+		require.NoError(t, err)
+		terms, err := termRepo.List(ctx)
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, currentID, current.TermID)
+		require.Len(t, terms, 2)
+		assert.Equal(t, "Past", terms[0].Name)
+	})
+	t.Run("No current term", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termRepo := NewAcademicTermStorage(db.DB)
+		studentRepo := NewStudentStorage(db.DB)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		//act
+		_, getErr := termRepo.GetCurrent(ctx)
+		_, addErr := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+		//assert
+		assert.ErrorIs(t, getErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, addErr, pkgErrors.ErrNoCurrentTerm)
+	})
+	t.Run("Legacy term between terms", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termRepo := NewAcademicTermStorage(db.DB)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		_, noTermErr := classInfoRepo.LegacyTermID(ctx)
+		_, err := termRepo.Add(ctx, models.AcademicTerm{
+			Name: "Spring", StartDate: "2001-01-10", EndDate: "2001-05-20", Status: models.TermStatusOpen,
+		})
+		require.NoError(t, err)
+		fallID, err := termRepo.Add(ctx, models.AcademicTerm{
+			Name: "Fall", StartDate: "2001-09-01", EndDate: "2001-12-20", Status: models.TermStatusOpen,
+		})
+		require.NoError(t, err)
+		_, err = termRepo.Add(ctx, models.AcademicTerm{
+			Name: "Next", StartDate: "2999-01-10", EndDate: "2999-05-20", Status: models.TermStatusOpen,
+		})
+		require.NoError(t, err)
+		//act
+		termID, err := classInfoRepo.LegacyTermID(ctx)
+		//assert
+		assert.ErrorIs(t, noTermErr, pkgErrors.ErrNoCurrentTerm)
+		require.NoError(t, err)
+		assert.Equal(t, fallID, termID)
+	})
+	t.Run("Overlapping terms", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termRepo := NewAcademicTermStorage(db.DB)
+		_, err := termRepo.Add(ctx, models.AcademicTerm{
+			Name: "Fall", StartDate: "2026-09-01", EndDate: "2026-12-20", Status: models.TermStatusPlanned,
+		})
+		require.NoError(t, err)
+		//act
+		_, err = termRepo.Add(ctx, models.AcademicTerm{
+			Name: "Winter", StartDate: "2026-12-01", EndDate: "2027-02-20", Status: models.TermStatusPlanned,
+		})
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrConflict)
+	})
+}
+
+func TestClosedTermFreezesEnrollments(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Success", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termRepo := NewAcademicTermStorage(db.DB)
+		studentRepo := NewStudentStorage(db.DB)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		termID := addCurrentTerm(ctx, t, db)
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		classInfoID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+		require.NoError(t, err)
+		term, err := termRepo.GetByID(ctx, termID)
+		require.NoError(t, err)
+		term.Status = models.TermStatusClosed
+		require.NoError(t, termRepo.Update(ctx, termID, term))
+		//act
+		_, addErr := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Art", TermID: termID})
+		updateErr := classInfoRepo.UpdateByID(ctx, classInfoID, models.ClassInfo{ClassName: "Algebra"})
+		deleteErr := classInfoRepo.DeleteByID(ctx, classInfoID)
+		termErr := termRepo.Update(ctx, termID, term)
+		classes, err := classInfoRepo.GetByStudentIDInTerm(ctx, studentID, nil)
+		//assert
+		require.NoError(t, err)
+		assert.ErrorIs(t, addErr, pkgErrors.ErrTermClosed)
+		assert.ErrorIs(t, updateErr, pkgErrors.ErrTermClosed)
+		assert.ErrorIs(t, deleteErr, pkgErrors.ErrTermClosed)
+		assert.ErrorIs(t, termErr, pkgErrors.ErrTermClosed)
+		require.Len(t, classes, 1)
+		assert.Equal(t, termID, classes[0].TermID)
+	})
+}
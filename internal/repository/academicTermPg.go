@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
+)
+
+// exclusionViolation is the SQLSTATE raised when the dates of a term overlap another term.
+const exclusionViolation = "23P01"
+
+// currentTermQuery selects the id of the term whose dates cover today. Terms cannot overlap,
+// so there is at most one.
+const currentTermQuery = `SELECT term_id FROM academic_term WHERE CURRENT_DATE BETWEEN start_date AND end_date`
+
+// legacyTermQuery selects the current term or, when no term covers today, the open term that started
+// and ended last. v1 clients cannot choose a term, so their enrollments go there between terms.
+const legacyTermQuery = `SELECT COALESCE((` + currentTermQuery + `), (
+	SELECT term_id FROM academic_term WHERE status = 'open' AND start_date <= CURRENT_DATE ORDER BY end_date DESC LIMIT 1
+))`
+
+const academicTermColumns = `term_id, name, start_date, end_date, status, created_at`
+
+type AcademicTermStorage struct {
+	db connection.DBops
+}
+
+func NewAcademicTermStorage(database connection.DBops) AcademicTermStorage {
+	return AcademicTermStorage{db: database}
+}
+
+// Add creates a term. It returns ErrConflict when the name is taken or the dates overlap another term.
+func (r *AcademicTermStorage) Add(ctx context.Context, term models.AcademicTerm) (int64, error) {
+	ctx, span := tracer.Start(ctx, "AcademicTermStorage.Add")
+	defer span.End()
+
+	var termID int64
+
+	err := r.db.ExecQueryRow(ctx, `
+		INSERT INTO academic_term(name, start_date, end_date, status) VALUES($1, $2, $3, $4) RETURNING term_id;
+	`, term.Name, term.StartDate, term.EndDate, term.Status).Scan(&termID)
+	if err != nil {
+		return -1, academicTermError(err)
+	}
+
+	return termID, nil
+}
+
+func (r *AcademicTermStorage) GetByID(ctx context.Context, termID int64) (models.AcademicTerm, error) {
+	ctx, span := tracer.Start(ctx, "AcademicTermStorage.GetByID")
+	defer span.End()
+
+	return r.get(ctx, `SELECT `+academicTermColumns+` FROM academic_term WHERE term_id = $1;`, termID)
+}
+
+// GetCurrent returns the term whose dates cover today, or ErrNotFound.
+func (r *AcademicTermStorage) GetCurrent(ctx context.Context) (models.AcademicTerm, error) {
+	ctx, span := tracer.Start(ctx, "AcademicTermStorage.GetCurrent")
+	defer span.End()
+
+	return r.get(ctx, `SELECT `+academicTermColumns+` FROM academic_term WHERE term_id = (`+currentTermQuery+`);`)
+}
+
+func (r *AcademicTermStorage) get(ctx context.Context, query string, args ...interface{}) (models.AcademicTerm, error) {
+	var term entities.AcademicTerm
+
+	if err := r.db.Get(ctx, &term, query, args...); err != nil {
+		if pgxscan.NotFound(err) {
+			return models.AcademicTerm{}, pkgErrors.ErrNotFound
+		}
+
+		return models.AcademicTerm{}, err
+	}
+
+	return term.ToAcademicTermDomain(), nil
+}
+
+// selectTerm returns the term termID, or the current term when termID is nil. It returns ErrNoCurrentTerm
+// when no term covers today, and ErrNotFound for an unknown term.
+func selectTerm(ctx context.Context, db connection.DBops, termID *int64) (models.AcademicTerm, error) {
+	var term entities.AcademicTerm
+
+	query, args := `SELECT `+academicTermColumns+` FROM academic_term WHERE term_id = $1;`, []interface{}{termID}
+	if termID == nil {
+		query, args = `SELECT `+academicTermColumns+` FROM academic_term WHERE term_id = (`+currentTermQuery+`);`, nil
+	}
+
+	if err := db.Get(ctx, &term, query, args...); err != nil {
+		if !pgxscan.NotFound(err) {
+			return models.AcademicTerm{}, err
+		}
+
+		if termID == nil {
+			return models.AcademicTerm{}, pkgErrors.ErrNoCurrentTerm
+		}
+
+		return models.AcademicTerm{}, pkgErrors.ErrNotFound
+	}
+
+	return term.ToAcademicTermDomain(), nil
+}
+
+// List returns every term, oldest first.
+func (r *AcademicTermStorage) List(ctx context.Context) ([]models.AcademicTerm, error) {
+	ctx, span := tracer.Start(ctx, "AcademicTermStorage.List")
+	defer span.End()
+
+	var terms []entities.AcademicTerm
+
+	if err := r.db.Select(ctx, &terms, `SELECT `+academicTermColumns+` FROM academic_term ORDER BY start_date;`); err != nil {
+		return nil, err
+	}
+
+	return utils.Map(terms, func(t entities.AcademicTerm) models.AcademicTerm {
+		return t.ToAcademicTermDomain()
+	}), nil
+}
+
+// Update changes a term that is not closed. It returns ErrTermClosed for a closed term, so that closing
+// a term is final, and ErrConflict when the new name or dates clash with another term.
+func (r *AcademicTermStorage) Update(ctx context.Context, termID int64, term models.AcademicTerm) error {
+	ctx, span := tracer.Start(ctx, "AcademicTermStorage.Update")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, `
+		UPDATE academic_term
+		SET name = $2, start_date = $3, end_date = $4, status = $5
+		WHERE term_id = $1 AND status <> 'closed'
+	`, termID, term.Name, term.StartDate, term.EndDate, term.Status)
+	if err != nil {
+		return academicTermError(err)
+	}
+
+	if command.RowsAffected() == 0 {
+		if _, err := r.GetByID(ctx, termID); err != nil {
+			return err
+		}
+
+		return pkgErrors.ErrTermClosed
+	}
+
+	return nil
+}
+
+func academicTermError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (pgErr.Code == uniqueViolation || pgErr.Code == exclusionViolation) {
+		return pkgErrors.ErrConflict
+	}
+
+	return err
+}
@@ -0,0 +1,66 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+func TestAPIKey(t *testing.T) {
+
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+		keyHash       = []byte("0123456789abcdef0123456789abcdef")
+	)
+	t.Run("Success", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		apiKeyRepo := NewAPIKeyStorage(db.DB)
+		//act
+		id, err := apiKeyRepo.Add(ctx, models.APIKey{Name: "reporting", Prefix: "crud_abc"}, keyHash)
+		//assert
+		require.NoError(t, err)
+		assert.NotZero(t, id)
+		//act
+		apiKey, err := apiKeyRepo.GetActiveByHash(ctx, keyHash)
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, id, apiKey.ID)
+		assert.Equal(t, "reporting", apiKey.Name)
+		assert.NotNil(t, apiKey.LastUsedAt)
+		//act
+		apiKeys, err := apiKeyRepo.List(ctx)
+		//assert
+		require.NoError(t, err)
+		require.Equal(t, 1, len(apiKeys))
+		assert.Equal(t, "crud_abc", apiKeys[0].Prefix)
+	})
+	t.Run("Revoked key is not found", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		apiKeyRepo := NewAPIKeyStorage(db.DB)
+		id, err := apiKeyRepo.Add(ctx, models.APIKey{Name: "reporting", Prefix: "crud_abc"}, keyHash)
+		require.NoError(t, err)
+		//act
+		err = apiKeyRepo.Revoke(ctx, id)
+		//assert
+		require.NoError(t, err)
+		_, err = apiKeyRepo.GetActiveByHash(ctx, keyHash)
+		assert.ErrorIs(t, err, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, apiKeyRepo.Revoke(ctx, id), pkgErrors.ErrNotFound)
+	})
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+type APIKeyStorage struct {
+	db connection.DBops
+}
+
+func NewAPIKeyStorage(database connection.DBops) APIKeyStorage {
+	return APIKeyStorage{db: database}
+}
+
+func (r *APIKeyStorage) Add(ctx context.Context, apiKey models.APIKey, keyHash []byte) (int64, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyStorage.Add")
+	defer span.End()
+
+	var id int64
+
+	err := r.db.ExecQueryRow(ctx,
+		`INSERT INTO api_key(name, prefix, key_hash) VALUES($1, $2, $3) RETURNING id;`,
+		apiKey.Name,
+		apiKey.Prefix,
+		keyHash,
+	).Scan(&id)
+	if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+// GetActiveByHash returns the non-revoked key with the given hash and records its use.
+func (r *APIKeyStorage) GetActiveByHash(ctx context.Context, keyHash []byte) (models.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyStorage.GetActiveByHash")
+	defer span.End()
+
+	var apiKey entities.APIKey
+
+	err := r.db.Get(ctx, &apiKey, `
+		UPDATE api_key SET last_used_at = NOW()
+		WHERE key_hash = $1 AND revoked_at IS NULL
+		RETURNING id, name, prefix, key_hash, created_at, last_used_at, revoked_at;
+	`, keyHash)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.APIKey{}, pkgErrors.ErrNotFound
+		}
+
+		return models.APIKey{}, err
+	}
+
+	return apiKey.ToAPIKeyDomain(), nil
+}
+
+func (r *APIKeyStorage) List(ctx context.Context) ([]models.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyStorage.List")
+	defer span.End()
+
+	var apiKeys []entities.APIKey
+
+	err := r.db.Select(ctx, &apiKeys, `
+		SELECT id, name, prefix, key_hash, created_at, last_used_at, revoked_at
+		FROM api_key ORDER BY id;
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(apiKeys, func(k entities.APIKey) models.APIKey {
+		return k.ToAPIKeyDomain()
+	}), nil
+}
+
+func (r *APIKeyStorage) Revoke(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "APIKeyStorage.Revoke")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, `UPDATE api_key SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+// addAssignment publishes an assignment of a class in the current term, due dueIn from now, and returns its id.
+func addAssignment(ctx context.Context, t *testing.T, assignmentRepo AssignmentStorage, classID int64, dueIn time.Duration) int64 {
+	t.Helper()
+
+	assignmentID, err := assignmentRepo.Add(ctx, models.Assignment{
+		ClassID:   classID,
+		Title:     "Essay",
+		DueAt:     time.Now().Add(dueIn).Truncate(time.Second),
+		MaxPoints: 20,
+	})
+	require.NoError(t, err)
+
+	return assignmentID
+}
+
+func TestAssignment(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Late detection", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termID := addCurrentTerm(ctx, t, db)
+		classID := addClass(ctx, t, db, "Math", 30)
+		studentIDs := addStudents(ctx, t, db, 3)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		for _, studentID := range studentIDs {
+			_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+			require.NoError(t, err)
+		}
+		assignmentRepo := NewAssignmentStorage(db.DB)
+		assignmentID := addAssignment(ctx, t, assignmentRepo, classID, -24*time.Hour)
+		onTime := time.Now().Add(-48 * time.Hour)
+		afterDue := time.Now().Add(-time.Hour)
+		require.NoError(t, assignmentRepo.SetSubmission(ctx, models.Submission{
+			AssignmentID: assignmentID, StudentID: studentIDs[0], Status: models.SubmissionSubmitted, SubmittedAt: &onTime,
+		}))
+		require.NoError(t, assignmentRepo.SetSubmission(ctx, models.Submission{
+			AssignmentID: assignmentID, StudentID: studentIDs[1], Status: models.SubmissionSubmitted, SubmittedAt: &afterDue,
+		}))
+		//act
+		submissions, err := assignmentRepo.ListSubmissions(ctx, assignmentID)
+		//assert
+		require.NoError(t, err)
+		require.Len(t, submissions, 3)
+		late := make(map[int64]bool, len(submissions))
+		for _, submission := range submissions {
+			late[submission.StudentID] = submission.Late
+		}
+		assert.Equal(t, map[int64]bool{studentIDs[0]: false, studentIDs[1]: true, studentIDs[2]: true}, late)
+		assignment, err := assignmentRepo.GetByID(ctx, assignmentID)
+		require.NoError(t, err)
+		assert.Equal(t, termID, assignment.TermID)
+		require.NotNil(t, assignment.Weight)
+		assert.Equal(t, 1.0, *assignment.Weight)
+	})
+	t.Run("Not enrolled and score above max", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		classID := addClass(ctx, t, db, "Math", 30)
+		studentIDs := addStudents(ctx, t, db, 2)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[0], ClassName: "Math"})
+		require.NoError(t, err)
+		assignmentRepo := NewAssignmentStorage(db.DB)
+		assignmentID := addAssignment(ctx, t, assignmentRepo, classID, 24*time.Hour)
+		score := 15.0
+		require.NoError(t, assignmentRepo.SetSubmission(ctx, models.Submission{
+			AssignmentID: assignmentID, StudentID: studentIDs[0], Status: models.SubmissionMissing, Score: &score,
+		}))
+		tooHigh := 25.0
+		//act
+		notEnrolledErr := assignmentRepo.SetSubmission(ctx, models.Submission{
+			AssignmentID: assignmentID, StudentID: studentIDs[1], Status: models.SubmissionMissing,
+		})
+		aboveMaxErr := assignmentRepo.SetSubmission(ctx, models.Submission{
+			AssignmentID: assignmentID, StudentID: studentIDs[0], Status: models.SubmissionMissing, Score: &tooHigh,
+		})
+		updateErr := assignmentRepo.Update(ctx, assignmentID, models.Assignment{
+			Title: "Essay", DueAt: time.Now(), MaxPoints: 10,
+		})
+		//assert
+		assert.ErrorIs(t, notEnrolledErr, pkgErrors.ErrNotEnrolled)
+		assert.ErrorIs(t, aboveMaxErr, pkgErrors.ErrScoreAboveMax)
+		assert.ErrorIs(t, updateErr, pkgErrors.ErrScoreAboveMax)
+		_, err = assignmentRepo.GetSubmission(ctx, assignmentID, studentIDs[1])
+		assert.ErrorIs(t, err, pkgErrors.ErrNotEnrolled)
+	})
+	t.Run("Gradebook", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termID := addCurrentTerm(ctx, t, db)
+		classID := addClass(ctx, t, db, "Math", 30)
+		studentIDs := addStudents(ctx, t, db, 2)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		for _, studentID := range studentIDs {
+			_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+			require.NoError(t, err)
+		}
+		assignmentRepo := NewAssignmentStorage(db.DB)
+		firstID := addAssignment(ctx, t, assignmentRepo, classID, time.Hour)
+		secondID := addAssignment(ctx, t, assignmentRepo, classID, 2*time.Hour)
+		require.NoError(t, assignmentRepo.SetSubmission(ctx, models.Submission{
+			AssignmentID: secondID, StudentID: studentIDs[1], Status: models.SubmissionExcused,
+		}))
+		//act
+		term, assignments, submissions, err := assignmentRepo.GetGradebook(ctx, classID, &termID)
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, termID, term.TermID)
+		require.Len(t, assignments, 2)
+		assert.Equal(t, []int64{firstID, secondID}, []int64{assignments[0].AssignmentID, assignments[1].AssignmentID})
+		require.Len(t, submissions, 4)
+		statuses := make(map[[2]int64]string, len(submissions))
+		for _, submission := range submissions {
+			statuses[[2]int64{submission.StudentID, submission.AssignmentID}] = submission.Status
+		}
+		assert.Equal(t, models.SubmissionExcused, statuses[[2]int64{studentIDs[1], secondID}])
+		assert.Equal(t, models.SubmissionPending, statuses[[2]int64{studentIDs[0], firstID}])
+	})
+	t.Run("Unknown class and term", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termID := addCurrentTerm(ctx, t, db)
+		classID := addClass(ctx, t, db, "Math", 30)
+		assignmentRepo := NewAssignmentStorage(db.DB)
+		unknownTerm := termID + 1
+		//act
+		_, classErr := assignmentRepo.Add(ctx, models.Assignment{ClassID: 999, Title: "Essay", DueAt: time.Now(), MaxPoints: 20})
+		_, termErr := assignmentRepo.Add(ctx, models.Assignment{
+			ClassID: classID, TermID: unknownTerm, Title: "Essay", DueAt: time.Now(), MaxPoints: 20,
+		})
+		_, listErr := assignmentRepo.ListByClass(ctx, 999, nil)
+		//assert
+		assert.ErrorIs(t, classErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, termErr, pkgErrors.ErrReferenceNotFound)
+		assert.ErrorIs(t, listErr, pkgErrors.ErrNotFound)
+	})
+}
@@ -0,0 +1,365 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
+)
+
+const (
+	assignmentColumns = `assignment_id, class_id, term_id, title, description, due_at, max_points, weight`
+
+	// submissionColumns select a student, the submission of an assignment a if one was recorded,
+	// and whether it is late. a, ci, st and s are assignment, class_info, student and submission.
+	submissionColumns = `a.assignment_id, ci.student_id, st.student_name, ci.id AS class_info_id,
+		s.status, s.submitted_at, s.score, s.feedback,
+		COALESCE(s.submitted_at > a.due_at, s.status IS NULL AND NOW() > a.due_at, FALSE) AS late`
+
+	// submissionQuery selects the students enrolled in the class and term of assignment $1 with their submissions.
+	// $2 limits it to one student when not null.
+	submissionQuery = `
+		SELECT ` + submissionColumns + `
+		FROM assignment a
+		JOIN class c ON c.class_id = a.class_id
+		JOIN class_info ci ON ci.class_name = c.class_name AND ci.term_id = a.term_id AND ci.status = 'enrolled'
+		JOIN student st ON st.student_id = ci.student_id
+		LEFT JOIN submission s ON s.assignment_id = a.assignment_id AND s.class_info_id = ci.id
+		WHERE a.assignment_id = $1 AND ($2::BIGINT IS NULL OR ci.student_id = $2)
+		ORDER BY st.student_name, ci.student_id`
+)
+
+// AssignmentStorage keeps the assignments of classes and the submissions of the students enrolled in them.
+type AssignmentStorage struct {
+	db connection.DBops
+}
+
+func NewAssignmentStorage(database connection.DBops) AssignmentStorage {
+	return AssignmentStorage{db: database}
+}
+
+// assignmentError maps the errors of writing an assignment: ErrNotFound for an unknown class,
+// ErrReferenceNotFound for an unknown term, and ErrNoCurrentTerm when no term was given and none is current.
+func assignmentError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		if pgErr.ConstraintName == "fk_assignment_class" {
+			return pkgErrors.ErrNotFound
+		}
+
+		return pkgErrors.ErrReferenceNotFound
+	}
+
+	return classInfoError(err)
+}
+
+// Add publishes an assignment for a class in its term, or in the current term when TermID is 0.
+func (r *AssignmentStorage) Add(ctx context.Context, assignment models.Assignment) (int64, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentStorage.Add")
+	defer span.End()
+
+	var assignmentID int64
+
+	err := r.db.ExecQueryRow(ctx, `
+		INSERT INTO assignment(class_id, term_id, title, description, due_at, max_points, weight)
+		VALUES($1, COALESCE(NULLIF($2::BIGINT, 0), (`+currentTermQuery+`)), $3, $4, $5, $6, COALESCE($7, 1))
+		RETURNING assignment_id;
+	`, assignment.ClassID, assignment.TermID, assignment.Title, assignment.Description,
+		assignment.DueAt, assignment.MaxPoints, assignment.Weight).Scan(&assignmentID)
+	if err != nil {
+		return -1, assignmentError(err)
+	}
+
+	return assignmentID, nil
+}
+
+func (r *AssignmentStorage) GetByID(ctx context.Context, assignmentID int64) (models.Assignment, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentStorage.GetByID")
+	defer span.End()
+
+	var assignment entities.Assignment
+
+	err := r.db.Get(ctx, &assignment, `SELECT `+assignmentColumns+` FROM assignment WHERE assignment_id = $1;`, assignmentID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.Assignment{}, pkgErrors.ErrNotFound
+		}
+
+		return models.Assignment{}, err
+	}
+
+	return assignment.ToAssignmentDomain(), nil
+}
+
+// ListByClass returns the assignments of a class in a term by due date. A nil termID selects the current term.
+// It returns ErrNoCurrentTerm when no term covers today, and ErrNotFound for an unknown class or term.
+func (r *AssignmentStorage) ListByClass(ctx context.Context, classID int64, termID *int64) ([]models.Assignment, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentStorage.ListByClass")
+	defer span.End()
+
+	term, err := selectTerm(ctx, r.db, termID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.classExists(ctx, classID); err != nil {
+		return nil, err
+	}
+
+	return r.listByClass(ctx, classID, term.TermID)
+}
+
+func (r *AssignmentStorage) listByClass(ctx context.Context, classID, termID int64) ([]models.Assignment, error) {
+	var assignments []entities.Assignment
+
+	err := r.db.Select(ctx, &assignments, `
+		SELECT `+assignmentColumns+` FROM assignment
+		WHERE class_id = $1 AND term_id = $2
+		ORDER BY due_at, assignment_id;
+	`, classID, termID)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(assignments, func(a entities.Assignment) models.Assignment {
+		return a.ToAssignmentDomain()
+	}), nil
+}
+
+// Update changes the title, description, due date, maximum points and weight of an assignment. Its class and
+// term cannot be changed. It returns ErrScoreAboveMax when a recorded score is above the new maximum points.
+func (r *AssignmentStorage) Update(ctx context.Context, assignmentID int64, assignment models.Assignment) error {
+	ctx, span := tracer.Start(ctx, "AssignmentStorage.Update")
+	defer span.End()
+
+	return r.db.InTx(ctx, func(tx connection.DBops) error {
+		var exists bool
+		if err := tx.Get(ctx, &exists, `
+			SELECT EXISTS(SELECT 1 FROM assignment WHERE assignment_id = $1 FOR UPDATE);
+		`, assignmentID); err != nil {
+			return err
+		}
+
+		if !exists {
+			return pkgErrors.ErrNotFound
+		}
+
+		var above bool
+		if err := tx.Get(ctx, &above, `
+			SELECT EXISTS(SELECT 1 FROM submission WHERE assignment_id = $1 AND score > $2);
+		`, assignmentID, assignment.MaxPoints); err != nil {
+			return err
+		}
+
+		if above {
+			return pkgErrors.ErrScoreAboveMax
+		}
+
+		_, err := tx.Exec(ctx, `
+			UPDATE assignment
+			SET title = $2, description = $3, due_at = $4, max_points = $5, weight = COALESCE($6, weight)
+			WHERE assignment_id = $1
+		`, assignmentID, assignment.Title, assignment.Description, assignment.DueAt, assignment.MaxPoints, assignment.Weight)
+
+		return err
+	})
+}
+
+// Delete removes an assignment with its submissions. It returns ErrTermClosed when submissions of a closed term would be lost.
+func (r *AssignmentStorage) Delete(ctx context.Context, assignmentID int64) error {
+	ctx, span := tracer.Start(ctx, "AssignmentStorage.Delete")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, "DELETE FROM assignment WHERE assignment_id = $1", assignmentID)
+	if err != nil {
+		return classInfoError(err)
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListSubmissions returns every student enrolled in the class and term of an assignment with their submission,
+// pending when none was recorded. It returns ErrNotFound for an unknown assignment.
+func (r *AssignmentStorage) ListSubmissions(ctx context.Context, assignmentID int64) ([]models.Submission, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentStorage.ListSubmissions")
+	defer span.End()
+
+	var submissions []entities.Submission
+
+	if err := r.db.Select(ctx, &submissions, submissionQuery+`;`, assignmentID, nil); err != nil {
+		return nil, err
+	}
+
+	if len(submissions) == 0 {
+		if _, err := r.GetByID(ctx, assignmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	return utils.Map(submissions, func(s entities.Submission) models.Submission {
+		return s.ToSubmissionDomain()
+	}), nil
+}
+
+// GetSubmission returns the submission of a student, pending when none was recorded. It returns ErrNotFound for
+// an unknown assignment, and ErrNotEnrolled when the student is not enrolled in its class and term.
+func (r *AssignmentStorage) GetSubmission(ctx context.Context, assignmentID, studentID int64) (models.Submission, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentStorage.GetSubmission")
+	defer span.End()
+
+	var submission entities.Submission
+
+	if err := r.db.Get(ctx, &submission, submissionQuery+`;`, assignmentID, studentID); err != nil {
+		if !pgxscan.NotFound(err) {
+			return models.Submission{}, err
+		}
+
+		if _, err := r.GetByID(ctx, assignmentID); err != nil {
+			return models.Submission{}, err
+		}
+
+		return models.Submission{}, pkgErrors.ErrNotEnrolled
+	}
+
+	return submission.ToSubmissionDomain(), nil
+}
+
+// SetSubmission records the submission of a student, replacing the previous one. It returns ErrNotFound for an
+// unknown assignment, ErrNotEnrolled when the student is not enrolled in its class and term, ErrScoreAboveMax for
+// a score above the maximum points of the assignment, and ErrTermClosed when the term is closed.
+func (r *AssignmentStorage) SetSubmission(ctx context.Context, submission models.Submission) error {
+	ctx, span := tracer.Start(ctx, "AssignmentStorage.SetSubmission")
+	defer span.End()
+
+	return classInfoError(r.db.InTx(ctx, func(tx connection.DBops) error {
+		// The assignment is locked, so that its maximum points cannot change under the score.
+		var assignment entities.Assignment
+		if err := tx.Get(ctx, &assignment, `
+			SELECT `+assignmentColumns+` FROM assignment WHERE assignment_id = $1 FOR SHARE;
+		`, submission.AssignmentID); err != nil {
+			if pgxscan.NotFound(err) {
+				return pkgErrors.ErrNotFound
+			}
+
+			return err
+		}
+
+		if submission.Score != nil && *submission.Score > assignment.MaxPoints {
+			return pkgErrors.ErrScoreAboveMax
+		}
+
+		var classInfoIDs []int64
+		if err := tx.Select(ctx, &classInfoIDs, `
+			SELECT ci.id FROM class_info ci JOIN class c ON c.class_name = ci.class_name
+			WHERE c.class_id = $1 AND ci.term_id = $2 AND ci.student_id = $3 AND ci.status = 'enrolled';
+		`, assignment.ClassID, assignment.TermID, submission.StudentID); err != nil {
+			return err
+		}
+
+		if len(classInfoIDs) == 0 {
+			return pkgErrors.ErrNotEnrolled
+		}
+
+		_, err := tx.Exec(ctx, `
+			INSERT INTO submission(assignment_id, class_info_id, status, submitted_at, score, feedback)
+			VALUES($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (assignment_id, class_info_id) DO UPDATE
+			SET status = EXCLUDED.status, submitted_at = EXCLUDED.submitted_at, score = EXCLUDED.score,
+				feedback = EXCLUDED.feedback, updated_at = NOW()
+		`, submission.AssignmentID, classInfoIDs[0], submission.Status, submission.SubmittedAt,
+			submission.Score, submission.Feedback)
+
+		return err
+	}))
+}
+
+// DeleteSubmission removes the submission of a student, which becomes pending again.
+func (r *AssignmentStorage) DeleteSubmission(ctx context.Context, assignmentID, studentID int64) error {
+	ctx, span := tracer.Start(ctx, "AssignmentStorage.DeleteSubmission")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, `
+		DELETE FROM submission s USING class_info ci
+		WHERE s.class_info_id = ci.id AND s.assignment_id = $1 AND ci.student_id = $2
+	`, assignmentID, studentID)
+	if err != nil {
+		return classInfoError(err)
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetGradebook returns a term, the assignments of a class in it, and one submission per enrolled student and
+// assignment, ordered by student and due date. Students of a class without assignments get one submission
+// without an assignment. A nil termID selects the current term. It returns ErrNoCurrentTerm when no term
+// covers today, and ErrNotFound for an unknown class or term.
+func (r *AssignmentStorage) GetGradebook(
+	ctx context.Context,
+	classID int64,
+	termID *int64,
+) (models.AcademicTerm, []models.Assignment, []models.Submission, error) {
+	ctx, span := tracer.Start(ctx, "AssignmentStorage.GetGradebook")
+	defer span.End()
+
+	term, err := selectTerm(ctx, r.db, termID)
+	if err != nil {
+		return models.AcademicTerm{}, nil, nil, err
+	}
+
+	if err := r.classExists(ctx, classID); err != nil {
+		return models.AcademicTerm{}, nil, nil, err
+	}
+
+	assignments, err := r.listByClass(ctx, classID, term.TermID)
+	if err != nil {
+		return models.AcademicTerm{}, nil, nil, err
+	}
+
+	var submissions []entities.Submission
+
+	err = r.db.Select(ctx, &submissions, `
+		SELECT `+submissionColumns+`
+		FROM class c
+		JOIN class_info ci ON ci.class_name = c.class_name AND ci.term_id = $2 AND ci.status = 'enrolled'
+		JOIN student st ON st.student_id = ci.student_id
+		LEFT JOIN assignment a ON a.class_id = c.class_id AND a.term_id = ci.term_id
+		LEFT JOIN submission s ON s.assignment_id = a.assignment_id AND s.class_info_id = ci.id
+		WHERE c.class_id = $1
+		ORDER BY st.student_name, ci.student_id, a.due_at, a.assignment_id;
+	`, classID, term.TermID)
+	if err != nil {
+		return models.AcademicTerm{}, nil, nil, err
+	}
+
+	return term, assignments, utils.Map(submissions, func(s entities.Submission) models.Submission {
+		return s.ToSubmissionDomain()
+	}), nil
+}
+
+func (r *AssignmentStorage) classExists(ctx context.Context, classID int64) error {
+	var exists bool
+	if err := r.db.Get(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM class WHERE class_id = $1);`, classID); err != nil {
+		return err
+	}
+
+	if !exists {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+// addSession schedules an hour-long session of a class, starting daysAgo days before now, and returns its id.
+func addSession(ctx context.Context, t *testing.T, attendanceRepo AttendanceStorage, classID int64, daysAgo int) int64 {
+	t.Helper()
+
+	startsAt := time.Now().AddDate(0, 0, -daysAgo).Truncate(time.Second)
+	sessionID, err := attendanceRepo.AddSession(ctx, models.ClassSession{
+		ClassID:  classID,
+		StartsAt: startsAt,
+		EndsAt:   startsAt.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	return sessionID
+}
+
+func TestAttendance(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Marked in bulk", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		classID := addClass(ctx, t, db, "Math", 30)
+		studentIDs := addStudents(ctx, t, db, 3)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		for _, studentID := range studentIDs {
+			_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+			require.NoError(t, err)
+		}
+		attendanceRepo := NewAttendanceStorage(db.DB)
+		sessionID := addSession(ctx, t, attendanceRepo, classID, 1)
+		require.NoError(t, attendanceRepo.Mark(ctx, sessionID, models.AttendanceMarking{
+			Records: []models.AttendanceRecord{{StudentID: studentIDs[0], Status: models.AttendanceAbsent}},
+		}))
+		//act
+		err := attendanceRepo.Mark(ctx, sessionID, models.AttendanceMarking{
+			DefaultStatus: models.AttendancePresent,
+			Records:       []models.AttendanceRecord{{StudentID: studentIDs[1], Status: models.AttendanceLate, Note: "bus"}},
+		})
+		//assert
+		require.NoError(t, err)
+		records, err := attendanceRepo.GetAttendance(ctx, sessionID)
+		require.NoError(t, err)
+		statuses := make(map[int64]string, len(records))
+		for _, record := range records {
+			statuses[record.StudentID] = record.Status
+		}
+		assert.Equal(t, map[int64]string{
+			studentIDs[0]: models.AttendanceAbsent,
+			studentIDs[1]: models.AttendanceLate,
+			studentIDs[2]: models.AttendancePresent,
+		}, statuses)
+	})
+	t.Run("Not enrolled", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		classID := addClass(ctx, t, db, "Math", 30)
+		studentIDs := addStudents(ctx, t, db, 2)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[0], ClassName: "Math"})
+		require.NoError(t, err)
+		attendanceRepo := NewAttendanceStorage(db.DB)
+		sessionID := addSession(ctx, t, attendanceRepo, classID, 1)
+		//act
+		err = attendanceRepo.Mark(ctx, sessionID, models.AttendanceMarking{
+			DefaultStatus: models.AttendancePresent,
+			Records:       []models.AttendanceRecord{{StudentID: studentIDs[1], Status: models.AttendanceLate}},
+		})
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrNotEnrolled)
+		records, err := attendanceRepo.GetAttendance(ctx, sessionID)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Empty(t, records[0].Status)
+	})
+	t.Run("Rates over a date range", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		classID := addClass(ctx, t, db, "Math", 30)
+		studentID := addStudents(ctx, t, db, 1)[0]
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+		require.NoError(t, err)
+		attendanceRepo := NewAttendanceStorage(db.DB)
+		for daysAgo, status := range []string{
+			models.AttendancePresent, models.AttendanceLate, models.AttendanceAbsent, models.AttendanceExcused, "",
+		} {
+			sessionID := addSession(ctx, t, attendanceRepo, classID, daysAgo+1)
+			if status == "" {
+				continue
+			}
+			require.NoError(t, attendanceRepo.Mark(ctx, sessionID, models.AttendanceMarking{DefaultStatus: status}))
+		}
+		// Only the sessions of the last three days are in range.
+		from := time.Now().AddDate(0, 0, -3)
+		//act
+		studentRates, err := attendanceRepo.GetStudentRates(ctx, studentID, nil, nil)
+		require.NoError(t, err)
+		classRates, err := attendanceRepo.GetClassRates(ctx, classID, &from, nil)
+		//assert
+		require.NoError(t, err)
+		require.Len(t, studentRates, 1)
+		assert.Equal(t, models.AttendanceRate{
+			StudentID: studentID, StudentName: "Test", ClassID: classID, ClassName: "Math",
+			Sessions: 5, Present: 1, Late: 1, Absent: 1, Excused: 1, Unmarked: 1,
+		}, studentRates[0])
+		require.Len(t, classRates, 1)
+		assert.Equal(t, int64(3), classRates[0].Sessions)
+		assert.Equal(t, int64(0), classRates[0].Excused)
+	})
+	t.Run("Unknown session and class", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		attendanceRepo := NewAttendanceStorage(db.DB)
+		//act
+		_, addErr := attendanceRepo.AddSession(ctx, models.ClassSession{
+			ClassID: 999, StartsAt: time.Now(), EndsAt: time.Now().Add(time.Hour),
+		})
+		markErr := attendanceRepo.Mark(ctx, 999, models.AttendanceMarking{DefaultStatus: models.AttendancePresent})
+		_, ratesErr := attendanceRepo.GetClassRates(ctx, 999, nil, nil)
+		//assert
+		assert.ErrorIs(t, addErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, markErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, ratesErr, pkgErrors.ErrNotFound)
+	})
+}
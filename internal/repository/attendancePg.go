@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
+)
+
+const (
+	classSessionColumns = `s.session_id, s.class_id, c.class_name, s.starts_at, s.ends_at`
+
+	// rosterQuery selects the students enrolled in the class of session $1 during the term that covers the session.
+	rosterQuery = `
+		SELECT DISTINCT ci.student_id
+		FROM class_session s
+		JOIN class c ON c.class_id = s.class_id
+		JOIN class_info ci ON ci.class_name = c.class_name AND ci.status = 'enrolled'
+		JOIN academic_term t ON t.term_id = ci.term_id AND s.starts_at::date BETWEEN t.start_date AND t.end_date
+		WHERE s.session_id = $1`
+
+	// attendanceCountQuery counts the sessions of enrolled students by attendance status, within the terms
+	// of their enrollments and the dates $2 to $3 when they are set.
+	attendanceCountQuery = `
+		SELECT ci.student_id, st.student_name, c.class_id, c.class_name,
+			COUNT(DISTINCT s.session_id) AS sessions,
+			COUNT(DISTINCT s.session_id) FILTER (WHERE a.status = 'present') AS present,
+			COUNT(DISTINCT s.session_id) FILTER (WHERE a.status = 'late') AS late,
+			COUNT(DISTINCT s.session_id) FILTER (WHERE a.status = 'absent') AS absent,
+			COUNT(DISTINCT s.session_id) FILTER (WHERE a.status = 'excused') AS excused
+		FROM class_info ci
+		JOIN student st ON st.student_id = ci.student_id
+		JOIN academic_term t ON t.term_id = ci.term_id
+		JOIN class c ON c.class_name = ci.class_name
+		JOIN class_session s ON s.class_id = c.class_id AND s.starts_at::date BETWEEN t.start_date AND t.end_date
+		LEFT JOIN attendance a ON a.session_id = s.session_id AND a.student_id = ci.student_id
+		WHERE ci.status = 'enrolled'
+			AND ($2::date IS NULL OR s.starts_at::date >= $2::date)
+			AND ($3::date IS NULL OR s.starts_at::date <= $3::date)`
+)
+
+// AttendanceStorage keeps the sessions of classes and the attendance of their enrolled students.
+type AttendanceStorage struct {
+	db connection.DBops
+}
+
+func NewAttendanceStorage(database connection.DBops) AttendanceStorage {
+	return AttendanceStorage{db: database}
+}
+
+// AddSession schedules a session of a class. It returns ErrNotFound for an unknown class and ErrConflict
+// when the class already has a session starting at the same time.
+func (r *AttendanceStorage) AddSession(ctx context.Context, session models.ClassSession) (int64, error) {
+	ctx, span := tracer.Start(ctx, "AttendanceStorage.AddSession")
+	defer span.End()
+
+	var id int64
+
+	err := r.db.ExecQueryRow(ctx, `
+		INSERT INTO class_session(class_id, starts_at, ends_at) VALUES($1, $2, $3) RETURNING session_id;
+	`, session.ClassID, session.StartsAt, session.EndsAt).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case foreignKeyViolation:
+				return -1, pkgErrors.ErrNotFound
+			case uniqueViolation:
+				return -1, pkgErrors.ErrConflict
+			}
+		}
+
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func (r *AttendanceStorage) GetSession(ctx context.Context, sessionID int64) (models.ClassSession, error) {
+	ctx, span := tracer.Start(ctx, "AttendanceStorage.GetSession")
+	defer span.End()
+
+	var session entities.ClassSession
+
+	err := r.db.Get(ctx, &session, `
+		SELECT `+classSessionColumns+` FROM class_session s JOIN class c ON c.class_id = s.class_id WHERE s.session_id = $1;
+	`, sessionID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.ClassSession{}, pkgErrors.ErrNotFound
+		}
+
+		return models.ClassSession{}, err
+	}
+
+	return session.ToClassSessionDomain(), nil
+}
+
+// ListSessions returns the sessions of a class in start order, on the dates from to to when they are set.
+// It returns ErrNotFound for an unknown class.
+func (r *AttendanceStorage) ListSessions(ctx context.Context, classID int64, from, to *time.Time) ([]models.ClassSession, error) {
+	ctx, span := tracer.Start(ctx, "AttendanceStorage.ListSessions")
+	defer span.End()
+
+	var sessions []entities.ClassSession
+
+	err := r.db.Select(ctx, &sessions, `
+		SELECT `+classSessionColumns+`
+		FROM class_session s
+		JOIN class c ON c.class_id = s.class_id
+		WHERE s.class_id = $1
+			AND ($2::date IS NULL OR s.starts_at::date >= $2::date)
+			AND ($3::date IS NULL OR s.starts_at::date <= $3::date)
+		ORDER BY s.starts_at;
+	`, classID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sessions) == 0 {
+		if err := r.classExists(ctx, classID); err != nil {
+			return nil, err
+		}
+	}
+
+	return utils.Map(sessions, func(s entities.ClassSession) models.ClassSession {
+		return s.ToClassSessionDomain()
+	}), nil
+}
+
+// DeleteSession removes a session together with its attendance.
+func (r *AttendanceStorage) DeleteSession(ctx context.Context, sessionID int64) error {
+	ctx, span := tracer.Start(ctx, "AttendanceStorage.DeleteSession")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, `DELETE FROM class_session WHERE session_id = $1`, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetAttendance returns the roster of a session with the attendance of each student, and the students
+// marked before they left the roster. Students who have not been marked have no status.
+// It returns ErrNotFound for an unknown session.
+func (r *AttendanceStorage) GetAttendance(ctx context.Context, sessionID int64) ([]models.AttendanceRecord, error) {
+	ctx, span := tracer.Start(ctx, "AttendanceStorage.GetAttendance")
+	defer span.End()
+
+	var records []entities.AttendanceRecord
+
+	err := r.db.Select(ctx, &records, `
+		WITH roster AS (`+rosterQuery+`)
+		SELECT st.student_id, st.student_name, a.status, a.note, a.recorded_at
+		FROM (SELECT student_id FROM roster UNION SELECT student_id FROM attendance WHERE session_id = $1) r
+		JOIN student st ON st.student_id = r.student_id
+		LEFT JOIN attendance a ON a.session_id = $1 AND a.student_id = r.student_id
+		ORDER BY st.student_name, st.student_id;
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		var exists bool
+		if err := r.db.Get(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM class_session WHERE session_id = $1);`, sessionID); err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, pkgErrors.ErrNotFound
+		}
+	}
+
+	return utils.Map(records, func(a entities.AttendanceRecord) models.AttendanceRecord {
+		return a.ToAttendanceRecordDomain()
+	}), nil
+}
+
+// Mark records the attendance of a session in one transaction. The listed records replace the attendance
+// of their students, and the default status marks the rest of the roster who have not been marked yet.
+// It returns ErrNotFound for an unknown session and ErrNotEnrolled when a listed student is not on the roster.
+func (r *AttendanceStorage) Mark(ctx context.Context, sessionID int64, marking models.AttendanceMarking) error {
+	ctx, span := tracer.Start(ctx, "AttendanceStorage.Mark")
+	defer span.End()
+
+	return r.db.InTx(ctx, func(tx connection.DBops) error {
+		// Locking the session serializes markings, so that a default status never overwrites a concurrent mark.
+		command, err := tx.Exec(ctx, `SELECT session_id FROM class_session WHERE session_id = $1 FOR UPDATE;`, sessionID)
+		if err != nil {
+			return err
+		}
+
+		if command.RowsAffected() == 0 {
+			return pkgErrors.ErrNotFound
+		}
+
+		var roster []int64
+		if err := tx.Select(ctx, &roster, rosterQuery+`;`, sessionID); err != nil {
+			return err
+		}
+
+		enrolled := make(map[int64]bool, len(roster))
+		for _, studentID := range roster {
+			enrolled[studentID] = true
+		}
+
+		for _, record := range marking.Records {
+			if !enrolled[record.StudentID] {
+				return pkgErrors.ErrNotEnrolled
+			}
+
+			_, err := tx.Exec(ctx, `
+				INSERT INTO attendance(session_id, student_id, status, note) VALUES($1, $2, $3, $4)
+				ON CONFLICT (session_id, student_id) DO UPDATE
+				SET status = EXCLUDED.status, note = EXCLUDED.note, recorded_at = NOW();
+			`, sessionID, record.StudentID, record.Status, record.Note)
+			if err != nil {
+				return err
+			}
+		}
+
+		if marking.DefaultStatus == "" {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO attendance(session_id, student_id, status)
+			SELECT $1, student_id, $2 FROM UNNEST($3::BIGINT[]) AS roster(student_id)
+			ON CONFLICT (session_id, student_id) DO NOTHING;
+		`, sessionID, marking.DefaultStatus, roster)
+
+		return err
+	})
+}
+
+// GetStudentRates counts the attendance of a student in each class they are enrolled in, over the sessions
+// on the dates from to to when they are set. It returns ErrNotFound for an unknown student.
+func (r *AttendanceStorage) GetStudentRates(ctx context.Context, studentID int64, from, to *time.Time) ([]models.AttendanceRate, error) {
+	ctx, span := tracer.Start(ctx, "AttendanceStorage.GetStudentRates")
+	defer span.End()
+
+	var counts []entities.AttendanceCount
+
+	err := r.db.Select(ctx, &counts, attendanceCountQuery+`
+			AND ci.student_id = $1
+		GROUP BY ci.student_id, st.student_name, c.class_id, c.class_name
+		ORDER BY c.class_name;
+	`, studentID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(counts) == 0 {
+		var exists bool
+		if err := r.db.Get(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM student WHERE student_id = $1);`, studentID); err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, pkgErrors.ErrNotFound
+		}
+	}
+
+	return utils.Map(counts, func(a entities.AttendanceCount) models.AttendanceRate {
+		return a.ToAttendanceRateDomain()
+	}), nil
+}
+
+// GetClassRates counts the attendance of each student enrolled in a class, over the sessions on the dates
+// from to to when they are set. It returns ErrNotFound for an unknown class.
+func (r *AttendanceStorage) GetClassRates(ctx context.Context, classID int64, from, to *time.Time) ([]models.AttendanceRate, error) {
+	ctx, span := tracer.Start(ctx, "AttendanceStorage.GetClassRates")
+	defer span.End()
+
+	var counts []entities.AttendanceCount
+
+	err := r.db.Select(ctx, &counts, attendanceCountQuery+`
+			AND c.class_id = $1
+		GROUP BY ci.student_id, st.student_name, c.class_id, c.class_name
+		ORDER BY st.student_name, ci.student_id;
+	`, classID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(counts) == 0 {
+		if err := r.classExists(ctx, classID); err != nil {
+			return nil, err
+		}
+	}
+
+	return utils.Map(counts, func(a entities.AttendanceCount) models.AttendanceRate {
+		return a.ToAttendanceRateDomain()
+	}), nil
+}
+
+// classExists returns ErrNotFound for an unknown class.
+func (r *AttendanceStorage) classExists(ctx context.Context, classID int64) error {
+	var exists bool
+	if err := r.db.Get(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM class WHERE class_id = $1);`, classID); err != nil {
+		return err
+	}
+
+	if !exists {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+)
+
+// AuditStorage keeps the audit trail of actions that bypassed a rule.
+type AuditStorage struct {
+	db connection.DBops
+}
+
+func NewAuditStorage(database connection.DBops) AuditStorage {
+	return AuditStorage{db: database}
+}
+
+func (r *AuditStorage) Record(ctx context.Context, event models.AuditEvent) (int64, error) {
+	ctx, span := tracer.Start(ctx, "AuditStorage.Record")
+	defer span.End()
+
+	var id int64
+
+	err := r.db.ExecQueryRow(ctx, `
+		INSERT INTO audit_event(actor, action, student_id, class_name, detail) VALUES($1, $2, $3, $4, $5) RETURNING audit_id;
+	`, event.Actor, event.Action, event.StudentID, event.ClassName, event.Detail).Scan(&id)
+	if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+// List returns the audit trail, newest first.
+func (r *AuditStorage) List(ctx context.Context) ([]models.AuditEvent, error) {
+	ctx, span := tracer.Start(ctx, "AuditStorage.List")
+	defer span.End()
+
+	var events []entities.AuditEvent
+
+	err := r.db.Select(ctx, &events, `
+		SELECT audit_id, actor, action, student_id, class_name, detail, created_at FROM audit_event ORDER BY audit_id DESC;
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(events, func(e entities.AuditEvent) models.AuditEvent {
+		return e.ToAuditEventDomain()
+	}), nil
+}
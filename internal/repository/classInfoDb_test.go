@@ -26,6 +26,7 @@ func TestCreateClassInfo(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		db.SetUpDatabase(migrationPath)
 		defer db.TearDownDatabase(migrationPath)
+		addCurrentTerm(ctx, t, db)
 		//arrange
 		studentRepo := NewStudentStorage(db.DB)
 		testStudentReq := models.StudentRequest{
@@ -63,6 +64,7 @@ func TestCreateClassInfo(t *testing.T) {
 	t.Run("Fail for: insert or update on table \"class_info\" violates foreign key constraint", func(t *testing.T) {
 		db.SetUpDatabase(migrationPath)
 		defer db.TearDownDatabase(migrationPath)
+		addCurrentTerm(ctx, t, db)
 		//arrange
 		classInfoRepo := NewClassInfoStorage(db.DB)
 		testClassInfoReq := models.ClassInfo{ID: 1, StudentID: 1, ClassName: "Math"}
@@ -75,6 +77,7 @@ func TestCreateClassInfo(t *testing.T) {
 	t.Run("Not Found", func(t *testing.T) {
 		db.SetUpDatabase(migrationPath)
 		defer db.TearDownDatabase(migrationPath)
+		addCurrentTerm(ctx, t, db)
 		//arrange
 		studentRepo := NewStudentStorage(db.DB)
 		testStudentReq := models.StudentRequest{
@@ -114,6 +117,7 @@ func TestGetByStudentIDClassInfo(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		db.SetUpDatabase(migrationPath)
 		defer db.TearDownDatabase(migrationPath)
+		addCurrentTerm(ctx, t, db)
 		//arrange
 		studentRepo := NewStudentStorage(db.DB)
 		testStudentReq := models.StudentRequest{
@@ -146,6 +150,7 @@ func TestGetByStudentIDClassInfo(t *testing.T) {
 	t.Run("Fail Not Found", func(t *testing.T) {
 		db.SetUpDatabase(migrationPath)
 		defer db.TearDownDatabase(migrationPath)
+		addCurrentTerm(ctx, t, db)
 		//arrange
 		studentRepo := NewStudentStorage(db.DB)
 		testStudentReq := models.StudentRequest{
@@ -180,6 +185,7 @@ func TestDeleteClassByStudentIDClassInfo(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		db.SetUpDatabase(migrationPath)
 		defer db.TearDownDatabase(migrationPath)
+		addCurrentTerm(ctx, t, db)
 		//arrange
 		studentRepo := NewStudentStorage(db.DB)
 		testStudentReq := models.StudentRequest{
@@ -201,7 +207,7 @@ func TestDeleteClassByStudentIDClassInfo(t *testing.T) {
 			ClassName: "Math",
 		}
 		_, err = classInfoRepo.Add(ctx, testClassInfoReq)
-		err = classInfoRepo.DeleteClassByStudentID(ctx, respStudentID)
+		err = classInfoRepo.DeleteClassByStudentID(ctx, respStudentID, nil)
 		//assert
 		require.NoError(t, err)
 		assert.Nil(t, err)
@@ -215,6 +221,7 @@ func TestDeleteClassByStudentIDClassInfo(t *testing.T) {
 	t.Run("Fail Not Found", func(t *testing.T) {
 		db.SetUpDatabase(migrationPath)
 		defer db.TearDownDatabase(migrationPath)
+		addCurrentTerm(ctx, t, db)
 		//arrange
 		studentRepo := NewStudentStorage(db.DB)
 		testStudentReq := models.StudentRequest{
@@ -235,7 +242,7 @@ func TestDeleteClassByStudentIDClassInfo(t *testing.T) {
 			ClassName: "Math",
 		}
 		_, err = classInfoRepo.Add(ctx, testClassInfoReq)
-		err = classInfoRepo.DeleteClassByStudentID(ctx, respStudentID-1)
+		err = classInfoRepo.DeleteClassByStudentID(ctx, respStudentID-1, nil)
 		//assert
 		require.Error(t, err)
 		assert.ErrorIs(t, err, pkgErrors.ErrNotFound)
@@ -253,6 +260,7 @@ func TestUpdateClassInfo(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		db.SetUpDatabase(migrationPath)
 		defer db.TearDownDatabase(migrationPath)
+		termID := addCurrentTerm(ctx, t, db)
 		//arrange
 		studentRepo := NewStudentStorage(db.DB)
 		testStudentReq := models.StudentRequest{
@@ -273,6 +281,7 @@ func TestUpdateClassInfo(t *testing.T) {
 			ID:        1,
 			StudentID: respStudentID,
 			ClassName: "Math",
+			TermID:    termID,
 		}
 		_, err = classInfoRepo.Add(ctx, testClassInfoReq)
 		testClassInfoReq.StudentID = 2
@@ -286,6 +295,7 @@ func TestUpdateClassInfo(t *testing.T) {
 	t.Run("Fail Not Found", func(t *testing.T) {
 		db.SetUpDatabase(migrationPath)
 		defer db.TearDownDatabase(migrationPath)
+		termID := addCurrentTerm(ctx, t, db)
 		//arrange
 		studentRepo := NewStudentStorage(db.DB)
 		testStudentReq := models.StudentRequest{
@@ -305,6 +315,7 @@ func TestUpdateClassInfo(t *testing.T) {
 			ID:        1,
 			StudentID: respStudentID,
 			ClassName: "Math",
+			TermID:    termID,
 		}
 		_, err = classInfoRepo.Add(ctx, testClassInfoReq)
 		err = classInfoRepo.Update(ctx, respStudentID-1, testClassInfoReq)
@@ -312,4 +323,129 @@ func TestUpdateClassInfo(t *testing.T) {
 		require.Error(t, err)
 		assert.ErrorIs(t, err, pkgErrors.ErrNotFound)
 	})
+	t.Run("Closed term is left alone", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termRepo := NewAcademicTermStorage(db.DB)
+		studentRepo := NewStudentStorage(db.DB)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		past := models.AcademicTerm{Name: "Past", StartDate: "2001-09-01", EndDate: "2001-12-20", Status: models.TermStatusOpen}
+		pastID, err := termRepo.Add(ctx, past)
+		require.NoError(t, err)
+		currentID := addCurrentTerm(ctx, t, db)
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		_, err = classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math", TermID: pastID})
+		require.NoError(t, err)
+		_, err = classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math", TermID: currentID})
+		require.NoError(t, err)
+		past.Status = models.TermStatusClosed
+		require.NoError(t, termRepo.Update(ctx, pastID, past))
+		legacyID, err := classInfoRepo.LegacyTermID(ctx)
+		require.NoError(t, err)
+		//act
+		updateErr := classInfoRepo.Update(ctx, studentID, models.ClassInfo{ClassName: "Art", TermID: legacyID})
+		moved, err := classInfoRepo.GetByStudentID(ctx, studentID)
+		require.NoError(t, err)
+		deleteErr := classInfoRepo.DeleteClassByStudentID(ctx, studentID, &legacyID)
+		remaining, err := classInfoRepo.GetByStudentID(ctx, studentID)
+		require.NoError(t, err)
+		//assert
+		assert.Equal(t, currentID, legacyID)
+		require.NoError(t, updateErr)
+		require.Len(t, moved, 2)
+		assert.Equal(t, "Math", moved[0].ClassName)
+		assert.Equal(t, "Art", moved[1].ClassName)
+		require.NoError(t, deleteErr)
+		require.Len(t, remaining, 1)
+		assert.Equal(t, pastID, remaining[0].TermID)
+		assert.Equal(t, "Math", remaining[0].ClassName)
+	})
+}
+
+func TestByIDClassInfo(t *testing.T) {
+
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Success", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		addCurrentTerm(ctx, t, db)
+		//arrange
+		studentRepo := NewStudentStorage(db.DB)
+		respStudentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		classInfoID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: respStudentID, ClassName: "Math"})
+		require.NoError(t, err)
+		//act
+		err = classInfoRepo.UpdateByID(ctx, classInfoID, models.ClassInfo{ClassName: "Algebra"})
+		//assert
+		require.NoError(t, err)
+		//act
+		classInfo, err := classInfoRepo.GetByID(ctx, classInfoID)
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, models.ClassInfo{ID: classInfoID, StudentID: respStudentID, ClassName: "Algebra"}, classInfo)
+		//act
+		err = classInfoRepo.DeleteByID(ctx, classInfoID)
+		//assert
+		require.NoError(t, err)
+		_, err = classInfoRepo.GetByID(ctx, classInfoID)
+		assert.ErrorIs(t, err, pkgErrors.ErrNotFound)
+	})
+	t.Run("Fail Not Found", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		addCurrentTerm(ctx, t, db)
+		//arrange
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		//act
+		err := classInfoRepo.UpdateByID(ctx, 42, models.ClassInfo{ClassName: "Algebra"})
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrNotFound)
+		//act
+		err = classInfoRepo.DeleteByID(ctx, 42)
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrNotFound)
+	})
+}
+
+func TestGetByStudentIDsClassInfo(t *testing.T) {
+
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Success", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		addCurrentTerm(ctx, t, db)
+		//arrange
+		studentRepo := NewStudentStorage(db.DB)
+		firstID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "A", Grade: 90})
+		require.NoError(t, err)
+		secondID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "B", Grade: 80})
+		require.NoError(t, err)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		mathID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: firstID, ClassName: "Math"})
+		require.NoError(t, err)
+		physicsID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: firstID, ClassName: "Physics"})
+		require.NoError(t, err)
+		//act
+		classesInfo, err := classInfoRepo.GetByStudentIDs(ctx, []int64{firstID, secondID})
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, map[int64][]models.ClassInfo{firstID: {
+			{ID: mathID, StudentID: firstID, ClassName: "Math"},
+			{ID: physicsID, StudentID: firstID, ClassName: "Physics"},
+		}}, classesInfo)
+	})
 }
@@ -3,11 +3,26 @@ package repository
 import (
 	"CRUD_Go_Backend/internal/pkg/connection"
 	"context"
+	"errors"
 
 	"CRUD_Go_Backend/internal/handlers/models"
 	"CRUD_Go_Backend/internal/pkg/pkgErrors"
 	"CRUD_Go_Backend/internal/pkg/utils"
 	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// classInfoColumns are the columns of class_info read into entities.ClassInfo.
+const classInfoColumns = `id, student_id, class_name, term_id, status`
+
+const (
+	// notNullViolation is the SQLSTATE raised when an enrollment gets no term because none is current.
+	notNullViolation = "23502"
+	// termClosedViolation is the SQLSTATE raised by the class_info trigger for enrollments of a closed term.
+	termClosedViolation = "55000"
 )
 
 type ClassInfoStorage struct {
@@ -21,43 +36,132 @@ func ToClassInfoStorage(c models.ClassInfo) entities.ClassInfo {
 	return entities.ClassInfo{
 		StudentID: c.StudentID,
 		ClassName: c.ClassName,
+		TermID:    c.TermID,
+	}
+}
+
+// classInfoError maps the errors raised by class_info constraints and triggers to pkgErrors.
+func classInfoError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case termClosedViolation:
+		return pkgErrors.ErrTermClosed
+	case notNullViolation:
+		return pkgErrors.ErrNoCurrentTerm
+	case exclusionViolation:
+		return scheduleError(err)
 	}
+
+	return err
 }
 
+// Add enrolls the student in the term of classInfoReq, or in the current term when TermID is zero.
+// The student is waitlisted when the class is full in that term. It returns ErrNoCurrentTerm when
+// no term covers today and ErrTermClosed when the term is closed.
 func (r *ClassInfoStorage) Add(ctx context.Context, classInfoReq models.ClassInfo) (int64, error) {
+	ctx, span := tracer.Start(ctx, "ClassInfoStorage.Add")
+	defer span.End()
+
 	classInfoPg := ToClassInfoStorage(classInfoReq)
-	var id int64
 
-	err := r.db.ExecQueryRow(ctx, `INSERT INTO class_info(student_id, class_name) VALUES($1, $2) RETURNING id;`,
-		classInfoPg.StudentID,
-		classInfoPg.ClassName,
-	).Scan(&id)
+	err := r.db.InTx(ctx, func(tx connection.DBops) error {
+		var termID *int64
+		if err := tx.Get(ctx, &termID, `SELECT COALESCE(NULLIF($1::BIGINT, 0), (`+currentTermQuery+`));`, classInfoPg.TermID); err != nil {
+			return err
+		}
+
+		if termID == nil {
+			return pkgErrors.ErrNoCurrentTerm
+		}
+
+		classInfoPg.TermID = *termID
+
+		if err := lockClasses(ctx, tx, classInfoPg.ClassName); err != nil {
+			return err
+		}
 
+		status, err := seatStatus(ctx, tx, classInfoPg.ClassName, classInfoPg.TermID)
+		if err != nil {
+			return err
+		}
+
+		classInfoPg.Status = status
+
+		err = tx.ExecQueryRow(ctx, `
+			INSERT INTO class_info(student_id, class_name, term_id, status, waitlisted_at)
+			VALUES($1, $2, $3, $4, CASE WHEN $4 = 'waitlisted' THEN NOW() END)
+			RETURNING id;
+		`,
+			classInfoPg.StudentID,
+			classInfoPg.ClassName,
+			classInfoPg.TermID,
+			classInfoPg.Status,
+		).Scan(&classInfoPg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordEvent(ctx, tx, classInfoPg, classInfoPg.Status)
+	})
 	if err != nil {
-		return -1, err
+		return -1, classInfoError(err)
 	}
 
-	return id, nil
+	return classInfoPg.ID, nil
+}
+
+// LegacyTermID returns the term v1 enrollments without a term go to: the current term, or the open
+// term that ended last when no term covers today. It returns ErrNoCurrentTerm when there is neither.
+func (r *ClassInfoStorage) LegacyTermID(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "ClassInfoStorage.LegacyTermID")
+	defer span.End()
+
+	var termID *int64
+	if err := r.db.Get(ctx, &termID, legacyTermQuery+`;`); err != nil {
+		return 0, err
+	}
+
+	if termID == nil {
+		return 0, pkgErrors.ErrNoCurrentTerm
+	}
+
+	return *termID, nil
 }
 
 func (r *ClassInfoStorage) GetByStudentID(ctx context.Context, studentID int64) ([]models.ClassInfo, error) {
+	ctx, span := tracer.Start(ctx, "ClassInfoStorage.GetByStudentID")
+	defer span.End()
+
 	var classInfo []entities.ClassInfo
 
-	rows, err := r.db.ExecQuery(ctx, `SELECT id, student_id, class_name FROM class_info WHERE student_id=$1;`, studentID)
+	rows, err := r.db.ExecQuery(ctx, `SELECT `+classInfoColumns+` FROM class_info WHERE student_id=$1 ORDER BY id;`, studentID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
+	_, scanSpan := tracer.Start(ctx, "ClassInfoStorage.GetByStudentID.scan")
 	for rows.Next() {
 		var tempClassInfo entities.ClassInfo
 
-		err := rows.Scan(&tempClassInfo.ID, &tempClassInfo.StudentID, &tempClassInfo.ClassName)
+		err := rows.Scan(&tempClassInfo.ID, &tempClassInfo.StudentID, &tempClassInfo.ClassName, &tempClassInfo.TermID, &tempClassInfo.Status)
 		if err != nil {
+			scanSpan.End()
 			return nil, err
 		}
 
 		classInfo = append(classInfo, tempClassInfo)
 	}
+	scanSpan.SetAttributes(attribute.Int("db.rows_scanned", len(classInfo)))
+	scanSpan.End()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
 	classesInfo := utils.Map(
 		classInfo,
@@ -69,35 +173,115 @@ func (r *ClassInfoStorage) GetByStudentID(ctx context.Context, studentID int64)
 	return classesInfo, nil
 }
 
-func (r *ClassInfoStorage) DeleteClassByStudentID(ctx context.Context, studentID int64) error {
-	command, err := r.db.Exec(ctx, "DELETE FROM class_info WHERE student_id = $1", studentID)
+// GetByStudentIDInTerm returns the classes of a student in one academic term. A nil termID selects
+// the current term, which gives no classes when no term covers today.
+func (r *ClassInfoStorage) GetByStudentIDInTerm(ctx context.Context, studentID int64, termID *int64) ([]models.ClassInfo, error) {
+	ctx, span := tracer.Start(ctx, "ClassInfoStorage.GetByStudentIDInTerm")
+	defer span.End()
+
+	var classInfo []entities.ClassInfo
+
+	err := r.db.Select(ctx, &classInfo, `
+		SELECT `+classInfoColumns+` FROM class_info
+		WHERE student_id = $1 AND term_id = COALESCE($2, (`+currentTermQuery+`))
+		ORDER BY id;
+	`, studentID, termID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return utils.Map(classInfo, func(c entities.ClassInfo) models.ClassInfo {
+		return c.ToClassInfoDomain()
+	}), nil
+}
+
+// GetByStudentIDs loads the classes of several students in one query, keyed by student id.
+// Students without classes have no entry.
+func (r *ClassInfoStorage) GetByStudentIDs(ctx context.Context, studentIDs []int64) (map[int64][]models.ClassInfo, error) {
+	ctx, span := tracer.Start(ctx, "ClassInfoStorage.GetByStudentIDs")
+	defer span.End()
+
+	var classInfo []entities.ClassInfo
+
+	err := r.db.Select(
+		ctx,
+		&classInfo,
+		`SELECT `+classInfoColumns+` FROM class_info WHERE student_id = ANY($1) ORDER BY student_id, id;`,
+		studentIDs,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	if command.RowsAffected() == 0 {
-		return pkgErrors.ErrNotFound
+	classesInfo := make(map[int64][]models.ClassInfo, len(studentIDs))
+	for _, c := range classInfo {
+		classesInfo[c.StudentID] = append(classesInfo[c.StudentID], c.ToClassInfoDomain())
 	}
 
-	return nil
+	return classesInfo, nil
 }
 
+// DeleteClassByStudentID drops the enrollments of a student in termID, or in every term when termID is nil,
+// and promotes waitlisted students into the freed seats.
+func (r *ClassInfoStorage) DeleteClassByStudentID(ctx context.Context, studentID int64, termID *int64) error {
+	ctx, span := tracer.Start(ctx, "ClassInfoStorage.DeleteClassByStudentID")
+	defer span.End()
+
+	return classInfoError(r.db.InTx(ctx, func(tx connection.DBops) error {
+		return drop(ctx, tx, `student_id = $1 AND ($2::BIGINT IS NULL OR term_id = $2)`, studentID, termID)
+	}))
+}
+
+// Update moves the enrollments of a student in the term of classInfoReq to another class. Moved enrollments
+// are waitlisted when the new class is full, and waitlisted students are promoted into the seats freed in
+// the old classes.
 func (r *ClassInfoStorage) Update(ctx context.Context, studentID int64, classInfoReq models.ClassInfo) error {
+	ctx, span := tracer.Start(ctx, "ClassInfoStorage.Update")
+	defer span.End()
+
 	classInfo := ToClassInfoStorage(classInfoReq)
 
-	command, err := r.db.Exec(ctx, `
-		UPDATE class_info
-		SET class_name = $2
-		WHERE student_id = $1
-	`, studentID, classInfo.ClassName)
+	return classInfoError(r.db.InTx(ctx, func(tx connection.DBops) error {
+		return move(ctx, tx, classInfo.ClassName, `student_id = $1 AND term_id = $2`, studentID, classInfo.TermID)
+	}))
+}
+
+func (r *ClassInfoStorage) GetByID(ctx context.Context, id int64) (models.ClassInfo, error) {
+	ctx, span := tracer.Start(ctx, "ClassInfoStorage.GetByID")
+	defer span.End()
 
+	var classInfo entities.ClassInfo
+
+	err := r.db.Get(ctx, &classInfo, `SELECT `+classInfoColumns+` FROM class_info WHERE id=$1;`, id)
 	if err != nil {
-		return err
-	}
+		if pgxscan.NotFound(err) {
+			return models.ClassInfo{}, pkgErrors.ErrNotFound
+		}
 
-	if command.RowsAffected() == 0 {
-		return pkgErrors.ErrNotFound
+		return models.ClassInfo{}, err
 	}
 
-	return nil
+	return classInfo.ToClassInfoDomain(), nil
+}
+
+// UpdateByID moves one enrollment to another class, like Update.
+func (r *ClassInfoStorage) UpdateByID(ctx context.Context, id int64, classInfoReq models.ClassInfo) error {
+	ctx, span := tracer.Start(ctx, "ClassInfoStorage.UpdateByID")
+	defer span.End()
+
+	classInfo := ToClassInfoStorage(classInfoReq)
+
+	return classInfoError(r.db.InTx(ctx, func(tx connection.DBops) error {
+		return move(ctx, tx, classInfo.ClassName, `id = $1`, id)
+	}))
+}
+
+// DeleteByID drops one enrollment and promotes the first waitlisted student of its class into the freed seat.
+func (r *ClassInfoStorage) DeleteByID(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "ClassInfoStorage.DeleteByID")
+	defer span.End()
+
+	return classInfoError(r.db.InTx(ctx, func(tx connection.DBops) error {
+		return drop(ctx, tx, `id = $1`, id)
+	}))
 }
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+const classColumns = `class_id, class_name, credits, capacity, created_at`
+
+// ClassStorage reads the classes that students enroll in and sets their credits and capacity. Classes
+// are created on first use by name, from class_info and teacher assignments.
+type ClassStorage struct {
+	db connection.DBops
+}
+
+func NewClassStorage(database connection.DBops) ClassStorage {
+	return ClassStorage{db: database}
+}
+
+// List returns every class ordered by name.
+func (r *ClassStorage) List(ctx context.Context) ([]models.Class, error) {
+	ctx, span := tracer.Start(ctx, "ClassStorage.List")
+	defer span.End()
+
+	var classes []entities.Class
+
+	if err := r.db.Select(ctx, &classes, `SELECT `+classColumns+` FROM class ORDER BY class_name;`); err != nil {
+		return nil, err
+	}
+
+	return utils.Map(classes, func(c entities.Class) models.Class {
+		return c.ToClassDomain()
+	}), nil
+}
+
+func (r *ClassStorage) GetByID(ctx context.Context, classID int64) (models.Class, error) {
+	ctx, span := tracer.Start(ctx, "ClassStorage.GetByID")
+	defer span.End()
+
+	var class entities.Class
+
+	err := r.db.Get(ctx, &class, `SELECT `+classColumns+` FROM class WHERE class_id = $1;`, classID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.Class{}, pkgErrors.ErrNotFound
+		}
+
+		return models.Class{}, err
+	}
+
+	return class.ToClassDomain(), nil
+}
+
+// Update sets the credits and capacity of a class. Credits weight its grades in every GPA; a nil capacity
+// leaves the class unlimited. Raising the capacity promotes waitlisted students of open terms into the new seats.
+func (r *ClassStorage) Update(ctx context.Context, classID int64, class models.Class) error {
+	ctx, span := tracer.Start(ctx, "ClassStorage.Update")
+	defer span.End()
+
+	return r.db.InTx(ctx, func(tx connection.DBops) error {
+		var className string
+
+		err := tx.Get(ctx, &className, `
+			UPDATE class SET credits = $2, capacity = $3 WHERE class_id = $1 RETURNING class_name;
+		`, classID, class.Credits, class.Capacity)
+		if err != nil {
+			if pgxscan.NotFound(err) {
+				return pkgErrors.ErrNotFound
+			}
+
+			return err
+		}
+
+		var termIDs []int64
+
+		err = tx.Select(ctx, &termIDs, `
+			SELECT DISTINCT ci.term_id FROM class_info ci
+			JOIN academic_term t ON t.term_id = ci.term_id
+			WHERE ci.class_name = $1 AND ci.status = 'waitlisted' AND t.status <> 'closed'
+			ORDER BY ci.term_id;
+		`, className)
+		if err != nil {
+			return err
+		}
+
+		for _, termID := range termIDs {
+			if err := fillSeats(ctx, tx, className, termID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListEvents returns the enrollment events of a class in the order they happened.
+// It returns ErrNotFound for an unknown class.
+func (r *ClassStorage) ListEvents(ctx context.Context, classID int64) ([]models.EnrollmentEvent, error) {
+	ctx, span := tracer.Start(ctx, "ClassStorage.ListEvents")
+	defer span.End()
+
+	var events []entities.EnrollmentEvent
+
+	err := r.db.Select(ctx, &events, `
+		SELECT e.event_id, e.class_info_id, e.student_id, e.class_name, e.term_id, e.event, e.created_at
+		FROM enrollment_event e
+		JOIN class c ON c.class_name = e.class_name
+		WHERE c.class_id = $1
+		ORDER BY e.event_id;
+	`, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		var exists bool
+		if err := r.db.Get(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM class WHERE class_id = $1);`, classID); err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, pkgErrors.ErrNotFound
+		}
+	}
+
+	return utils.Map(events, func(e entities.EnrollmentEvent) models.EnrollmentEvent {
+		return e.ToEnrollmentEventDomain()
+	}), nil
+}
@@ -0,0 +1,89 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+func TestContact(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+		mother        = models.Contact{
+			Name: "Jane Doe", Relationship: models.RelationshipMother, Email: "jane@example.com",
+			PreferredChannel: models.ContactChannelEmail, ConsentEmail: true,
+		}
+	)
+	t.Run("Shared by siblings", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentIDs := addStudents(ctx, t, db, 2)
+		contactRepo := NewContactStorage(db.DB)
+		contactID, err := contactRepo.Add(ctx, studentIDs[0], mother)
+		require.NoError(t, err)
+		require.NoError(t, contactRepo.Link(ctx, studentIDs[1], contactID, models.RelationshipGuardian))
+		updated := mother
+		updated.ContactID, updated.Phone = contactID, "+14155552671"
+		//act
+		err = contactRepo.Update(ctx, studentIDs[0], updated)
+		//assert
+		require.NoError(t, err)
+		contacts, err := contactRepo.ListByStudent(ctx, studentIDs[1])
+		require.NoError(t, err)
+		require.Len(t, contacts, 1)
+		assert.Equal(t, "+14155552671", contacts[0].Phone)
+		assert.Equal(t, models.RelationshipGuardian, contacts[0].Relationship)
+		assert.ErrorIs(t, contactRepo.Link(ctx, studentIDs[1], contactID, models.RelationshipOther), pkgErrors.ErrConflict)
+	})
+	t.Run("Removed with its last student", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentIDs := addStudents(ctx, t, db, 2)
+		contactRepo := NewContactStorage(db.DB)
+		contactID, err := contactRepo.Add(ctx, studentIDs[0], mother)
+		require.NoError(t, err)
+		require.NoError(t, contactRepo.Link(ctx, studentIDs[1], contactID, models.RelationshipMother))
+		studentRepo := NewStudentStorage(db.DB)
+		//act
+		require.NoError(t, contactRepo.Unlink(ctx, studentIDs[0], contactID))
+		var afterUnlink bool
+		require.NoError(t, db.DB.Get(ctx, &afterUnlink, `SELECT EXISTS(SELECT 1 FROM contact WHERE contact_id = $1);`, contactID))
+		require.NoError(t, studentRepo.Delete(ctx, studentIDs[1]))
+		var afterDelete bool
+		require.NoError(t, db.DB.Get(ctx, &afterDelete, `SELECT EXISTS(SELECT 1 FROM contact WHERE contact_id = $1);`, contactID))
+		//assert
+		assert.True(t, afterUnlink)
+		assert.False(t, afterDelete)
+	})
+	t.Run("Unknown student and contact", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentID := addStudents(ctx, t, db, 1)[0]
+		contactRepo := NewContactStorage(db.DB)
+		//act
+		_, addErr := contactRepo.Add(ctx, 999, mother)
+		linkErr := contactRepo.Link(ctx, studentID, 999, models.RelationshipMother)
+		_, listErr := contactRepo.ListByStudent(ctx, 999)
+		unlinkErr := contactRepo.Unlink(ctx, studentID, 999)
+		//assert
+		assert.ErrorIs(t, addErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, linkErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, listErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, unlinkErr, pkgErrors.ErrNotFound)
+	})
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
+)
+
+const contactColumns = `c.contact_id, c.contact_name, sc.relationship, c.email, c.phone, c.preferred_channel,
+	c.consent_email, c.consent_sms, c.consent_phone, c.updated_at`
+
+// ContactStorage keeps the parent and guardian contacts of students. A contact is removed with its last link to a student.
+type ContactStorage struct {
+	db connection.DBops
+}
+
+func NewContactStorage(database connection.DBops) ContactStorage {
+	return ContactStorage{db: database}
+}
+
+// contactLinkError maps the errors of linking a contact to a student: ErrNotFound for an unknown student
+// or contact, and ErrConflict when they are already linked.
+func contactLinkError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case foreignKeyViolation:
+			return pkgErrors.ErrNotFound
+		case uniqueViolation:
+			return pkgErrors.ErrConflict
+		}
+	}
+
+	return err
+}
+
+// ListByStudent returns the contacts of a student ordered by name. It returns ErrNotFound for an unknown student.
+func (r *ContactStorage) ListByStudent(ctx context.Context, studentID int64) ([]models.Contact, error) {
+	ctx, span := tracer.Start(ctx, "ContactStorage.ListByStudent")
+	defer span.End()
+
+	var contacts []entities.Contact
+
+	err := r.db.Select(ctx, &contacts, `
+		SELECT `+contactColumns+`
+		FROM student_contact sc
+		JOIN contact c ON c.contact_id = sc.contact_id
+		WHERE sc.student_id = $1
+		ORDER BY c.contact_name, c.contact_id;
+	`, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contacts) == 0 {
+		var exists bool
+		if err := r.db.Get(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM student WHERE student_id = $1);`, studentID); err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, pkgErrors.ErrNotFound
+		}
+	}
+
+	return utils.Map(contacts, func(c entities.Contact) models.Contact {
+		return c.ToContactDomain()
+	}), nil
+}
+
+// GetByStudent returns a contact of a student. It returns ErrNotFound unless the contact is linked to the student.
+func (r *ContactStorage) GetByStudent(ctx context.Context, studentID, contactID int64) (models.Contact, error) {
+	ctx, span := tracer.Start(ctx, "ContactStorage.GetByStudent")
+	defer span.End()
+
+	var contact entities.Contact
+
+	err := r.db.Get(ctx, &contact, `
+		SELECT `+contactColumns+`
+		FROM student_contact sc
+		JOIN contact c ON c.contact_id = sc.contact_id
+		WHERE sc.student_id = $1 AND sc.contact_id = $2;
+	`, studentID, contactID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.Contact{}, pkgErrors.ErrNotFound
+		}
+
+		return models.Contact{}, err
+	}
+
+	return contact.ToContactDomain(), nil
+}
+
+// Add creates a contact and links it to a student. It returns ErrNotFound for an unknown student.
+func (r *ContactStorage) Add(ctx context.Context, studentID int64, contact models.Contact) (int64, error) {
+	ctx, span := tracer.Start(ctx, "ContactStorage.Add")
+	defer span.End()
+
+	var contactID int64
+
+	err := r.db.InTx(ctx, func(tx connection.DBops) error {
+		err := tx.ExecQueryRow(ctx, `
+			INSERT INTO contact(contact_name, email, phone, preferred_channel, consent_email, consent_sms, consent_phone)
+			VALUES($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6, $7)
+			RETURNING contact_id;
+		`, contact.Name, contact.Email, contact.Phone, contact.PreferredChannel,
+			contact.ConsentEmail, contact.ConsentSMS, contact.ConsentPhone).Scan(&contactID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO student_contact(student_id, contact_id, relationship) VALUES($1, $2, $3)
+		`, studentID, contactID, contact.Relationship)
+
+		return err
+	})
+	if err != nil {
+		return -1, contactLinkError(err)
+	}
+
+	return contactID, nil
+}
+
+// Link links an existing contact, such as the parent of a sibling, to a student. It returns ErrNotFound
+// for an unknown student or contact, and ErrConflict when the contact is already linked to the student.
+func (r *ContactStorage) Link(ctx context.Context, studentID, contactID int64, relationship string) error {
+	ctx, span := tracer.Start(ctx, "ContactStorage.Link")
+	defer span.End()
+
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO student_contact(student_id, contact_id, relationship) VALUES($1, $2, $3)
+	`, studentID, contactID, relationship)
+
+	return contactLinkError(err)
+}
+
+// Update changes a contact and its relationship to a student. Changes other than the relationship
+// are seen by every student the contact is linked to. It returns ErrNotFound unless the contact is
+// linked to the student.
+func (r *ContactStorage) Update(ctx context.Context, studentID int64, contact models.Contact) error {
+	ctx, span := tracer.Start(ctx, "ContactStorage.Update")
+	defer span.End()
+
+	return r.db.InTx(ctx, func(tx connection.DBops) error {
+		command, err := tx.Exec(ctx, `
+			UPDATE student_contact SET relationship = $3 WHERE student_id = $1 AND contact_id = $2
+		`, studentID, contact.ContactID, contact.Relationship)
+		if err != nil {
+			return err
+		}
+
+		if command.RowsAffected() == 0 {
+			return pkgErrors.ErrNotFound
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE contact
+			SET contact_name = $2, email = NULLIF($3, ''), phone = NULLIF($4, ''), preferred_channel = $5,
+				consent_email = $6, consent_sms = $7, consent_phone = $8, updated_at = NOW()
+			WHERE contact_id = $1
+		`, contact.ContactID, contact.Name, contact.Email, contact.Phone, contact.PreferredChannel,
+			contact.ConsentEmail, contact.ConsentSMS, contact.ConsentPhone)
+
+		return err
+	})
+}
+
+// Unlink removes a contact from a student. The contact itself is removed once no student is linked to it.
+// It returns ErrNotFound unless the contact is linked to the student.
+func (r *ContactStorage) Unlink(ctx context.Context, studentID, contactID int64) error {
+	ctx, span := tracer.Start(ctx, "ContactStorage.Unlink")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, `
+		DELETE FROM student_contact WHERE student_id = $1 AND contact_id = $2
+	`, studentID, contactID)
+	if err != nil {
+		return err
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
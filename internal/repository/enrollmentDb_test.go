@@ -0,0 +1,203 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+	"sync"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+// addClass creates a class with capacity seats and returns its id.
+func addClass(ctx context.Context, t *testing.T, db *postgres.TDB, className string, capacity int64) int64 {
+	t.Helper()
+
+	var classID int64
+	err := db.DB.Get(ctx, &classID, `INSERT INTO class(class_name, capacity) VALUES($1, $2) RETURNING class_id;`, className, capacity)
+	require.NoError(t, err)
+
+	return classID
+}
+
+// addStudents creates count students and returns their ids.
+func addStudents(ctx context.Context, t *testing.T, db *postgres.TDB, count int) []int64 {
+	t.Helper()
+
+	studentRepo := NewStudentStorage(db.DB)
+	studentIDs := make([]int64, 0, count)
+	for i := 0; i < count; i++ {
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		studentIDs = append(studentIDs, studentID)
+	}
+
+	return studentIDs
+}
+
+func eventNames(events []models.EnrollmentEvent) []string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, event.Event)
+	}
+
+	return names
+}
+
+func TestWaitlist(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Waitlisted when full", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		addClass(ctx, t, db, "Math", 1)
+		studentIDs := addStudents(ctx, t, db, 2)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		gradeRepo := NewGradeStorage(db.DB)
+		enrolledID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[0], ClassName: "Math"})
+		require.NoError(t, err)
+		//act
+		waitlistedID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[1], ClassName: "Math"})
+		//assert
+		require.NoError(t, err)
+		enrolled, err := classInfoRepo.GetByID(ctx, enrolledID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EnrollmentStatusEnrolled, enrolled.Status)
+		waitlisted, err := classInfoRepo.GetByID(ctx, waitlistedID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EnrollmentStatusWaitlisted, waitlisted.Status)
+		_, err = gradeRepo.Set(ctx, models.Grade{ClassInfoID: waitlistedID, Score: 90, Letter: "A", GradePoints: 4, Scale: "simple"})
+		assert.ErrorIs(t, err, pkgErrors.ErrWaitlisted)
+	})
+	t.Run("Promoted on drop", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		classID := addClass(ctx, t, db, "Math", 1)
+		studentIDs := addStudents(ctx, t, db, 3)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		classRepo := NewClassStorage(db.DB)
+		enrolledID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[0], ClassName: "Math"})
+		require.NoError(t, err)
+		firstID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[1], ClassName: "Math"})
+		require.NoError(t, err)
+		secondID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[2], ClassName: "Math"})
+		require.NoError(t, err)
+		//act
+		err = classInfoRepo.DeleteByID(ctx, enrolledID)
+		//assert
+		require.NoError(t, err)
+		first, err := classInfoRepo.GetByID(ctx, firstID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EnrollmentStatusEnrolled, first.Status)
+		second, err := classInfoRepo.GetByID(ctx, secondID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EnrollmentStatusWaitlisted, second.Status)
+		events, err := classRepo.ListEvents(ctx, classID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"enrolled", "waitlisted", "waitlisted", "dropped", "promoted"}, eventNames(events))
+		assert.Equal(t, firstID, events[4].ClassInfoID)
+	})
+	t.Run("Promoted when moved out", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		addClass(ctx, t, db, "Math", 1)
+		addClass(ctx, t, db, "Art", 1)
+		studentIDs := addStudents(ctx, t, db, 3)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[0], ClassName: "Art"})
+		require.NoError(t, err)
+		movedID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[1], ClassName: "Math"})
+		require.NoError(t, err)
+		waitingID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[2], ClassName: "Math"})
+		require.NoError(t, err)
+		//act
+		err = classInfoRepo.UpdateByID(ctx, movedID, models.ClassInfo{ClassName: "Art"})
+		//assert
+		require.NoError(t, err)
+		moved, err := classInfoRepo.GetByID(ctx, movedID)
+		require.NoError(t, err)
+		assert.Equal(t, "Art", moved.ClassName)
+		assert.Equal(t, models.EnrollmentStatusWaitlisted, moved.Status)
+		waiting, err := classInfoRepo.GetByID(ctx, waitingID)
+		require.NoError(t, err)
+		assert.Equal(t, models.EnrollmentStatusEnrolled, waiting.Status)
+	})
+	t.Run("Promoted when capacity is raised", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		classID := addClass(ctx, t, db, "Math", 0)
+		studentIDs := addStudents(ctx, t, db, 2)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		classRepo := NewClassStorage(db.DB)
+		firstID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[0], ClassName: "Math"})
+		require.NoError(t, err)
+		secondID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentIDs[1], ClassName: "Math"})
+		require.NoError(t, err)
+		//act
+		err = classRepo.Update(ctx, classID, models.Class{Credits: 1})
+		//assert
+		require.NoError(t, err)
+		for _, id := range []int64{firstID, secondID} {
+			enrollment, err := classInfoRepo.GetByID(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, models.EnrollmentStatusEnrolled, enrollment.Status)
+		}
+	})
+	t.Run("Concurrent adds stay within capacity", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		const capacity = 3
+		addCurrentTerm(ctx, t, db)
+		addClass(ctx, t, db, "Math", capacity)
+		studentIDs := addStudents(ctx, t, db, 10)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		var wg sync.WaitGroup
+		errs := make(chan error, len(studentIDs))
+		//act
+		for _, studentID := range studentIDs {
+			wg.Add(1)
+			go func(studentID int64) {
+				defer wg.Done()
+				_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+				errs <- err
+			}(studentID)
+		}
+		wg.Wait()
+		close(errs)
+		//assert
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		enrollments, err := classInfoRepo.GetByStudentIDs(ctx, studentIDs)
+		require.NoError(t, err)
+		enrolled := 0
+		for _, classes := range enrollments {
+			for _, class := range classes {
+				if class.Status == models.EnrollmentStatusEnrolled {
+					enrolled++
+				}
+			}
+		}
+		assert.Equal(t, capacity, enrolled)
+	})
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+)
+
+// Enrollments are kept within the capacity of their class by taking a row lock on the class
+// before counting its seats. Every change that adds, moves or drops enrollments runs in one
+// transaction that locks the classes involved, so concurrent requests for a class are serialized.
+// Moves and drops lock the enrollments they change before their classes.
+
+// lockClasses creates the missing classes of classNames and locks them for the rest of the transaction.
+// Locks are taken in name order, so that transactions locking several classes cannot deadlock.
+func lockClasses(ctx context.Context, tx connection.DBops, classNames ...string) error {
+	names := make([]string, 0, len(classNames))
+	seen := make(map[string]bool, len(classNames))
+
+	for _, name := range classNames {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	sort.Strings(names)
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO class(class_name) SELECT unnest($1::text[]) ON CONFLICT (class_name) DO NOTHING;
+	`, names); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `SELECT class_id FROM class WHERE class_name = ANY($1) ORDER BY class_name FOR UPDATE;`, names)
+
+	return err
+}
+
+// seatStatus returns the status of one more enrollment in a class and term: enrolled while seats are free,
+// waitlisted otherwise. The class must be locked.
+func seatStatus(ctx context.Context, tx connection.DBops, className string, termID int64) (string, error) {
+	var status string
+
+	err := tx.Get(ctx, &status, `
+		SELECT CASE
+			WHEN c.capacity IS NULL OR c.capacity > (
+				SELECT COUNT(*) FROM class_info
+				WHERE class_name = $1 AND term_id = $2 AND status = 'enrolled'
+			) THEN 'enrolled'
+			ELSE 'waitlisted'
+		END
+		FROM class c WHERE c.class_name = $1;
+	`, className, termID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			// Enrollments without a class name have no class and so no capacity.
+			return models.EnrollmentStatusEnrolled, nil
+		}
+
+		return "", err
+	}
+
+	return status, nil
+}
+
+// recordEvent appends event for enrollment to the enrollment log.
+func recordEvent(ctx context.Context, tx connection.DBops, enrollment entities.ClassInfo, event string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO enrollment_event(class_info_id, student_id, class_name, term_id, event) VALUES($1, $2, $3, $4, $5);
+	`, enrollment.ID, enrollment.StudentID, enrollment.ClassName, enrollment.TermID, event)
+
+	return err
+}
+
+// fillSeats promotes waitlisted enrollments of a class and term, longest waiting first, until the class is full,
+// and records a promoted event for each. The class must be locked.
+func fillSeats(ctx context.Context, tx connection.DBops, className string, termID int64) error {
+	_, err := tx.Exec(ctx, `
+		WITH free AS (
+			-- A NULL limit promotes every waitlisted enrollment of a class without capacity.
+			SELECT CASE WHEN c.capacity IS NOT NULL THEN GREATEST(c.capacity - (
+				SELECT COUNT(*) FROM class_info
+				WHERE class_name = $1 AND term_id = $2 AND status = 'enrolled'
+			), 0) END AS seats
+			FROM class c WHERE c.class_name = $1
+		), promoted AS (
+			UPDATE class_info SET status = 'enrolled', waitlisted_at = NULL
+			WHERE id IN (
+				SELECT id FROM class_info
+				WHERE class_name = $1 AND term_id = $2 AND status = 'waitlisted'
+				ORDER BY waitlisted_at, id
+				LIMIT (SELECT seats FROM free)
+			)
+			RETURNING id, student_id, class_name, term_id
+		)
+		INSERT INTO enrollment_event(class_info_id, student_id, class_name, term_id, event)
+		SELECT id, student_id, class_name, term_id, 'promoted' FROM promoted;
+	`, className, termID)
+
+	return err
+}
+
+// selectEnrollments locks and returns the enrollments matching where, a condition on class_info with the
+// arguments args. It returns ErrNotFound when none match.
+func selectEnrollments(ctx context.Context, tx connection.DBops, where string, args []interface{}) ([]entities.ClassInfo, error) {
+	var enrollments []entities.ClassInfo
+
+	err := tx.Select(ctx, &enrollments, `SELECT `+classInfoColumns+` FROM class_info WHERE `+where+` ORDER BY id FOR UPDATE;`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(enrollments) == 0 {
+		return nil, pkgErrors.ErrNotFound
+	}
+
+	return enrollments, nil
+}
+
+// lockEnrollments locks the enrollments matching where, then their classes and extra, and returns the enrollments.
+// Enrollments matching where can be added until their class is locked, so they are selected again after
+// locking the classes, until no enrollment of an unlocked class shows up.
+func lockEnrollments(
+	ctx context.Context,
+	tx connection.DBops,
+	where string,
+	args []interface{},
+	extra ...string,
+) ([]entities.ClassInfo, error) {
+	locked := make(map[string]bool)
+
+	for {
+		enrollments, err := selectEnrollments(ctx, tx, where, args)
+		if err != nil {
+			return nil, err
+		}
+
+		var unlocked []string
+
+		for _, name := range classNames(enrollments, extra...) {
+			if !locked[name] {
+				unlocked = append(unlocked, name)
+			}
+		}
+
+		if len(unlocked) == 0 {
+			return enrollments, nil
+		}
+
+		if err := lockClasses(ctx, tx, unlocked...); err != nil {
+			return nil, err
+		}
+
+		for _, name := range unlocked {
+			locked[name] = true
+		}
+	}
+}
+
+// classNames returns the class names of enrollments, followed by extra.
+func classNames(enrollments []entities.ClassInfo, extra ...string) []string {
+	return append(utils.Map(enrollments, func(c entities.ClassInfo) string {
+		return c.ClassName
+	}), extra...)
+}
+
+// drop deletes the enrollments matching where, records a dropped event for each and fills the freed seats.
+func drop(ctx context.Context, tx connection.DBops, where string, args ...interface{}) error {
+	enrollments, err := lockEnrollments(ctx, tx, where, args)
+	if err != nil {
+		return err
+	}
+
+	ids := utils.Map(enrollments, func(c entities.ClassInfo) int64 { return c.ID })
+
+	var dropped []entities.ClassInfo
+
+	if err := tx.Select(ctx, &dropped, `DELETE FROM class_info WHERE id = ANY($1) RETURNING `+classInfoColumns+`;`, ids); err != nil {
+		return err
+	}
+
+	for _, enrollment := range dropped {
+		if err := recordEvent(ctx, tx, enrollment, models.EnrollmentEventDropped); err != nil {
+			return err
+		}
+	}
+
+	return fillClasses(ctx, tx, dropped)
+}
+
+// move moves the enrollments matching where, a condition with the arguments args, to className. Each moved enrollment takes a seat in the new class
+// when one is free and is waitlisted otherwise; the seats freed in the old classes are filled. The grade and
+// submissions of a moved enrollment belong to the old class, so they are deleted.
+func move(ctx context.Context, tx connection.DBops, className, where string, args ...interface{}) error {
+	enrollments, err := lockEnrollments(ctx, tx, where, args, className)
+	if err != nil {
+		return err
+	}
+
+	var moved []entities.ClassInfo
+
+	for _, enrollment := range enrollments {
+		if enrollment.ClassName == className {
+			continue
+		}
+
+		status, err := seatStatus(ctx, tx, className, enrollment.TermID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE class_info
+			SET class_name = $2, status = $3, waitlisted_at = CASE WHEN $3 = 'waitlisted' THEN NOW() END
+			WHERE id = $1
+		`, enrollment.ID, className, status)
+		if err != nil {
+			return err
+		}
+
+		if err := clearCoursework(ctx, tx, enrollment.ID); err != nil {
+			return err
+		}
+
+		if err := recordEvent(ctx, tx, enrollment, models.EnrollmentEventDropped); err != nil {
+			return err
+		}
+
+		moved = append(moved, enrollment)
+		enrollment.ClassName, enrollment.Status = className, status
+
+		if err := recordEvent(ctx, tx, enrollment, status); err != nil {
+			return err
+		}
+	}
+
+	return fillClasses(ctx, tx, moved)
+}
+
+// clearCoursework deletes the grade and the submissions of an enrollment.
+func clearCoursework(ctx context.Context, tx connection.DBops, classInfoID int64) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM grade WHERE class_info_id = $1;`, classInfoID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM submission WHERE class_info_id = $1;`, classInfoID)
+
+	return err
+}
+
+// fillClasses fills the seats of the classes and terms that enrollments left.
+func fillClasses(ctx context.Context, tx connection.DBops, enrollments []entities.ClassInfo) error {
+	type classTerm struct {
+		className string
+		termID    int64
+	}
+
+	filled := make(map[classTerm]bool, len(enrollments))
+
+	for _, enrollment := range enrollments {
+		key := classTerm{className: enrollment.ClassName, termID: enrollment.TermID}
+		if filled[key] {
+			continue
+		}
+
+		filled[key] = true
+
+		if err := fillSeats(ctx, tx, key.className, key.termID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
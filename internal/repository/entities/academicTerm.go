@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+type AcademicTerm struct {
+	TermID    int64     `db:"term_id"`
+	Name      string    `db:"name"`
+	StartDate time.Time `db:"start_date"`
+	EndDate   time.Time `db:"end_date"`
+	Status    string    `db:"status"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (t *AcademicTerm) ToAcademicTermDomain() models.AcademicTerm {
+	return models.AcademicTerm{
+		TermID:    t.TermID,
+		Name:      t.Name,
+		StartDate: t.StartDate.Format(models.DateLayout),
+		EndDate:   t.EndDate.Format(models.DateLayout),
+		Status:    t.Status,
+	}
+}
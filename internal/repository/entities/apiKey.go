@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+type APIKey struct {
+	ID         int64      `db:"id"`
+	Name       string     `db:"name"`
+	Prefix     string     `db:"prefix"`
+	KeyHash    []byte     `db:"key_hash"`
+	CreatedAt  time.Time  `db:"created_at"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+}
+
+func (k *APIKey) ToAPIKeyDomain() models.APIKey {
+	return models.APIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		CreatedAt:  k.CreatedAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+type Assignment struct {
+	AssignmentID int64     `db:"assignment_id"`
+	ClassID      int64     `db:"class_id"`
+	TermID       int64     `db:"term_id"`
+	Title        string    `db:"title"`
+	Description  string    `db:"description"`
+	DueAt        time.Time `db:"due_at"`
+	MaxPoints    float64   `db:"max_points"`
+	Weight       float64   `db:"weight"`
+}
+
+func (a *Assignment) ToAssignmentDomain() models.Assignment {
+	weight := a.Weight
+
+	return models.Assignment{
+		AssignmentID: a.AssignmentID,
+		ClassID:      a.ClassID,
+		TermID:       a.TermID,
+		Title:        a.Title,
+		Description:  a.Description,
+		DueAt:        a.DueAt,
+		MaxPoints:    a.MaxPoints,
+		Weight:       &weight,
+	}
+}
+
+// Submission is an enrolled student joined with their submission of an assignment, if one was recorded.
+// AssignmentID is nil for a student of a gradebook whose class has no assignments.
+type Submission struct {
+	AssignmentID *int64     `db:"assignment_id"`
+	StudentID    int64      `db:"student_id"`
+	StudentName  string     `db:"student_name"`
+	ClassInfoID  int64      `db:"class_info_id"`
+	Status       *string    `db:"status"`
+	SubmittedAt  *time.Time `db:"submitted_at"`
+	Late         bool       `db:"late"`
+	Score        *float64   `db:"score"`
+	Feedback     *string    `db:"feedback"`
+}
+
+func (s *Submission) ToSubmissionDomain() models.Submission {
+	submission := models.Submission{
+		StudentID:   s.StudentID,
+		StudentName: s.StudentName,
+		ClassInfoID: s.ClassInfoID,
+		Status:      models.SubmissionPending,
+		SubmittedAt: s.SubmittedAt,
+		Late:        s.Late,
+		Score:       s.Score,
+	}
+
+	if s.AssignmentID != nil {
+		submission.AssignmentID = *s.AssignmentID
+	}
+
+	if s.Status != nil {
+		submission.Status = *s.Status
+	}
+
+	if s.Feedback != nil {
+		submission.Feedback = *s.Feedback
+	}
+
+	return submission
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+// ClassSession is a row of class_session joined with the name of its class.
+type ClassSession struct {
+	SessionID int64     `db:"session_id"`
+	ClassID   int64     `db:"class_id"`
+	ClassName string    `db:"class_name"`
+	StartsAt  time.Time `db:"starts_at"`
+	EndsAt    time.Time `db:"ends_at"`
+}
+
+func (s *ClassSession) ToClassSessionDomain() models.ClassSession {
+	return models.ClassSession{
+		SessionID: s.SessionID,
+		ClassID:   s.ClassID,
+		ClassName: s.ClassName,
+		StartsAt:  s.StartsAt,
+		EndsAt:    s.EndsAt,
+	}
+}
+
+// AttendanceRecord is a student on the roster of a session, with the attendance row if they were marked.
+type AttendanceRecord struct {
+	StudentID   int64      `db:"student_id"`
+	StudentName string     `db:"student_name"`
+	Status      *string    `db:"status"`
+	Note        *string    `db:"note"`
+	RecordedAt  *time.Time `db:"recorded_at"`
+}
+
+func (a *AttendanceRecord) ToAttendanceRecordDomain() models.AttendanceRecord {
+	record := models.AttendanceRecord{
+		StudentID:   a.StudentID,
+		StudentName: a.StudentName,
+		RecordedAt:  a.RecordedAt,
+	}
+
+	if a.Status != nil {
+		record.Status = *a.Status
+	}
+
+	if a.Note != nil {
+		record.Note = *a.Note
+	}
+
+	return record
+}
+
+// AttendanceCount counts the sessions of a student in a class by attendance status.
+type AttendanceCount struct {
+	StudentID   int64  `db:"student_id"`
+	StudentName string `db:"student_name"`
+	ClassID     int64  `db:"class_id"`
+	ClassName   string `db:"class_name"`
+	Sessions    int64  `db:"sessions"`
+	Present     int64  `db:"present"`
+	Late        int64  `db:"late"`
+	Absent      int64  `db:"absent"`
+	Excused     int64  `db:"excused"`
+}
+
+func (a *AttendanceCount) ToAttendanceRateDomain() models.AttendanceRate {
+	return models.AttendanceRate{
+		StudentID:   a.StudentID,
+		StudentName: a.StudentName,
+		ClassID:     a.ClassID,
+		ClassName:   a.ClassName,
+		Sessions:    a.Sessions,
+		Present:     a.Present,
+		Late:        a.Late,
+		Absent:      a.Absent,
+		Excused:     a.Excused,
+		Unmarked:    a.Sessions - a.Present - a.Late - a.Absent - a.Excused,
+	}
+}
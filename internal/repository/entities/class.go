@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+type Class struct {
+	ClassID   int64     `db:"class_id"`
+	ClassName string    `db:"class_name"`
+	Credits   float64   `db:"credits"`
+	Capacity  *int64    `db:"capacity"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (c *Class) ToClassDomain() models.Class {
+	return models.Class{
+		ClassID:   c.ClassID,
+		ClassName: c.ClassName,
+		Credits:   c.Credits,
+		Capacity:  c.Capacity,
+	}
+}
@@ -1,11 +1,16 @@
 package entities
 
-import "CRUD_Go_Backend/internal/handlers/models"
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
 
 type ClassInfo struct {
 	ID        int64  `db:"id"`
 	StudentID int64  `db:"student_id"`
 	ClassName string `db:"class_name"`
+	TermID    int64  `db:"term_id"`
+	Status    string `db:"status"`
 }
 
 func (c *ClassInfo) ToClassInfoDomain() models.ClassInfo {
@@ -13,5 +18,29 @@ func (c *ClassInfo) ToClassInfoDomain() models.ClassInfo {
 		ID:        c.ID,
 		StudentID: c.StudentID,
 		ClassName: c.ClassName,
+		TermID:    c.TermID,
+		Status:    c.Status,
+	}
+}
+
+type EnrollmentEvent struct {
+	EventID     int64     `db:"event_id"`
+	ClassInfoID int64     `db:"class_info_id"`
+	StudentID   int64     `db:"student_id"`
+	ClassName   string    `db:"class_name"`
+	TermID      int64     `db:"term_id"`
+	Event       string    `db:"event"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (e *EnrollmentEvent) ToEnrollmentEventDomain() models.EnrollmentEvent {
+	return models.EnrollmentEvent{
+		EventID:     e.EventID,
+		ClassInfoID: e.ClassInfoID,
+		StudentID:   e.StudentID,
+		ClassName:   e.ClassName,
+		TermID:      e.TermID,
+		Event:       e.Event,
+		CreatedAt:   e.CreatedAt,
 	}
 }
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+// Contact is a row of contact joined with its relationship to a student.
+type Contact struct {
+	ContactID        int64     `db:"contact_id"`
+	ContactName      string    `db:"contact_name"`
+	Relationship     string    `db:"relationship"`
+	Email            *string   `db:"email"`
+	Phone            *string   `db:"phone"`
+	PreferredChannel string    `db:"preferred_channel"`
+	ConsentEmail     bool      `db:"consent_email"`
+	ConsentSMS       bool      `db:"consent_sms"`
+	ConsentPhone     bool      `db:"consent_phone"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+func (c *Contact) ToContactDomain() models.Contact {
+	contact := models.Contact{
+		ContactID:        c.ContactID,
+		Name:             c.ContactName,
+		Relationship:     c.Relationship,
+		PreferredChannel: c.PreferredChannel,
+		ConsentEmail:     c.ConsentEmail,
+		ConsentSMS:       c.ConsentSMS,
+		ConsentPhone:     c.ConsentPhone,
+	}
+
+	if c.Email != nil {
+		contact.Email = *c.Email
+	}
+
+	if c.Phone != nil {
+		contact.Phone = *c.Phone
+	}
+
+	return contact
+}
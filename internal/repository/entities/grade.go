@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+type Grade struct {
+	ClassInfoID int64     `db:"class_info_id"`
+	Score       float64   `db:"score"`
+	Letter      string    `db:"letter"`
+	GradePoints float64   `db:"grade_points"`
+	Scale       string    `db:"scale"`
+	GradedAt    time.Time `db:"graded_at"`
+}
+
+func (g *Grade) ToGradeDomain() models.Grade {
+	return models.Grade{
+		ClassInfoID: g.ClassInfoID,
+		Score:       g.Score,
+		Letter:      g.Letter,
+		GradePoints: g.GradePoints,
+		Scale:       g.Scale,
+		GradedAt:    g.GradedAt,
+	}
+}
+
+// TranscriptEntry is a row of the transcript query. The grade columns are NULL for ungraded enrollments.
+type TranscriptEntry struct {
+	AcademicTerm
+	ClassInfoID int64      `db:"class_info_id"`
+	ClassName   string     `db:"class_name"`
+	Credits     float64    `db:"credits"`
+	Score       *float64   `db:"score"`
+	Letter      *string    `db:"letter"`
+	GradePoints *float64   `db:"grade_points"`
+	Scale       *string    `db:"scale"`
+	GradedAt    *time.Time `db:"graded_at"`
+}
+
+func (e *TranscriptEntry) ToTranscriptEntryDomain() models.TranscriptEntry {
+	entry := models.TranscriptEntry{
+		Term:        e.AcademicTerm.ToAcademicTermDomain(),
+		ClassInfoID: e.ClassInfoID,
+		ClassName:   e.ClassName,
+		Credits:     e.Credits,
+	}
+
+	if e.Score != nil && e.Letter != nil && e.GradePoints != nil && e.Scale != nil && e.GradedAt != nil {
+		entry.Grade = &models.Grade{
+			ClassInfoID: e.ClassInfoID,
+			Score:       *e.Score,
+			Letter:      *e.Letter,
+			GradePoints: *e.GradePoints,
+			Scale:       *e.Scale,
+			GradedAt:    *e.GradedAt,
+		}
+	}
+
+	return entry
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+// PrerequisiteRequirement is a row of class_prerequisite joined with the name of the required class.
+type PrerequisiteRequirement struct {
+	GroupNo   int      `db:"group_no"`
+	ClassID   int64    `db:"class_id"`
+	ClassName string   `db:"class_name"`
+	MinScore  *float64 `db:"min_score"`
+}
+
+func (p *PrerequisiteRequirement) ToRequirementDomain() models.Requirement {
+	return models.Requirement{
+		ClassID:   p.ClassID,
+		ClassName: p.ClassName,
+		MinScore:  p.MinScore,
+	}
+}
+
+// ClassScore is the best score of a student in a class.
+type ClassScore struct {
+	ClassID int64   `db:"class_id"`
+	Score   float64 `db:"score"`
+}
+
+type AuditEvent struct {
+	AuditID   int64     `db:"audit_id"`
+	Actor     string    `db:"actor"`
+	Action    string    `db:"action"`
+	StudentID *int64    `db:"student_id"`
+	ClassName *string   `db:"class_name"`
+	Detail    string    `db:"detail"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (a *AuditEvent) ToAuditEventDomain() models.AuditEvent {
+	return models.AuditEvent{
+		AuditID:   a.AuditID,
+		Actor:     a.Actor,
+		Action:    a.Action,
+		StudentID: a.StudentID,
+		ClassName: a.ClassName,
+		Detail:    a.Detail,
+		CreatedAt: a.CreatedAt,
+	}
+}
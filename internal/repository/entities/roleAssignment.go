@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+type RoleAssignment struct {
+	Subject   string    `db:"subject"`
+	Role      string    `db:"role"`
+	StudentID *int64    `db:"student_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (r *RoleAssignment) ToRoleAssignmentDomain() models.RoleAssignment {
+	return models.RoleAssignment{
+		Subject:   r.Subject,
+		Role:      r.Role,
+		StudentID: r.StudentID,
+		CreatedAt: r.CreatedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+type Room struct {
+	RoomID    int64     `db:"room_id"`
+	RoomName  string    `db:"room_name"`
+	Capacity  *int64    `db:"capacity"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (r *Room) ToRoomDomain() models.Room {
+	return models.Room{
+		RoomID:   r.RoomID,
+		RoomName: r.RoomName,
+		Capacity: r.Capacity,
+	}
+}
+
+// ScheduleSlot is a row of schedule_slot joined with the names of its class and room. Times are
+// formatted as models.TimeLayout, and Status is the enrollment status in student schedules.
+type ScheduleSlot struct {
+	SlotID    int64   `db:"slot_id"`
+	ClassID   int64   `db:"class_id"`
+	ClassName string  `db:"class_name"`
+	TermID    int64   `db:"term_id"`
+	RoomID    int64   `db:"room_id"`
+	RoomName  string  `db:"room_name"`
+	DayOfWeek int     `db:"day_of_week"`
+	StartTime string  `db:"start_time"`
+	EndTime   string  `db:"end_time"`
+	Status    *string `db:"status"`
+}
+
+func (s *ScheduleSlot) ToScheduleSlotDomain() models.ScheduleSlot {
+	slot := models.ScheduleSlot{
+		SlotID:    s.SlotID,
+		ClassID:   s.ClassID,
+		ClassName: s.ClassName,
+		TermID:    s.TermID,
+		RoomID:    s.RoomID,
+		RoomName:  s.RoomName,
+		DayOfWeek: s.DayOfWeek,
+		StartTime: s.StartTime,
+		EndTime:   s.EndTime,
+	}
+
+	if s.Status != nil {
+		slot.Status = *s.Status
+	}
+
+	return slot
+}
@@ -6,16 +6,71 @@ import (
 )
 
 type Student struct {
-	StudentID   int64     `db:"student_id"`
-	StudentName string    `db:"student_name"`
-	Grade       int64     `db:"grade"`
-	CreatedAt   time.Time `db:"created_at"`
+	StudentID     int64      `db:"student_id"`
+	StudentName   string     `db:"student_name"`
+	Grade         int64      `db:"grade"`
+	FirstName     string     `db:"first_name"`
+	LastName      string     `db:"last_name"`
+	PreferredName string     `db:"preferred_name"`
+	DateOfBirth   *time.Time `db:"date_of_birth"`
+	Email         *string    `db:"email"`
+	StudentNumber *string    `db:"student_number"`
+	Status        string     `db:"status"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
 }
 
+// ToStudentDomain converts the selected columns of a student. Timestamps that were not selected stay nil.
 func (s *Student) ToStudentDomain() models.StudentRequest {
-	return models.StudentRequest{
-		StudentID:   s.StudentID,
-		StudentName: s.StudentName,
-		Grade:       s.Grade,
+	student := models.StudentRequest{
+		StudentID:     s.StudentID,
+		StudentName:   s.StudentName,
+		Grade:         s.Grade,
+		FirstName:     s.FirstName,
+		LastName:      s.LastName,
+		PreferredName: s.PreferredName,
+		Status:        s.Status,
+	}
+
+	if s.DateOfBirth != nil {
+		student.DateOfBirth = s.DateOfBirth.Format(models.DateLayout)
+	}
+
+	if s.Email != nil {
+		student.Email = *s.Email
+	}
+
+	if s.StudentNumber != nil {
+		student.StudentNumber = *s.StudentNumber
+	}
+
+	if !s.CreatedAt.IsZero() {
+		createdAt := s.CreatedAt
+		student.CreatedAt = &createdAt
+	}
+
+	if !s.UpdatedAt.IsZero() {
+		updatedAt := s.UpdatedAt
+		student.UpdatedAt = &updatedAt
+	}
+
+	return student
+}
+
+type StudentStatusChange struct {
+	StudentID  int64     `db:"student_id"`
+	FromStatus *string   `db:"from_status"`
+	ToStatus   string    `db:"to_status"`
+	Reason     string    `db:"reason"`
+	ChangedAt  time.Time `db:"changed_at"`
+}
+
+func (c *StudentStatusChange) ToStudentStatusChangeDomain() models.StudentStatusChange {
+	return models.StudentStatusChange{
+		StudentID:  c.StudentID,
+		FromStatus: c.FromStatus,
+		ToStatus:   c.ToStatus,
+		Reason:     c.Reason,
+		ChangedAt:  c.ChangedAt,
 	}
 }
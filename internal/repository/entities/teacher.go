@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"time"
+)
+
+type Teacher struct {
+	TeacherID   int64     `db:"teacher_id"`
+	TeacherName string    `db:"teacher_name"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (t *Teacher) ToTeacherDomain() models.TeacherRequest {
+	return models.TeacherRequest{
+		TeacherID:   t.TeacherID,
+		TeacherName: t.TeacherName,
+	}
+}
+
+type TeacherAssignment struct {
+	ClassID     int64  `db:"class_id"`
+	ClassName   string `db:"class_name"`
+	TeacherID   int64  `db:"teacher_id"`
+	TeacherName string `db:"teacher_name"`
+	Role        string `db:"role"`
+}
+
+func (a *TeacherAssignment) ToTeacherAssignmentDomain() models.TeacherAssignment {
+	return models.TeacherAssignment{
+		ClassID:     a.ClassID,
+		ClassName:   a.ClassName,
+		TeacherID:   a.TeacherID,
+		TeacherName: a.TeacherName,
+		Role:        a.Role,
+	}
+}
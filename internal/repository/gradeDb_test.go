@@ -0,0 +1,166 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+func TestGrade(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Regraded", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		studentRepo := NewStudentStorage(db.DB)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		gradeRepo := NewGradeStorage(db.DB)
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		classInfoID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+		require.NoError(t, err)
+		_, err = gradeRepo.Set(ctx, models.Grade{ClassInfoID: classInfoID, Score: 75, Letter: "C", GradePoints: 2, Scale: "simple"})
+		require.NoError(t, err)
+		//act
+		stored, err := gradeRepo.Set(ctx, models.Grade{ClassInfoID: classInfoID, Score: 91.5, Letter: "A", GradePoints: 4, Scale: "simple"})
+		require.NoError(t, err)
+		grade, err := gradeRepo.GetByClassInfoID(ctx, classInfoID)
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, stored, grade)
+		assert.Equal(t, 91.5, grade.Score)
+		assert.Equal(t, "A", grade.Letter)
+	})
+	t.Run("Enrollment not found", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		gradeRepo := NewGradeStorage(db.DB)
+		//act
+		_, setErr := gradeRepo.Set(ctx, models.Grade{ClassInfoID: 1, Score: 90, Letter: "A", GradePoints: 4, Scale: "simple"})
+		deleteErr := gradeRepo.Delete(ctx, 1)
+		//assert
+		assert.ErrorIs(t, setErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, deleteErr, pkgErrors.ErrNotFound)
+	})
+	t.Run("Frozen when the term closes", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termID := addCurrentTerm(ctx, t, db)
+		termRepo := NewAcademicTermStorage(db.DB)
+		studentRepo := NewStudentStorage(db.DB)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		gradeRepo := NewGradeStorage(db.DB)
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		classInfoID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+		require.NoError(t, err)
+		_, err = gradeRepo.Set(ctx, models.Grade{ClassInfoID: classInfoID, Score: 75, Letter: "C", GradePoints: 2, Scale: "simple"})
+		require.NoError(t, err)
+		term, err := termRepo.GetByID(ctx, termID)
+		require.NoError(t, err)
+		term.Status = models.TermStatusClosed
+		require.NoError(t, termRepo.Update(ctx, termID, term))
+		//act
+		_, setErr := gradeRepo.Set(ctx, models.Grade{ClassInfoID: classInfoID, Score: 95, Letter: "A", GradePoints: 4, Scale: "simple"})
+		deleteErr := gradeRepo.Delete(ctx, classInfoID)
+		//assert
+		assert.ErrorIs(t, setErr, pkgErrors.ErrTermClosed)
+		assert.ErrorIs(t, deleteErr, pkgErrors.ErrTermClosed)
+	})
+}
+
+func TestTranscript(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Success", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termID := addCurrentTerm(ctx, t, db)
+		studentRepo := NewStudentStorage(db.DB)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		classRepo := NewClassStorage(db.DB)
+		gradeRepo := NewGradeStorage(db.DB)
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		mathID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+		require.NoError(t, err)
+		_, err = classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Art"})
+		require.NoError(t, err)
+		classes, err := classRepo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, classes, 2)
+		require.NoError(t, classRepo.Update(ctx, classes[1].ClassID, models.Class{Credits: 3}))
+		_, err = gradeRepo.Set(ctx, models.Grade{ClassInfoID: mathID, Score: 91.5, Letter: "A", GradePoints: 4, Scale: "simple"})
+		require.NoError(t, err)
+		//act
+		entries, err := gradeRepo.GetTranscript(ctx, studentID)
+		//assert
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, termID, entries[0].Term.TermID)
+		assert.Equal(t, "Art", entries[0].ClassName)
+		assert.Equal(t, 1.0, entries[0].Credits)
+		assert.Nil(t, entries[0].Grade)
+		assert.Equal(t, "Math", entries[1].ClassName)
+		assert.Equal(t, 3.0, entries[1].Credits)
+		require.NotNil(t, entries[1].Grade)
+		assert.Equal(t, "A", entries[1].Grade.Letter)
+	})
+	t.Run("Moved enrollment loses its grade", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		studentRepo := NewStudentStorage(db.DB)
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		gradeRepo := NewGradeStorage(db.DB)
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		classInfoID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Calculus I"})
+		require.NoError(t, err)
+		_, err = gradeRepo.Set(ctx, models.Grade{ClassInfoID: classInfoID, Score: 91.5, Letter: "A", GradePoints: 4, Scale: "simple"})
+		require.NoError(t, err)
+		//act
+		require.NoError(t, classInfoRepo.UpdateByID(ctx, classInfoID, models.ClassInfo{ClassName: "Calculus II"}))
+		entries, err := gradeRepo.GetTranscript(ctx, studentID)
+		require.NoError(t, err)
+		_, gradeErr := gradeRepo.GetByClassInfoID(ctx, classInfoID)
+		//assert
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Calculus II", entries[0].ClassName)
+		assert.Nil(t, entries[0].Grade)
+		assert.ErrorIs(t, gradeErr, pkgErrors.ErrNotFound)
+	})
+	t.Run("Student not found", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		gradeRepo := NewGradeStorage(db.DB)
+		//act
+		_, err := gradeRepo.GetTranscript(ctx, 1)
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrNotFound)
+	})
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
+)
+
+const gradeColumns = `class_info_id, score, letter, grade_points, scale, graded_at`
+
+type GradeStorage struct {
+	db connection.DBops
+}
+
+func NewGradeStorage(database connection.DBops) GradeStorage {
+	return GradeStorage{db: database}
+}
+
+// gradeError maps the errors raised by grade constraints and triggers to pkgErrors.
+func gradeError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return pkgErrors.ErrNotFound
+	}
+
+	return classInfoError(err)
+}
+
+// Set grades an enrollment, replacing its previous grade. It returns ErrNotFound for an unknown
+// enrollment, ErrWaitlisted for a waitlisted one and ErrTermClosed when the term of the enrollment is closed.
+func (r *GradeStorage) Set(ctx context.Context, grade models.Grade) (models.Grade, error) {
+	ctx, span := tracer.Start(ctx, "GradeStorage.Set")
+	defer span.End()
+
+	return setGrade(ctx, r.db, grade)
+}
+
+// SetAll grades several enrollments in one transaction, so that either all of them or none are graded.
+// It fails like Set on the first enrollment that cannot be graded.
+func (r *GradeStorage) SetAll(ctx context.Context, grades []models.Grade) ([]models.Grade, error) {
+	ctx, span := tracer.Start(ctx, "GradeStorage.SetAll")
+	defer span.End()
+
+	stored := make([]models.Grade, 0, len(grades))
+
+	err := r.db.InTx(ctx, func(tx connection.DBops) error {
+		for _, grade := range grades {
+			set, err := setGrade(ctx, tx, grade)
+			if err != nil {
+				return err
+			}
+
+			stored = append(stored, set)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func setGrade(ctx context.Context, db connection.DBops, grade models.Grade) (models.Grade, error) {
+	var stored entities.Grade
+
+	err := db.Get(ctx, &stored, `
+		INSERT INTO grade(class_info_id, score, letter, grade_points, scale)
+		SELECT id, $2, $3, $4, $5 FROM class_info WHERE id = $1 AND status = 'enrolled'
+		ON CONFLICT (class_info_id) DO UPDATE
+		SET score = EXCLUDED.score, letter = EXCLUDED.letter, grade_points = EXCLUDED.grade_points,
+			scale = EXCLUDED.scale, graded_at = NOW()
+		RETURNING `+gradeColumns+`;
+	`, grade.ClassInfoID, grade.Score, grade.Letter, grade.GradePoints, grade.Scale)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.Grade{}, notGradable(ctx, db, grade.ClassInfoID)
+		}
+
+		return models.Grade{}, gradeError(err)
+	}
+
+	return stored.ToGradeDomain(), nil
+}
+
+// notGradable explains why an enrollment could not be graded: it is either unknown or waitlisted.
+func notGradable(ctx context.Context, db connection.DBops, classInfoID int64) error {
+	var exists bool
+	if err := db.Get(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM class_info WHERE id = $1);`, classInfoID); err != nil {
+		return err
+	}
+
+	if exists {
+		return pkgErrors.ErrWaitlisted
+	}
+
+	return pkgErrors.ErrNotFound
+}
+
+func (r *GradeStorage) GetByClassInfoID(ctx context.Context, classInfoID int64) (models.Grade, error) {
+	ctx, span := tracer.Start(ctx, "GradeStorage.GetByClassInfoID")
+	defer span.End()
+
+	var grade entities.Grade
+
+	err := r.db.Get(ctx, &grade, `SELECT `+gradeColumns+` FROM grade WHERE class_info_id = $1;`, classInfoID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.Grade{}, pkgErrors.ErrNotFound
+		}
+
+		return models.Grade{}, err
+	}
+
+	return grade.ToGradeDomain(), nil
+}
+
+// Delete removes the grade of an enrollment. It returns ErrTermClosed when the term of the enrollment is closed.
+func (r *GradeStorage) Delete(ctx context.Context, classInfoID int64) error {
+	ctx, span := tracer.Start(ctx, "GradeStorage.Delete")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, `DELETE FROM grade WHERE class_info_id = $1`, classInfoID)
+	if err != nil {
+		return gradeError(err)
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetTranscript returns every enrollment of a student with its term, credits and grade, ordered by term
+// start date and class name. Waitlisted enrollments are left out. It returns ErrNotFound for an unknown student.
+func (r *GradeStorage) GetTranscript(ctx context.Context, studentID int64) ([]models.TranscriptEntry, error) {
+	ctx, span := tracer.Start(ctx, "GradeStorage.GetTranscript")
+	defer span.End()
+
+	var entries []entities.TranscriptEntry
+
+	err := r.db.Select(ctx, &entries, `
+		SELECT t.term_id, t.name, t.start_date, t.end_date, t.status,
+			ci.id AS class_info_id, ci.class_name, COALESCE(c.credits, 1) AS credits,
+			g.score, g.letter, g.grade_points, g.scale, g.graded_at
+		FROM class_info ci
+		JOIN academic_term t ON t.term_id = ci.term_id
+		LEFT JOIN class c ON c.class_name = ci.class_name
+		LEFT JOIN grade g ON g.class_info_id = ci.id
+		WHERE ci.student_id = $1 AND ci.status = 'enrolled'
+		ORDER BY t.start_date, t.term_id, ci.class_name, ci.id;
+	`, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		var exists bool
+		if err := r.db.Get(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM student WHERE student_id = $1);`, studentID); err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, pkgErrors.ErrNotFound
+		}
+	}
+
+	return utils.Map(entries, func(e entities.TranscriptEntry) models.TranscriptEntry {
+		return e.ToTranscriptEntryDomain()
+	}), nil
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE role_assignment (
+    subject TEXT NOT NULL,
+    role TEXT NOT NULL,
+    student_id BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (subject, role),
+    CONSTRAINT role_assignment_role CHECK (role IN ('admin', 'teacher', 'student', 'read_only')),
+    CONSTRAINT role_assignment_student CHECK (role <> 'student' OR student_id IS NOT NULL),
+    CONSTRAINT fk_role_assignment_student FOREIGN KEY (student_id) REFERENCES student(student_id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table role_assignment;
+-- +goose StatementEnd
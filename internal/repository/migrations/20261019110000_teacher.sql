@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE teacher (
+    teacher_id BIGSERIAL PRIMARY KEY,
+    teacher_name TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE TABLE class (
+    class_id BIGSERIAL PRIMARY KEY,
+    class_name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+INSERT INTO class(class_name) SELECT DISTINCT class_name FROM class_info WHERE class_name <> '';
+
+CREATE TABLE class_teacher (
+    class_id BIGINT NOT NULL,
+    teacher_id BIGINT NOT NULL,
+    role TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (class_id, teacher_id),
+    CONSTRAINT class_teacher_role CHECK (role IN ('lead', 'assistant')),
+    CONSTRAINT fk_class_teacher_class FOREIGN KEY (class_id) REFERENCES class(class_id) ON DELETE CASCADE,
+    CONSTRAINT fk_class_teacher_teacher FOREIGN KEY (teacher_id) REFERENCES teacher(teacher_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX class_teacher_one_lead ON class_teacher(class_id) WHERE role = 'lead';
+CREATE INDEX class_teacher_teacher_id ON class_teacher(teacher_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table class_teacher;
+drop table class;
+drop table teacher;
+-- +goose StatementEnd
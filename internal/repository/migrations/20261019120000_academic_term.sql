@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE academic_term (
+    term_id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status TEXT NOT NULL DEFAULT 'planned',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CONSTRAINT academic_term_status CHECK (status IN ('planned', 'open', 'closed')),
+    CONSTRAINT academic_term_dates CHECK (start_date <= end_date),
+    CONSTRAINT academic_term_no_overlap EXCLUDE USING gist (daterange(start_date, end_date, '[]') WITH &&)
+);
+
+ALTER TABLE class_info ADD COLUMN term_id BIGINT;
+
+-- Enrollments made before terms existed are kept in an open term that ends today.
+INSERT INTO academic_term(name, start_date, end_date, status)
+SELECT 'Before terms', DATE '1970-01-01', CURRENT_DATE, 'open'
+WHERE EXISTS (SELECT 1 FROM class_info);
+
+UPDATE class_info SET term_id = (SELECT term_id FROM academic_term WHERE name = 'Before terms');
+
+ALTER TABLE class_info
+    ALTER COLUMN term_id SET NOT NULL,
+    ADD CONSTRAINT fk_class_info_term FOREIGN KEY (term_id) REFERENCES academic_term(term_id);
+
+CREATE INDEX class_info_student_term ON class_info(student_id, term_id);
+
+-- Enrollments of a closed term cannot be added, changed, moved or removed.
+CREATE FUNCTION class_info_term_not_closed() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' AND EXISTS (SELECT 1 FROM academic_term WHERE term_id = OLD.term_id AND status = 'closed') THEN
+        RAISE EXCEPTION 'academic term % is closed', OLD.term_id USING ERRCODE = 'object_not_in_prerequisite_state';
+    END IF;
+
+    IF TG_OP <> 'DELETE' AND EXISTS (SELECT 1 FROM academic_term WHERE term_id = NEW.term_id AND status = 'closed') THEN
+        RAISE EXCEPTION 'academic term % is closed', NEW.term_id USING ERRCODE = 'object_not_in_prerequisite_state';
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER class_info_term_not_closed
+    BEFORE INSERT OR UPDATE OR DELETE ON class_info
+    FOR EACH ROW EXECUTE FUNCTION class_info_term_not_closed();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER class_info_term_not_closed ON class_info;
+DROP FUNCTION class_info_term_not_closed();
+ALTER TABLE class_info DROP COLUMN term_id;
+drop table academic_term;
+-- +goose StatementEnd
@@ -0,0 +1,79 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE class
+    ADD COLUMN credits NUMERIC(4, 1) NOT NULL DEFAULT 1,
+    ADD CONSTRAINT class_credits CHECK (credits >= 0);
+
+INSERT INTO class(class_name)
+SELECT DISTINCT class_name FROM class_info WHERE class_name <> ''
+ON CONFLICT (class_name) DO NOTHING;
+
+-- Every class name in class_info has a class row, which carries its credits.
+CREATE FUNCTION class_info_ensure_class() RETURNS trigger AS $$
+BEGIN
+    IF NEW.class_name <> '' THEN
+        INSERT INTO class(class_name) VALUES (NEW.class_name) ON CONFLICT (class_name) DO NOTHING;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER class_info_ensure_class
+    AFTER INSERT OR UPDATE OF class_name ON class_info
+    FOR EACH ROW EXECUTE FUNCTION class_info_ensure_class();
+
+-- The letter and points are kept as graded, so that changing a scale does not rewrite past grades.
+CREATE TABLE grade (
+    class_info_id BIGINT PRIMARY KEY,
+    score NUMERIC(5, 2) NOT NULL,
+    letter TEXT NOT NULL,
+    grade_points NUMERIC(4, 2) NOT NULL,
+    scale TEXT NOT NULL,
+    graded_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CONSTRAINT grade_score CHECK (score BETWEEN 0 AND 100),
+    CONSTRAINT grade_points CHECK (grade_points >= 0),
+    CONSTRAINT fk_grade_class_info FOREIGN KEY (class_info_id) REFERENCES class_info(id) ON DELETE CASCADE
+);
+
+-- Grades of a closed term are frozen with its enrollments.
+CREATE FUNCTION grade_term_not_closed() RETURNS trigger AS $$
+DECLARE
+    enrollment_id BIGINT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        enrollment_id := OLD.class_info_id;
+    ELSE
+        enrollment_id := NEW.class_info_id;
+    END IF;
+
+    IF EXISTS (
+        SELECT 1 FROM class_info ci JOIN academic_term t ON t.term_id = ci.term_id
+        WHERE ci.id = enrollment_id AND t.status = 'closed'
+    ) THEN
+        RAISE EXCEPTION 'academic term of class_info % is closed', enrollment_id
+            USING ERRCODE = 'object_not_in_prerequisite_state';
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER grade_term_not_closed
+    BEFORE INSERT OR UPDATE OR DELETE ON grade
+    FOR EACH ROW EXECUTE FUNCTION grade_term_not_closed();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER grade_term_not_closed ON grade;
+DROP FUNCTION grade_term_not_closed();
+drop table grade;
+DROP TRIGGER class_info_ensure_class ON class_info;
+DROP FUNCTION class_info_ensure_class();
+ALTER TABLE class DROP COLUMN credits;
+-- +goose StatementEnd
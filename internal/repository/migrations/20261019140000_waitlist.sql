@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE class
+    ADD COLUMN capacity INT,
+    ADD CONSTRAINT class_capacity CHECK (capacity >= 0);
+
+ALTER TABLE class_info
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'enrolled',
+    ADD COLUMN waitlisted_at TIMESTAMP WITH TIME ZONE,
+    ADD CONSTRAINT class_info_status CHECK (status IN ('enrolled', 'waitlisted')),
+    ADD CONSTRAINT class_info_waitlisted_at CHECK ((status = 'waitlisted') = (waitlisted_at IS NOT NULL));
+
+CREATE INDEX class_info_class_term_status ON class_info(class_name, term_id, status);
+
+-- Enrollments are deleted on drop, so events keep their own copy of the enrollment instead of a foreign key.
+CREATE TABLE enrollment_event (
+    event_id BIGSERIAL PRIMARY KEY,
+    class_info_id BIGINT NOT NULL,
+    student_id BIGINT NOT NULL,
+    class_name TEXT NOT NULL,
+    term_id BIGINT NOT NULL,
+    event TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CONSTRAINT enrollment_event_event CHECK (event IN ('enrolled', 'waitlisted', 'promoted', 'dropped'))
+);
+
+CREATE INDEX enrollment_event_class_name ON enrollment_event(class_name, event_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table enrollment_event;
+ALTER TABLE class_info DROP COLUMN waitlisted_at, DROP COLUMN status;
+ALTER TABLE class DROP COLUMN capacity;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The prerequisites of a class are groups that must all be satisfied; a group is satisfied by a grade
+-- in any one of its classes, at least min_score when it is set.
+CREATE TABLE class_prerequisite (
+    class_id BIGINT NOT NULL,
+    group_no INT NOT NULL,
+    required_class_id BIGINT NOT NULL,
+    min_score NUMERIC(5, 2),
+    PRIMARY KEY (class_id, group_no, required_class_id),
+    CONSTRAINT fk_class_prerequisite_class FOREIGN KEY (class_id) REFERENCES class(class_id) ON DELETE CASCADE,
+    CONSTRAINT fk_class_prerequisite_required FOREIGN KEY (required_class_id) REFERENCES class(class_id) ON DELETE CASCADE,
+    CONSTRAINT class_prerequisite_not_self CHECK (class_id <> required_class_id),
+    CONSTRAINT class_prerequisite_min_score CHECK (min_score BETWEEN 0 AND 100)
+);
+
+CREATE INDEX class_prerequisite_required ON class_prerequisite(required_class_id);
+
+CREATE TABLE audit_event (
+    audit_id BIGSERIAL PRIMARY KEY,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    student_id BIGINT,
+    class_name TEXT,
+    detail TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table audit_event;
+drop table class_prerequisite;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE class_session (
+    session_id BIGSERIAL PRIMARY KEY,
+    class_id BIGINT NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CONSTRAINT fk_class_session_class FOREIGN KEY (class_id) REFERENCES class(class_id) ON DELETE CASCADE,
+    CONSTRAINT class_session_times CHECK (starts_at < ends_at),
+    CONSTRAINT class_session_unique UNIQUE (class_id, starts_at)
+);
+
+-- Attendance is recorded for the students enrolled in the class during the term of the session.
+CREATE TABLE attendance (
+    session_id BIGINT NOT NULL,
+    student_id BIGINT NOT NULL,
+    status TEXT NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    recorded_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (session_id, student_id),
+    CONSTRAINT fk_attendance_session FOREIGN KEY (session_id) REFERENCES class_session(session_id) ON DELETE CASCADE,
+    CONSTRAINT fk_attendance_student FOREIGN KEY (student_id) REFERENCES student(student_id) ON DELETE CASCADE,
+    CONSTRAINT attendance_status CHECK (status IN ('present', 'absent', 'late', 'excused'))
+);
+
+CREATE INDEX attendance_student ON attendance(student_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table attendance;
+drop table class_session;
+-- +goose StatementEnd
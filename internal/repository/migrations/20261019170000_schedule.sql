@@ -0,0 +1,139 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Exclusion constraints compare ids with = in GiST indexes.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE room (
+    room_id BIGSERIAL PRIMARY KEY,
+    room_name TEXT NOT NULL UNIQUE,
+    capacity INT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CONSTRAINT room_capacity CHECK (capacity >= 0)
+);
+
+-- A slot meets every week of its term. week_range places it in the week of Monday 2001-01-01 UTC,
+-- so that two weekly slots overlap exactly when their ranges do.
+CREATE TABLE schedule_slot (
+    slot_id BIGSERIAL PRIMARY KEY,
+    class_id BIGINT NOT NULL,
+    term_id BIGINT NOT NULL,
+    room_id BIGINT NOT NULL,
+    day_of_week SMALLINT NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    week_range TSTZRANGE GENERATED ALWAYS AS (tstzrange(
+        to_timestamp((978307200 + (day_of_week - 1) * 86400 + EXTRACT(EPOCH FROM start_time))::DOUBLE PRECISION),
+        to_timestamp((978307200 + (day_of_week - 1) * 86400 + EXTRACT(EPOCH FROM end_time))::DOUBLE PRECISION)
+    )) STORED,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CONSTRAINT fk_schedule_slot_class FOREIGN KEY (class_id) REFERENCES class(class_id) ON DELETE CASCADE,
+    CONSTRAINT fk_schedule_slot_term FOREIGN KEY (term_id) REFERENCES academic_term(term_id),
+    CONSTRAINT fk_schedule_slot_room FOREIGN KEY (room_id) REFERENCES room(room_id),
+    CONSTRAINT schedule_slot_day CHECK (day_of_week BETWEEN 1 AND 7),
+    CONSTRAINT schedule_slot_times CHECK (start_time < end_time),
+    CONSTRAINT schedule_slot_room_overlap EXCLUDE USING gist (term_id WITH =, room_id WITH =, week_range WITH &&)
+);
+
+CREATE INDEX schedule_slot_class_term ON schedule_slot(class_id, term_id);
+
+-- Bookings copy the slots of a class to its teachers and enrolled students, so that exclusion
+-- constraints can keep a teacher or a student from being in two places at once. Triggers keep
+-- them in sync and foreign keys remove them.
+CREATE TABLE teacher_booking (
+    slot_id BIGINT NOT NULL,
+    class_id BIGINT NOT NULL,
+    teacher_id BIGINT NOT NULL,
+    term_id BIGINT NOT NULL,
+    week_range TSTZRANGE NOT NULL,
+    PRIMARY KEY (slot_id, teacher_id),
+    CONSTRAINT fk_teacher_booking_slot FOREIGN KEY (slot_id) REFERENCES schedule_slot(slot_id) ON DELETE CASCADE,
+    CONSTRAINT fk_teacher_booking_assignment FOREIGN KEY (class_id, teacher_id)
+        REFERENCES class_teacher(class_id, teacher_id) ON DELETE CASCADE,
+    CONSTRAINT teacher_booking_overlap EXCLUDE USING gist (teacher_id WITH =, term_id WITH =, week_range WITH &&)
+);
+
+CREATE TABLE student_booking (
+    slot_id BIGINT NOT NULL,
+    class_info_id BIGINT NOT NULL,
+    student_id BIGINT NOT NULL,
+    term_id BIGINT NOT NULL,
+    week_range TSTZRANGE NOT NULL,
+    PRIMARY KEY (slot_id, class_info_id),
+    CONSTRAINT fk_student_booking_slot FOREIGN KEY (slot_id) REFERENCES schedule_slot(slot_id) ON DELETE CASCADE,
+    CONSTRAINT fk_student_booking_class_info FOREIGN KEY (class_info_id) REFERENCES class_info(id) ON DELETE CASCADE,
+    CONSTRAINT student_booking_overlap EXCLUDE USING gist (student_id WITH =, term_id WITH =, week_range WITH &&)
+);
+
+CREATE INDEX student_booking_class_info ON student_booking(class_info_id);
+
+CREATE FUNCTION book_schedule_slot() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM teacher_booking WHERE slot_id = NEW.slot_id;
+    DELETE FROM student_booking WHERE slot_id = NEW.slot_id;
+
+    INSERT INTO teacher_booking(slot_id, class_id, teacher_id, term_id, week_range)
+    SELECT NEW.slot_id, ct.class_id, ct.teacher_id, NEW.term_id, NEW.week_range
+    FROM class_teacher ct
+    WHERE ct.class_id = NEW.class_id;
+
+    INSERT INTO student_booking(slot_id, class_info_id, student_id, term_id, week_range)
+    SELECT NEW.slot_id, ci.id, ci.student_id, ci.term_id, NEW.week_range
+    FROM class_info ci
+    JOIN class c ON c.class_name = ci.class_name
+    WHERE c.class_id = NEW.class_id AND ci.term_id = NEW.term_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER schedule_slot_book
+    AFTER INSERT OR UPDATE ON schedule_slot
+    FOR EACH ROW EXECUTE FUNCTION book_schedule_slot();
+
+CREATE FUNCTION book_class_teacher() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO teacher_booking(slot_id, class_id, teacher_id, term_id, week_range)
+    SELECT s.slot_id, NEW.class_id, NEW.teacher_id, s.term_id, s.week_range
+    FROM schedule_slot s
+    WHERE s.class_id = NEW.class_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER class_teacher_book
+    AFTER INSERT ON class_teacher
+    FOR EACH ROW EXECUTE FUNCTION book_class_teacher();
+
+-- Waitlisted students are booked too, so that they never wait for a seat they could not take.
+CREATE FUNCTION book_class_info() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM student_booking WHERE class_info_id = NEW.id;
+
+    INSERT INTO student_booking(slot_id, class_info_id, student_id, term_id, week_range)
+    SELECT s.slot_id, NEW.id, NEW.student_id, NEW.term_id, s.week_range
+    FROM schedule_slot s
+    JOIN class c ON c.class_id = s.class_id
+    WHERE c.class_name = NEW.class_name AND s.term_id = NEW.term_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER class_info_book
+    AFTER INSERT OR UPDATE OF student_id, class_name, term_id ON class_info
+    FOR EACH ROW EXECUTE FUNCTION book_class_info();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER class_info_book ON class_info;
+DROP FUNCTION book_class_info();
+DROP TRIGGER class_teacher_book ON class_teacher;
+DROP FUNCTION book_class_teacher();
+drop table student_booking;
+drop table teacher_booking;
+drop table schedule_slot;
+DROP FUNCTION book_schedule_slot();
+drop table room;
+-- +goose StatementEnd
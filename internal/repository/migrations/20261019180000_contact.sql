@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Contacts are parents and guardians. A contact can be shared by siblings, and its relationship is kept per student.
+CREATE TABLE contact (
+    contact_id BIGSERIAL PRIMARY KEY,
+    contact_name TEXT NOT NULL,
+    email TEXT,
+    phone TEXT,
+    preferred_channel TEXT NOT NULL,
+    consent_email BOOLEAN NOT NULL DEFAULT FALSE,
+    consent_sms BOOLEAN NOT NULL DEFAULT FALSE,
+    consent_phone BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CONSTRAINT contact_channel CHECK (preferred_channel IN ('email', 'sms', 'phone')),
+    CONSTRAINT contact_reachable CHECK (
+        (preferred_channel = 'email' AND email IS NOT NULL) OR (preferred_channel IN ('sms', 'phone') AND phone IS NOT NULL)
+    )
+);
+
+CREATE TABLE student_contact (
+    student_id BIGINT NOT NULL,
+    contact_id BIGINT NOT NULL,
+    relationship TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (student_id, contact_id),
+    CONSTRAINT fk_student_contact_student FOREIGN KEY (student_id) REFERENCES student(student_id) ON DELETE CASCADE,
+    CONSTRAINT fk_student_contact_contact FOREIGN KEY (contact_id) REFERENCES contact(contact_id) ON DELETE CASCADE
+);
+
+CREATE INDEX student_contact_contact ON student_contact(contact_id);
+
+-- Personal data is not kept once a contact is no longer linked to any student,
+-- whether it was unlinked or its last student was deleted.
+CREATE FUNCTION delete_unlinked_contact() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM contact c
+    WHERE c.contact_id = OLD.contact_id
+      AND NOT EXISTS (SELECT 1 FROM student_contact sc WHERE sc.contact_id = OLD.contact_id);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER student_contact_unlinked
+    AFTER DELETE ON student_contact
+    FOR EACH ROW EXECUTE FUNCTION delete_unlinked_contact();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table student_contact;
+DROP FUNCTION delete_unlinked_contact();
+drop table contact;
+-- +goose StatementEnd
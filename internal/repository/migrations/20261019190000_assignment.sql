@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE assignment (
+    assignment_id BIGSERIAL PRIMARY KEY,
+    class_id BIGINT NOT NULL,
+    term_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    max_points NUMERIC(7, 2) NOT NULL,
+    weight NUMERIC(5, 2) NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CONSTRAINT fk_assignment_class FOREIGN KEY (class_id) REFERENCES class(class_id) ON DELETE CASCADE,
+    CONSTRAINT fk_assignment_term FOREIGN KEY (term_id) REFERENCES academic_term(term_id),
+    CONSTRAINT assignment_max_points CHECK (max_points > 0),
+    CONSTRAINT assignment_weight CHECK (weight >= 0)
+);
+
+CREATE INDEX assignment_class_term ON assignment(class_id, term_id);
+
+-- A submission belongs to the enrollment of the student in the class and term of the assignment.
+-- Whether it was late is derived from submitted_at and the due date of the assignment when read,
+-- so that moving the due date is reflected.
+CREATE TABLE submission (
+    assignment_id BIGINT NOT NULL,
+    class_info_id BIGINT NOT NULL,
+    status TEXT NOT NULL,
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    score NUMERIC(7, 2),
+    feedback TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (assignment_id, class_info_id),
+    CONSTRAINT fk_submission_assignment FOREIGN KEY (assignment_id) REFERENCES assignment(assignment_id) ON DELETE CASCADE,
+    CONSTRAINT fk_submission_class_info FOREIGN KEY (class_info_id) REFERENCES class_info(id) ON DELETE CASCADE,
+    CONSTRAINT submission_status CHECK (status IN ('submitted', 'missing', 'excused')),
+    CONSTRAINT submission_submitted_at CHECK ((status = 'submitted') = (submitted_at IS NOT NULL)),
+    CONSTRAINT submission_score CHECK (score >= 0)
+);
+
+CREATE INDEX submission_class_info ON submission(class_info_id);
+
+-- Submissions of a closed term are frozen with its enrollments and grades.
+CREATE TRIGGER submission_term_not_closed
+    BEFORE INSERT OR UPDATE OR DELETE ON submission
+    FOR EACH ROW EXECUTE FUNCTION grade_term_not_closed();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER submission_term_not_closed ON submission;
+drop table submission;
+drop table assignment;
+-- +goose StatementEnd
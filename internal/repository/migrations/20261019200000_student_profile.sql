@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Students created before profiles were already enrolled, so they start as active.
+ALTER TABLE student
+    ADD COLUMN first_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN last_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN preferred_name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN date_of_birth DATE,
+    ADD COLUMN email TEXT,
+    ADD COLUMN student_number TEXT,
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'active',
+    ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    ADD CONSTRAINT student_number_unique UNIQUE (student_number),
+    ADD CONSTRAINT student_status CHECK (status IN ('applicant', 'active', 'graduated', 'withdrawn'));
+
+UPDATE student SET updated_at = created_at;
+
+-- Every status a student has had, starting with the one it was created with, which has no from_status.
+CREATE TABLE student_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    student_id BIGINT NOT NULL,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    CONSTRAINT fk_student_status_history_student FOREIGN KEY (student_id) REFERENCES student(student_id) ON DELETE CASCADE
+);
+
+CREATE INDEX student_status_history_student ON student_status_history(student_id, changed_at);
+
+INSERT INTO student_status_history(student_id, to_status, changed_at)
+SELECT student_id, status, created_at FROM student;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table student_status_history;
+ALTER TABLE student
+    DROP CONSTRAINT student_status,
+    DROP CONSTRAINT student_number_unique,
+    DROP COLUMN updated_at,
+    DROP COLUMN status,
+    DROP COLUMN student_number,
+    DROP COLUMN email,
+    DROP COLUMN date_of_birth,
+    DROP COLUMN preferred_name,
+    DROP COLUMN last_name,
+    DROP COLUMN first_name;
+-- +goose StatementEnd
@@ -9,9 +9,10 @@
 package mock_repository
 
 import (
-	serviceEntities "CRUD_Go_Backend/internal/handlers/models"
+	models "CRUD_Go_Backend/internal/handlers/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,7 +41,7 @@ func (m *MockStudentPgRepo) EXPECT() *MockStudentPgRepoMockRecorder {
 }
 
 // Add mocks base method.
-func (m *MockStudentPgRepo) Add(ctx context.Context, studentReq serviceEntities.StudentRequest) (int64, error) {
+func (m *MockStudentPgRepo) Add(ctx context.Context, studentReq models.StudentRequest) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Add", ctx, studentReq)
 	ret0, _ := ret[0].(int64)
@@ -54,6 +55,21 @@ func (mr *MockStudentPgRepoMockRecorder) Add(ctx, studentReq any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockStudentPgRepo)(nil).Add), ctx, studentReq)
 }
 
+// Count mocks base method.
+func (m *MockStudentPgRepo) Count(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockStudentPgRepoMockRecorder) Count(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockStudentPgRepo)(nil).Count), ctx)
+}
+
 // Delete mocks base method.
 func (m *MockStudentPgRepo) Delete(ctx context.Context, studentID int64) error {
 	m.ctrl.T.Helper()
@@ -69,10 +85,10 @@ func (mr *MockStudentPgRepoMockRecorder) Delete(ctx, studentID any) *gomock.Call
 }
 
 // GetByID mocks base method.
-func (m *MockStudentPgRepo) GetByID(ctx context.Context, studentID int64) (serviceEntities.StudentRequest, error) {
+func (m *MockStudentPgRepo) GetByID(ctx context.Context, studentID int64) (models.StudentRequest, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByID", ctx, studentID)
-	ret0, _ := ret[0].(serviceEntities.StudentRequest)
+	ret0, _ := ret[0].(models.StudentRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -83,18 +99,92 @@ func (mr *MockStudentPgRepoMockRecorder) GetByID(ctx, studentID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockStudentPgRepo)(nil).GetByID), ctx, studentID)
 }
 
+// GetByIDWithFields mocks base method.
+func (m *MockStudentPgRepo) GetByIDWithFields(ctx context.Context, studentID int64, fields []string) (models.StudentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDWithFields", ctx, studentID, fields)
+	ret0, _ := ret[0].(models.StudentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDWithFields indicates an expected call of GetByIDWithFields.
+func (mr *MockStudentPgRepoMockRecorder) GetByIDWithFields(ctx, studentID, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDWithFields", reflect.TypeOf((*MockStudentPgRepo)(nil).GetByIDWithFields), ctx, studentID, fields)
+}
+
+// List mocks base method.
+func (m *MockStudentPgRepo) List(ctx context.Context, limit, offset int64, fields []string) ([]models.StudentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit, offset, fields)
+	ret0, _ := ret[0].([]models.StudentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockStudentPgRepoMockRecorder) List(ctx, limit, offset, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockStudentPgRepo)(nil).List), ctx, limit, offset, fields)
+}
+
+// SetStatus mocks base method.
+func (m *MockStudentPgRepo) SetStatus(ctx context.Context, studentID int64, status, reason string) (models.StudentStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, studentID, status, reason)
+	ret0, _ := ret[0].(models.StudentStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockStudentPgRepoMockRecorder) SetStatus(ctx, studentID, status, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockStudentPgRepo)(nil).SetStatus), ctx, studentID, status, reason)
+}
+
+// StatusHistory mocks base method.
+func (m *MockStudentPgRepo) StatusHistory(ctx context.Context, studentID int64) ([]models.StudentStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatusHistory", ctx, studentID)
+	ret0, _ := ret[0].([]models.StudentStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatusHistory indicates an expected call of StatusHistory.
+func (mr *MockStudentPgRepoMockRecorder) StatusHistory(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusHistory", reflect.TypeOf((*MockStudentPgRepo)(nil).StatusHistory), ctx, studentID)
+}
+
 // Update mocks base method.
-func (m *MockStudentPgRepo) Update(ctx context.Context, studentId int64, studentReq serviceEntities.StudentRequest) error {
+func (m *MockStudentPgRepo) Update(ctx context.Context, studentID int64, studentReq models.StudentRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, studentId, studentReq)
+	ret := m.ctrl.Call(m, "Update", ctx, studentID, studentReq)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockStudentPgRepoMockRecorder) Update(ctx, studentId, studentReq any) *gomock.Call {
+func (mr *MockStudentPgRepoMockRecorder) Update(ctx, studentID, studentReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStudentPgRepo)(nil).Update), ctx, studentID, studentReq)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockStudentPgRepo) UpdateProfile(ctx context.Context, studentID int64, studentReq models.StudentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, studentID, studentReq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockStudentPgRepoMockRecorder) UpdateProfile(ctx, studentID, studentReq any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStudentPgRepo)(nil).Update), ctx, studentId, studentReq)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockStudentPgRepo)(nil).UpdateProfile), ctx, studentID, studentReq)
 }
 
 // MockClassInfoPgRepo is a mock of ClassInfoPgRepo interface.
@@ -121,7 +211,7 @@ func (m *MockClassInfoPgRepo) EXPECT() *MockClassInfoPgRepoMockRecorder {
 }
 
 // Add mocks base method.
-func (m *MockClassInfoPgRepo) Add(ctx context.Context, classInfoReq serviceEntities.ClassInfo) (int64, error) {
+func (m *MockClassInfoPgRepo) Add(ctx context.Context, classInfoReq models.ClassInfo) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Add", ctx, classInfoReq)
 	ret0, _ := ret[0].(int64)
@@ -135,45 +225,1478 @@ func (mr *MockClassInfoPgRepoMockRecorder) Add(ctx, classInfoReq any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockClassInfoPgRepo)(nil).Add), ctx, classInfoReq)
 }
 
+// DeleteByID mocks base method.
+func (m *MockClassInfoPgRepo) DeleteByID(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockClassInfoPgRepoMockRecorder) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockClassInfoPgRepo)(nil).DeleteByID), ctx, id)
+}
+
 // DeleteClassByStudentID mocks base method.
-func (m *MockClassInfoPgRepo) DeleteClassByStudentID(ctx context.Context, studentID int64) error {
+func (m *MockClassInfoPgRepo) DeleteClassByStudentID(ctx context.Context, studentID int64, termID *int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteClassByStudentID", ctx, studentID)
+	ret := m.ctrl.Call(m, "DeleteClassByStudentID", ctx, studentID, termID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteClassByStudentID indicates an expected call of DeleteClassByStudentID.
-func (mr *MockClassInfoPgRepoMockRecorder) DeleteClassByStudentID(ctx, studentID any) *gomock.Call {
+func (mr *MockClassInfoPgRepoMockRecorder) DeleteClassByStudentID(ctx, studentID, termID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClassByStudentID", reflect.TypeOf((*MockClassInfoPgRepo)(nil).DeleteClassByStudentID), ctx, studentID, termID)
+}
+
+// GetByID mocks base method.
+func (m *MockClassInfoPgRepo) GetByID(ctx context.Context, id int64) (models.ClassInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.ClassInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockClassInfoPgRepoMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClassByStudentID", reflect.TypeOf((*MockClassInfoPgRepo)(nil).DeleteClassByStudentID), ctx, studentID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockClassInfoPgRepo)(nil).GetByID), ctx, id)
 }
 
 // GetByStudentID mocks base method.
-func (m *MockClassInfoPgRepo) GetByStudentID(ctx context.Context, studentId int64) ([]serviceEntities.ClassInfo, error) {
+func (m *MockClassInfoPgRepo) GetByStudentID(ctx context.Context, studentID int64) ([]models.ClassInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByStudentID", ctx, studentId)
-	ret0, _ := ret[0].([]serviceEntities.ClassInfo)
+	ret := m.ctrl.Call(m, "GetByStudentID", ctx, studentID)
+	ret0, _ := ret[0].([]models.ClassInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByStudentID indicates an expected call of GetByStudentID.
-func (mr *MockClassInfoPgRepoMockRecorder) GetByStudentID(ctx, studentId any) *gomock.Call {
+func (mr *MockClassInfoPgRepoMockRecorder) GetByStudentID(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudentID", reflect.TypeOf((*MockClassInfoPgRepo)(nil).GetByStudentID), ctx, studentID)
+}
+
+// GetByStudentIDInTerm mocks base method.
+func (m *MockClassInfoPgRepo) GetByStudentIDInTerm(ctx context.Context, studentID int64, termID *int64) ([]models.ClassInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudentIDInTerm", ctx, studentID, termID)
+	ret0, _ := ret[0].([]models.ClassInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudentIDInTerm indicates an expected call of GetByStudentIDInTerm.
+func (mr *MockClassInfoPgRepoMockRecorder) GetByStudentIDInTerm(ctx, studentID, termID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudentIDInTerm", reflect.TypeOf((*MockClassInfoPgRepo)(nil).GetByStudentIDInTerm), ctx, studentID, termID)
+}
+
+// GetByStudentIDs mocks base method.
+func (m *MockClassInfoPgRepo) GetByStudentIDs(ctx context.Context, studentIDs []int64) (map[int64][]models.ClassInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudentIDs", ctx, studentIDs)
+	ret0, _ := ret[0].(map[int64][]models.ClassInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudentIDs indicates an expected call of GetByStudentIDs.
+func (mr *MockClassInfoPgRepoMockRecorder) GetByStudentIDs(ctx, studentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudentIDs", reflect.TypeOf((*MockClassInfoPgRepo)(nil).GetByStudentIDs), ctx, studentIDs)
+}
+
+// LegacyTermID mocks base method.
+func (m *MockClassInfoPgRepo) LegacyTermID(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LegacyTermID", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LegacyTermID indicates an expected call of LegacyTermID.
+func (mr *MockClassInfoPgRepoMockRecorder) LegacyTermID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LegacyTermID", reflect.TypeOf((*MockClassInfoPgRepo)(nil).LegacyTermID), ctx)
+}
+
+// Update mocks base method.
+func (m *MockClassInfoPgRepo) Update(ctx context.Context, studentID int64, classInfoReq models.ClassInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, studentID, classInfoReq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockClassInfoPgRepoMockRecorder) Update(ctx, studentID, classInfoReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockClassInfoPgRepo)(nil).Update), ctx, studentID, classInfoReq)
+}
+
+// UpdateByID mocks base method.
+func (m *MockClassInfoPgRepo) UpdateByID(ctx context.Context, id int64, classInfoReq models.ClassInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateByID", ctx, id, classInfoReq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateByID indicates an expected call of UpdateByID.
+func (mr *MockClassInfoPgRepoMockRecorder) UpdateByID(ctx, id, classInfoReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateByID", reflect.TypeOf((*MockClassInfoPgRepo)(nil).UpdateByID), ctx, id, classInfoReq)
+}
+
+// MockTeacherPgRepo is a mock of TeacherPgRepo interface.
+type MockTeacherPgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeacherPgRepoMockRecorder
+}
+
+// MockTeacherPgRepoMockRecorder is the mock recorder for MockTeacherPgRepo.
+type MockTeacherPgRepoMockRecorder struct {
+	mock *MockTeacherPgRepo
+}
+
+// NewMockTeacherPgRepo creates a new mock instance.
+func NewMockTeacherPgRepo(ctrl *gomock.Controller) *MockTeacherPgRepo {
+	mock := &MockTeacherPgRepo{ctrl: ctrl}
+	mock.recorder = &MockTeacherPgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeacherPgRepo) EXPECT() *MockTeacherPgRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTeacherPgRepo) Add(ctx context.Context, teacherReq models.TeacherRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, teacherReq)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTeacherPgRepoMockRecorder) Add(ctx, teacherReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTeacherPgRepo)(nil).Add), ctx, teacherReq)
+}
+
+// AssignClass mocks base method.
+func (m *MockTeacherPgRepo) AssignClass(ctx context.Context, assignment models.TeacherAssignment) (models.TeacherAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignClass", ctx, assignment)
+	ret0, _ := ret[0].(models.TeacherAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignClass indicates an expected call of AssignClass.
+func (mr *MockTeacherPgRepoMockRecorder) AssignClass(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignClass", reflect.TypeOf((*MockTeacherPgRepo)(nil).AssignClass), ctx, assignment)
+}
+
+// Delete mocks base method.
+func (m *MockTeacherPgRepo) Delete(ctx context.Context, teacherID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, teacherID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTeacherPgRepoMockRecorder) Delete(ctx, teacherID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTeacherPgRepo)(nil).Delete), ctx, teacherID)
+}
+
+// GetByID mocks base method.
+func (m *MockTeacherPgRepo) GetByID(ctx context.Context, teacherID int64) (models.TeacherRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, teacherID)
+	ret0, _ := ret[0].(models.TeacherRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTeacherPgRepoMockRecorder) GetByID(ctx, teacherID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTeacherPgRepo)(nil).GetByID), ctx, teacherID)
+}
+
+// GetClassesByTeacherID mocks base method.
+func (m *MockTeacherPgRepo) GetClassesByTeacherID(ctx context.Context, teacherID int64) ([]models.TeacherAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassesByTeacherID", ctx, teacherID)
+	ret0, _ := ret[0].([]models.TeacherAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassesByTeacherID indicates an expected call of GetClassesByTeacherID.
+func (mr *MockTeacherPgRepoMockRecorder) GetClassesByTeacherID(ctx, teacherID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassesByTeacherID", reflect.TypeOf((*MockTeacherPgRepo)(nil).GetClassesByTeacherID), ctx, teacherID)
+}
+
+// GetTeachersByClassID mocks base method.
+func (m *MockTeacherPgRepo) GetTeachersByClassID(ctx context.Context, classID int64) ([]models.TeacherAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeachersByClassID", ctx, classID)
+	ret0, _ := ret[0].([]models.TeacherAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeachersByClassID indicates an expected call of GetTeachersByClassID.
+func (mr *MockTeacherPgRepoMockRecorder) GetTeachersByClassID(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeachersByClassID", reflect.TypeOf((*MockTeacherPgRepo)(nil).GetTeachersByClassID), ctx, classID)
+}
+
+// UnassignClass mocks base method.
+func (m *MockTeacherPgRepo) UnassignClass(ctx context.Context, teacherID, classID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignClass", ctx, teacherID, classID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignClass indicates an expected call of UnassignClass.
+func (mr *MockTeacherPgRepoMockRecorder) UnassignClass(ctx, teacherID, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignClass", reflect.TypeOf((*MockTeacherPgRepo)(nil).UnassignClass), ctx, teacherID, classID)
+}
+
+// Update mocks base method.
+func (m *MockTeacherPgRepo) Update(ctx context.Context, teacherID int64, teacherReq models.TeacherRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, teacherID, teacherReq)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTeacherPgRepoMockRecorder) Update(ctx, teacherID, teacherReq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTeacherPgRepo)(nil).Update), ctx, teacherID, teacherReq)
+}
+
+// MockAcademicTermPgRepo is a mock of AcademicTermPgRepo interface.
+type MockAcademicTermPgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAcademicTermPgRepoMockRecorder
+}
+
+// MockAcademicTermPgRepoMockRecorder is the mock recorder for MockAcademicTermPgRepo.
+type MockAcademicTermPgRepoMockRecorder struct {
+	mock *MockAcademicTermPgRepo
+}
+
+// NewMockAcademicTermPgRepo creates a new mock instance.
+func NewMockAcademicTermPgRepo(ctrl *gomock.Controller) *MockAcademicTermPgRepo {
+	mock := &MockAcademicTermPgRepo{ctrl: ctrl}
+	mock.recorder = &MockAcademicTermPgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAcademicTermPgRepo) EXPECT() *MockAcademicTermPgRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockAcademicTermPgRepo) Add(ctx context.Context, term models.AcademicTerm) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, term)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAcademicTermPgRepoMockRecorder) Add(ctx, term any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAcademicTermPgRepo)(nil).Add), ctx, term)
+}
+
+// GetByID mocks base method.
+func (m *MockAcademicTermPgRepo) GetByID(ctx context.Context, termID int64) (models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, termID)
+	ret0, _ := ret[0].(models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAcademicTermPgRepoMockRecorder) GetByID(ctx, termID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAcademicTermPgRepo)(nil).GetByID), ctx, termID)
+}
+
+// GetCurrent mocks base method.
+func (m *MockAcademicTermPgRepo) GetCurrent(ctx context.Context) (models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrent", ctx)
+	ret0, _ := ret[0].(models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrent indicates an expected call of GetCurrent.
+func (mr *MockAcademicTermPgRepoMockRecorder) GetCurrent(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrent", reflect.TypeOf((*MockAcademicTermPgRepo)(nil).GetCurrent), ctx)
+}
+
+// List mocks base method.
+func (m *MockAcademicTermPgRepo) List(ctx context.Context) ([]models.AcademicTerm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]models.AcademicTerm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAcademicTermPgRepoMockRecorder) List(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudentID", reflect.TypeOf((*MockClassInfoPgRepo)(nil).GetByStudentID), ctx, studentId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAcademicTermPgRepo)(nil).List), ctx)
 }
 
 // Update mocks base method.
-func (m *MockClassInfoPgRepo) Update(ctx context.Context, studentId int64, classInfoReq serviceEntities.ClassInfo) error {
+func (m *MockAcademicTermPgRepo) Update(ctx context.Context, termID int64, term models.AcademicTerm) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, studentId, classInfoReq)
+	ret := m.ctrl.Call(m, "Update", ctx, termID, term)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockClassInfoPgRepoMockRecorder) Update(ctx, studentId, classInfoReq any) *gomock.Call {
+func (mr *MockAcademicTermPgRepoMockRecorder) Update(ctx, termID, term any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAcademicTermPgRepo)(nil).Update), ctx, termID, term)
+}
+
+// MockGradePgRepo is a mock of GradePgRepo interface.
+type MockGradePgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockGradePgRepoMockRecorder
+}
+
+// MockGradePgRepoMockRecorder is the mock recorder for MockGradePgRepo.
+type MockGradePgRepoMockRecorder struct {
+	mock *MockGradePgRepo
+}
+
+// NewMockGradePgRepo creates a new mock instance.
+func NewMockGradePgRepo(ctrl *gomock.Controller) *MockGradePgRepo {
+	mock := &MockGradePgRepo{ctrl: ctrl}
+	mock.recorder = &MockGradePgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGradePgRepo) EXPECT() *MockGradePgRepoMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockGradePgRepo) Delete(ctx context.Context, classInfoID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, classInfoID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGradePgRepoMockRecorder) Delete(ctx, classInfoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGradePgRepo)(nil).Delete), ctx, classInfoID)
+}
+
+// GetByClassInfoID mocks base method.
+func (m *MockGradePgRepo) GetByClassInfoID(ctx context.Context, classInfoID int64) (models.Grade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByClassInfoID", ctx, classInfoID)
+	ret0, _ := ret[0].(models.Grade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByClassInfoID indicates an expected call of GetByClassInfoID.
+func (mr *MockGradePgRepoMockRecorder) GetByClassInfoID(ctx, classInfoID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByClassInfoID", reflect.TypeOf((*MockGradePgRepo)(nil).GetByClassInfoID), ctx, classInfoID)
+}
+
+// GetTranscript mocks base method.
+func (m *MockGradePgRepo) GetTranscript(ctx context.Context, studentID int64) ([]models.TranscriptEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranscript", ctx, studentID)
+	ret0, _ := ret[0].([]models.TranscriptEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranscript indicates an expected call of GetTranscript.
+func (mr *MockGradePgRepoMockRecorder) GetTranscript(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranscript", reflect.TypeOf((*MockGradePgRepo)(nil).GetTranscript), ctx, studentID)
+}
+
+// Set mocks base method.
+func (m *MockGradePgRepo) Set(ctx context.Context, grade models.Grade) (models.Grade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, grade)
+	ret0, _ := ret[0].(models.Grade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockGradePgRepoMockRecorder) Set(ctx, grade any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockGradePgRepo)(nil).Set), ctx, grade)
+}
+
+// SetAll mocks base method.
+func (m *MockGradePgRepo) SetAll(ctx context.Context, grades []models.Grade) ([]models.Grade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAll", ctx, grades)
+	ret0, _ := ret[0].([]models.Grade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAll indicates an expected call of SetAll.
+func (mr *MockGradePgRepoMockRecorder) SetAll(ctx, grades any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAll", reflect.TypeOf((*MockGradePgRepo)(nil).SetAll), ctx, grades)
+}
+
+// MockClassPgRepo is a mock of ClassPgRepo interface.
+type MockClassPgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockClassPgRepoMockRecorder
+}
+
+// MockClassPgRepoMockRecorder is the mock recorder for MockClassPgRepo.
+type MockClassPgRepoMockRecorder struct {
+	mock *MockClassPgRepo
+}
+
+// NewMockClassPgRepo creates a new mock instance.
+func NewMockClassPgRepo(ctrl *gomock.Controller) *MockClassPgRepo {
+	mock := &MockClassPgRepo{ctrl: ctrl}
+	mock.recorder = &MockClassPgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClassPgRepo) EXPECT() *MockClassPgRepoMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockClassPgRepo) GetByID(ctx context.Context, classID int64) (models.Class, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, classID)
+	ret0, _ := ret[0].(models.Class)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockClassPgRepoMockRecorder) GetByID(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockClassPgRepo)(nil).GetByID), ctx, classID)
+}
+
+// List mocks base method.
+func (m *MockClassPgRepo) List(ctx context.Context) ([]models.Class, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]models.Class)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockClassPgRepoMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockClassPgRepo)(nil).List), ctx)
+}
+
+// ListEvents mocks base method.
+func (m *MockClassPgRepo) ListEvents(ctx context.Context, classID int64) ([]models.EnrollmentEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, classID)
+	ret0, _ := ret[0].([]models.EnrollmentEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockClassPgRepoMockRecorder) ListEvents(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockClassPgRepo)(nil).ListEvents), ctx, classID)
+}
+
+// Update mocks base method.
+func (m *MockClassPgRepo) Update(ctx context.Context, classID int64, class models.Class) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, classID, class)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockClassPgRepoMockRecorder) Update(ctx, classID, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockClassPgRepo)(nil).Update), ctx, classID, class)
+}
+
+// MockPrerequisitePgRepo is a mock of PrerequisitePgRepo interface.
+type MockPrerequisitePgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrerequisitePgRepoMockRecorder
+}
+
+// MockPrerequisitePgRepoMockRecorder is the mock recorder for MockPrerequisitePgRepo.
+type MockPrerequisitePgRepoMockRecorder struct {
+	mock *MockPrerequisitePgRepo
+}
+
+// NewMockPrerequisitePgRepo creates a new mock instance.
+func NewMockPrerequisitePgRepo(ctrl *gomock.Controller) *MockPrerequisitePgRepo {
+	mock := &MockPrerequisitePgRepo{ctrl: ctrl}
+	mock.recorder = &MockPrerequisitePgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrerequisitePgRepo) EXPECT() *MockPrerequisitePgRepoMockRecorder {
+	return m.recorder
+}
+
+// GetBestScores mocks base method.
+func (m *MockPrerequisitePgRepo) GetBestScores(ctx context.Context, studentID int64, classIDs []int64) (map[int64]float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBestScores", ctx, studentID, classIDs)
+	ret0, _ := ret[0].(map[int64]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBestScores indicates an expected call of GetBestScores.
+func (mr *MockPrerequisitePgRepoMockRecorder) GetBestScores(ctx, studentID, classIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBestScores", reflect.TypeOf((*MockPrerequisitePgRepo)(nil).GetBestScores), ctx, studentID, classIDs)
+}
+
+// GetByClassID mocks base method.
+func (m *MockPrerequisitePgRepo) GetByClassID(ctx context.Context, classID int64) (models.Prerequisites, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByClassID", ctx, classID)
+	ret0, _ := ret[0].(models.Prerequisites)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByClassID indicates an expected call of GetByClassID.
+func (mr *MockPrerequisitePgRepoMockRecorder) GetByClassID(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByClassID", reflect.TypeOf((*MockPrerequisitePgRepo)(nil).GetByClassID), ctx, classID)
+}
+
+// GetByClassName mocks base method.
+func (m *MockPrerequisitePgRepo) GetByClassName(ctx context.Context, className string) (models.Prerequisites, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByClassName", ctx, className)
+	ret0, _ := ret[0].(models.Prerequisites)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByClassName indicates an expected call of GetByClassName.
+func (mr *MockPrerequisitePgRepoMockRecorder) GetByClassName(ctx, className any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByClassName", reflect.TypeOf((*MockPrerequisitePgRepo)(nil).GetByClassName), ctx, className)
+}
+
+// Replace mocks base method.
+func (m *MockPrerequisitePgRepo) Replace(ctx context.Context, classID int64, prerequisites models.Prerequisites) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, classID, prerequisites)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockPrerequisitePgRepoMockRecorder) Replace(ctx, classID, prerequisites any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockPrerequisitePgRepo)(nil).Replace), ctx, classID, prerequisites)
+}
+
+// MockAttendancePgRepo is a mock of AttendancePgRepo interface.
+type MockAttendancePgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttendancePgRepoMockRecorder
+}
+
+// MockAttendancePgRepoMockRecorder is the mock recorder for MockAttendancePgRepo.
+type MockAttendancePgRepoMockRecorder struct {
+	mock *MockAttendancePgRepo
+}
+
+// NewMockAttendancePgRepo creates a new mock instance.
+func NewMockAttendancePgRepo(ctrl *gomock.Controller) *MockAttendancePgRepo {
+	mock := &MockAttendancePgRepo{ctrl: ctrl}
+	mock.recorder = &MockAttendancePgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttendancePgRepo) EXPECT() *MockAttendancePgRepoMockRecorder {
+	return m.recorder
+}
+
+// AddSession mocks base method.
+func (m *MockAttendancePgRepo) AddSession(ctx context.Context, session models.ClassSession) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSession", ctx, session)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSession indicates an expected call of AddSession.
+func (mr *MockAttendancePgRepoMockRecorder) AddSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSession", reflect.TypeOf((*MockAttendancePgRepo)(nil).AddSession), ctx, session)
+}
+
+// DeleteSession mocks base method.
+func (m *MockAttendancePgRepo) DeleteSession(ctx context.Context, sessionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockAttendancePgRepoMockRecorder) DeleteSession(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockAttendancePgRepo)(nil).DeleteSession), ctx, sessionID)
+}
+
+// GetAttendance mocks base method.
+func (m *MockAttendancePgRepo) GetAttendance(ctx context.Context, sessionID int64) ([]models.AttendanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttendance", ctx, sessionID)
+	ret0, _ := ret[0].([]models.AttendanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttendance indicates an expected call of GetAttendance.
+func (mr *MockAttendancePgRepoMockRecorder) GetAttendance(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttendance", reflect.TypeOf((*MockAttendancePgRepo)(nil).GetAttendance), ctx, sessionID)
+}
+
+// GetClassRates mocks base method.
+func (m *MockAttendancePgRepo) GetClassRates(ctx context.Context, classID int64, from, to *time.Time) ([]models.AttendanceRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClassRates", ctx, classID, from, to)
+	ret0, _ := ret[0].([]models.AttendanceRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClassRates indicates an expected call of GetClassRates.
+func (mr *MockAttendancePgRepoMockRecorder) GetClassRates(ctx, classID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClassRates", reflect.TypeOf((*MockAttendancePgRepo)(nil).GetClassRates), ctx, classID, from, to)
+}
+
+// GetSession mocks base method.
+func (m *MockAttendancePgRepo) GetSession(ctx context.Context, sessionID int64) (models.ClassSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, sessionID)
+	ret0, _ := ret[0].(models.ClassSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockAttendancePgRepoMockRecorder) GetSession(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockAttendancePgRepo)(nil).GetSession), ctx, sessionID)
+}
+
+// GetStudentRates mocks base method.
+func (m *MockAttendancePgRepo) GetStudentRates(ctx context.Context, studentID int64, from, to *time.Time) ([]models.AttendanceRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentRates", ctx, studentID, from, to)
+	ret0, _ := ret[0].([]models.AttendanceRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStudentRates indicates an expected call of GetStudentRates.
+func (mr *MockAttendancePgRepoMockRecorder) GetStudentRates(ctx, studentID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentRates", reflect.TypeOf((*MockAttendancePgRepo)(nil).GetStudentRates), ctx, studentID, from, to)
+}
+
+// ListSessions mocks base method.
+func (m *MockAttendancePgRepo) ListSessions(ctx context.Context, classID int64, from, to *time.Time) ([]models.ClassSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, classID, from, to)
+	ret0, _ := ret[0].([]models.ClassSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAttendancePgRepoMockRecorder) ListSessions(ctx, classID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAttendancePgRepo)(nil).ListSessions), ctx, classID, from, to)
+}
+
+// Mark mocks base method.
+func (m *MockAttendancePgRepo) Mark(ctx context.Context, sessionID int64, marking models.AttendanceMarking) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mark", ctx, sessionID, marking)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Mark indicates an expected call of Mark.
+func (mr *MockAttendancePgRepoMockRecorder) Mark(ctx, sessionID, marking any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mark", reflect.TypeOf((*MockAttendancePgRepo)(nil).Mark), ctx, sessionID, marking)
+}
+
+// MockRoomPgRepo is a mock of RoomPgRepo interface.
+type MockRoomPgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoomPgRepoMockRecorder
+}
+
+// MockRoomPgRepoMockRecorder is the mock recorder for MockRoomPgRepo.
+type MockRoomPgRepoMockRecorder struct {
+	mock *MockRoomPgRepo
+}
+
+// NewMockRoomPgRepo creates a new mock instance.
+func NewMockRoomPgRepo(ctrl *gomock.Controller) *MockRoomPgRepo {
+	mock := &MockRoomPgRepo{ctrl: ctrl}
+	mock.recorder = &MockRoomPgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoomPgRepo) EXPECT() *MockRoomPgRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockRoomPgRepo) Add(ctx context.Context, room models.Room) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, room)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockRoomPgRepoMockRecorder) Add(ctx, room any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockRoomPgRepo)(nil).Add), ctx, room)
+}
+
+// Delete mocks base method.
+func (m *MockRoomPgRepo) Delete(ctx context.Context, roomID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, roomID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRoomPgRepoMockRecorder) Delete(ctx, roomID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRoomPgRepo)(nil).Delete), ctx, roomID)
+}
+
+// GetByID mocks base method.
+func (m *MockRoomPgRepo) GetByID(ctx context.Context, roomID int64) (models.Room, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, roomID)
+	ret0, _ := ret[0].(models.Room)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRoomPgRepoMockRecorder) GetByID(ctx, roomID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRoomPgRepo)(nil).GetByID), ctx, roomID)
+}
+
+// List mocks base method.
+func (m *MockRoomPgRepo) List(ctx context.Context) ([]models.Room, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]models.Room)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRoomPgRepoMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRoomPgRepo)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockRoomPgRepo) Update(ctx context.Context, roomID int64, room models.Room) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, roomID, room)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRoomPgRepoMockRecorder) Update(ctx, roomID, room any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRoomPgRepo)(nil).Update), ctx, roomID, room)
+}
+
+// MockSchedulePgRepo is a mock of SchedulePgRepo interface.
+type MockSchedulePgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulePgRepoMockRecorder
+}
+
+// MockSchedulePgRepoMockRecorder is the mock recorder for MockSchedulePgRepo.
+type MockSchedulePgRepoMockRecorder struct {
+	mock *MockSchedulePgRepo
+}
+
+// NewMockSchedulePgRepo creates a new mock instance.
+func NewMockSchedulePgRepo(ctrl *gomock.Controller) *MockSchedulePgRepo {
+	mock := &MockSchedulePgRepo{ctrl: ctrl}
+	mock.recorder = &MockSchedulePgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulePgRepo) EXPECT() *MockSchedulePgRepoMockRecorder {
+	return m.recorder
+}
+
+// AddSlot mocks base method.
+func (m *MockSchedulePgRepo) AddSlot(ctx context.Context, slot models.ScheduleSlot) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSlot", ctx, slot)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSlot indicates an expected call of AddSlot.
+func (mr *MockSchedulePgRepoMockRecorder) AddSlot(ctx, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSlot", reflect.TypeOf((*MockSchedulePgRepo)(nil).AddSlot), ctx, slot)
+}
+
+// DeleteSlot mocks base method.
+func (m *MockSchedulePgRepo) DeleteSlot(ctx context.Context, slotID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSlot", ctx, slotID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSlot indicates an expected call of DeleteSlot.
+func (mr *MockSchedulePgRepoMockRecorder) DeleteSlot(ctx, slotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSlot", reflect.TypeOf((*MockSchedulePgRepo)(nil).DeleteSlot), ctx, slotID)
+}
+
+// GetSlot mocks base method.
+func (m *MockSchedulePgRepo) GetSlot(ctx context.Context, slotID int64) (models.ScheduleSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlot", ctx, slotID)
+	ret0, _ := ret[0].(models.ScheduleSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlot indicates an expected call of GetSlot.
+func (mr *MockSchedulePgRepoMockRecorder) GetSlot(ctx, slotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlot", reflect.TypeOf((*MockSchedulePgRepo)(nil).GetSlot), ctx, slotID)
+}
+
+// GetStudentSchedule mocks base method.
+func (m *MockSchedulePgRepo) GetStudentSchedule(ctx context.Context, studentID int64, termID *int64) (models.AcademicTerm, []models.ScheduleSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStudentSchedule", ctx, studentID, termID)
+	ret0, _ := ret[0].(models.AcademicTerm)
+	ret1, _ := ret[1].([]models.ScheduleSlot)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetStudentSchedule indicates an expected call of GetStudentSchedule.
+func (mr *MockSchedulePgRepoMockRecorder) GetStudentSchedule(ctx, studentID, termID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStudentSchedule", reflect.TypeOf((*MockSchedulePgRepo)(nil).GetStudentSchedule), ctx, studentID, termID)
+}
+
+// ListByClass mocks base method.
+func (m *MockSchedulePgRepo) ListByClass(ctx context.Context, classID int64) ([]models.ScheduleSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByClass", ctx, classID)
+	ret0, _ := ret[0].([]models.ScheduleSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByClass indicates an expected call of ListByClass.
+func (mr *MockSchedulePgRepoMockRecorder) ListByClass(ctx, classID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByClass", reflect.TypeOf((*MockSchedulePgRepo)(nil).ListByClass), ctx, classID)
+}
+
+// UpdateSlot mocks base method.
+func (m *MockSchedulePgRepo) UpdateSlot(ctx context.Context, slotID int64, slot models.ScheduleSlot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSlot", ctx, slotID, slot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSlot indicates an expected call of UpdateSlot.
+func (mr *MockSchedulePgRepoMockRecorder) UpdateSlot(ctx, slotID, slot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSlot", reflect.TypeOf((*MockSchedulePgRepo)(nil).UpdateSlot), ctx, slotID, slot)
+}
+
+// MockContactPgRepo is a mock of ContactPgRepo interface.
+type MockContactPgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockContactPgRepoMockRecorder
+}
+
+// MockContactPgRepoMockRecorder is the mock recorder for MockContactPgRepo.
+type MockContactPgRepoMockRecorder struct {
+	mock *MockContactPgRepo
+}
+
+// NewMockContactPgRepo creates a new mock instance.
+func NewMockContactPgRepo(ctrl *gomock.Controller) *MockContactPgRepo {
+	mock := &MockContactPgRepo{ctrl: ctrl}
+	mock.recorder = &MockContactPgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContactPgRepo) EXPECT() *MockContactPgRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockContactPgRepo) Add(ctx context.Context, studentID int64, contact models.Contact) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, studentID, contact)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockContactPgRepoMockRecorder) Add(ctx, studentID, contact any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockContactPgRepo)(nil).Add), ctx, studentID, contact)
+}
+
+// GetByStudent mocks base method.
+func (m *MockContactPgRepo) GetByStudent(ctx context.Context, studentID, contactID int64) (models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByStudent", ctx, studentID, contactID)
+	ret0, _ := ret[0].(models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByStudent indicates an expected call of GetByStudent.
+func (mr *MockContactPgRepoMockRecorder) GetByStudent(ctx, studentID, contactID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStudent", reflect.TypeOf((*MockContactPgRepo)(nil).GetByStudent), ctx, studentID, contactID)
+}
+
+// Link mocks base method.
+func (m *MockContactPgRepo) Link(ctx context.Context, studentID, contactID int64, relationship string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Link", ctx, studentID, contactID, relationship)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Link indicates an expected call of Link.
+func (mr *MockContactPgRepoMockRecorder) Link(ctx, studentID, contactID, relationship any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Link", reflect.TypeOf((*MockContactPgRepo)(nil).Link), ctx, studentID, contactID, relationship)
+}
+
+// ListByStudent mocks base method.
+func (m *MockContactPgRepo) ListByStudent(ctx context.Context, studentID int64) ([]models.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByStudent", ctx, studentID)
+	ret0, _ := ret[0].([]models.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByStudent indicates an expected call of ListByStudent.
+func (mr *MockContactPgRepoMockRecorder) ListByStudent(ctx, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByStudent", reflect.TypeOf((*MockContactPgRepo)(nil).ListByStudent), ctx, studentID)
+}
+
+// Unlink mocks base method.
+func (m *MockContactPgRepo) Unlink(ctx context.Context, studentID, contactID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlink", ctx, studentID, contactID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlink indicates an expected call of Unlink.
+func (mr *MockContactPgRepoMockRecorder) Unlink(ctx, studentID, contactID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlink", reflect.TypeOf((*MockContactPgRepo)(nil).Unlink), ctx, studentID, contactID)
+}
+
+// Update mocks base method.
+func (m *MockContactPgRepo) Update(ctx context.Context, studentID int64, contact models.Contact) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, studentID, contact)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockContactPgRepoMockRecorder) Update(ctx, studentID, contact any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockContactPgRepo)(nil).Update), ctx, studentID, contact)
+}
+
+// MockAssignmentPgRepo is a mock of AssignmentPgRepo interface.
+type MockAssignmentPgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssignmentPgRepoMockRecorder
+}
+
+// MockAssignmentPgRepoMockRecorder is the mock recorder for MockAssignmentPgRepo.
+type MockAssignmentPgRepoMockRecorder struct {
+	mock *MockAssignmentPgRepo
+}
+
+// NewMockAssignmentPgRepo creates a new mock instance.
+func NewMockAssignmentPgRepo(ctrl *gomock.Controller) *MockAssignmentPgRepo {
+	mock := &MockAssignmentPgRepo{ctrl: ctrl}
+	mock.recorder = &MockAssignmentPgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssignmentPgRepo) EXPECT() *MockAssignmentPgRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockAssignmentPgRepo) Add(ctx context.Context, assignment models.Assignment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, assignment)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAssignmentPgRepoMockRecorder) Add(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAssignmentPgRepo)(nil).Add), ctx, assignment)
+}
+
+// Delete mocks base method.
+func (m *MockAssignmentPgRepo) Delete(ctx context.Context, assignmentID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, assignmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAssignmentPgRepoMockRecorder) Delete(ctx, assignmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAssignmentPgRepo)(nil).Delete), ctx, assignmentID)
+}
+
+// DeleteSubmission mocks base method.
+func (m *MockAssignmentPgRepo) DeleteSubmission(ctx context.Context, assignmentID, studentID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubmission", ctx, assignmentID, studentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubmission indicates an expected call of DeleteSubmission.
+func (mr *MockAssignmentPgRepoMockRecorder) DeleteSubmission(ctx, assignmentID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubmission", reflect.TypeOf((*MockAssignmentPgRepo)(nil).DeleteSubmission), ctx, assignmentID, studentID)
+}
+
+// GetByID mocks base method.
+func (m *MockAssignmentPgRepo) GetByID(ctx context.Context, assignmentID int64) (models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, assignmentID)
+	ret0, _ := ret[0].(models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAssignmentPgRepoMockRecorder) GetByID(ctx, assignmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAssignmentPgRepo)(nil).GetByID), ctx, assignmentID)
+}
+
+// GetGradebook mocks base method.
+func (m *MockAssignmentPgRepo) GetGradebook(ctx context.Context, classID int64, termID *int64) (models.AcademicTerm, []models.Assignment, []models.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGradebook", ctx, classID, termID)
+	ret0, _ := ret[0].(models.AcademicTerm)
+	ret1, _ := ret[1].([]models.Assignment)
+	ret2, _ := ret[2].([]models.Submission)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetGradebook indicates an expected call of GetGradebook.
+func (mr *MockAssignmentPgRepoMockRecorder) GetGradebook(ctx, classID, termID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGradebook", reflect.TypeOf((*MockAssignmentPgRepo)(nil).GetGradebook), ctx, classID, termID)
+}
+
+// GetSubmission mocks base method.
+func (m *MockAssignmentPgRepo) GetSubmission(ctx context.Context, assignmentID, studentID int64) (models.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubmission", ctx, assignmentID, studentID)
+	ret0, _ := ret[0].(models.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubmission indicates an expected call of GetSubmission.
+func (mr *MockAssignmentPgRepoMockRecorder) GetSubmission(ctx, assignmentID, studentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubmission", reflect.TypeOf((*MockAssignmentPgRepo)(nil).GetSubmission), ctx, assignmentID, studentID)
+}
+
+// ListByClass mocks base method.
+func (m *MockAssignmentPgRepo) ListByClass(ctx context.Context, classID int64, termID *int64) ([]models.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByClass", ctx, classID, termID)
+	ret0, _ := ret[0].([]models.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByClass indicates an expected call of ListByClass.
+func (mr *MockAssignmentPgRepoMockRecorder) ListByClass(ctx, classID, termID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByClass", reflect.TypeOf((*MockAssignmentPgRepo)(nil).ListByClass), ctx, classID, termID)
+}
+
+// ListSubmissions mocks base method.
+func (m *MockAssignmentPgRepo) ListSubmissions(ctx context.Context, assignmentID int64) ([]models.Submission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubmissions", ctx, assignmentID)
+	ret0, _ := ret[0].([]models.Submission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubmissions indicates an expected call of ListSubmissions.
+func (mr *MockAssignmentPgRepoMockRecorder) ListSubmissions(ctx, assignmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubmissions", reflect.TypeOf((*MockAssignmentPgRepo)(nil).ListSubmissions), ctx, assignmentID)
+}
+
+// SetSubmission mocks base method.
+func (m *MockAssignmentPgRepo) SetSubmission(ctx context.Context, submission models.Submission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSubmission", ctx, submission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSubmission indicates an expected call of SetSubmission.
+func (mr *MockAssignmentPgRepoMockRecorder) SetSubmission(ctx, submission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubmission", reflect.TypeOf((*MockAssignmentPgRepo)(nil).SetSubmission), ctx, submission)
+}
+
+// Update mocks base method.
+func (m *MockAssignmentPgRepo) Update(ctx context.Context, assignmentID int64, assignment models.Assignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, assignmentID, assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAssignmentPgRepoMockRecorder) Update(ctx, assignmentID, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAssignmentPgRepo)(nil).Update), ctx, assignmentID, assignment)
+}
+
+// MockAuditPgRepo is a mock of AuditPgRepo interface.
+type MockAuditPgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditPgRepoMockRecorder
+}
+
+// MockAuditPgRepoMockRecorder is the mock recorder for MockAuditPgRepo.
+type MockAuditPgRepoMockRecorder struct {
+	mock *MockAuditPgRepo
+}
+
+// NewMockAuditPgRepo creates a new mock instance.
+func NewMockAuditPgRepo(ctrl *gomock.Controller) *MockAuditPgRepo {
+	mock := &MockAuditPgRepo{ctrl: ctrl}
+	mock.recorder = &MockAuditPgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditPgRepo) EXPECT() *MockAuditPgRepoMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockAuditPgRepo) List(ctx context.Context) ([]models.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]models.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuditPgRepoMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuditPgRepo)(nil).List), ctx)
+}
+
+// Record mocks base method.
+func (m *MockAuditPgRepo) Record(ctx context.Context, event models.AuditEvent) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, event)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditPgRepoMockRecorder) Record(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditPgRepo)(nil).Record), ctx, event)
+}
+
+// MockAPIKeyPgRepo is a mock of APIKeyPgRepo interface.
+type MockAPIKeyPgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyPgRepoMockRecorder
+}
+
+// MockAPIKeyPgRepoMockRecorder is the mock recorder for MockAPIKeyPgRepo.
+type MockAPIKeyPgRepoMockRecorder struct {
+	mock *MockAPIKeyPgRepo
+}
+
+// NewMockAPIKeyPgRepo creates a new mock instance.
+func NewMockAPIKeyPgRepo(ctrl *gomock.Controller) *MockAPIKeyPgRepo {
+	mock := &MockAPIKeyPgRepo{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyPgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyPgRepo) EXPECT() *MockAPIKeyPgRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockAPIKeyPgRepo) Add(ctx context.Context, apiKey models.APIKey, keyHash []byte) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, apiKey, keyHash)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAPIKeyPgRepoMockRecorder) Add(ctx, apiKey, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAPIKeyPgRepo)(nil).Add), ctx, apiKey, keyHash)
+}
+
+// GetActiveByHash mocks base method.
+func (m *MockAPIKeyPgRepo) GetActiveByHash(ctx context.Context, keyHash []byte) (models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByHash", ctx, keyHash)
+	ret0, _ := ret[0].(models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByHash indicates an expected call of GetActiveByHash.
+func (mr *MockAPIKeyPgRepoMockRecorder) GetActiveByHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByHash", reflect.TypeOf((*MockAPIKeyPgRepo)(nil).GetActiveByHash), ctx, keyHash)
+}
+
+// List mocks base method.
+func (m *MockAPIKeyPgRepo) List(ctx context.Context) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAPIKeyPgRepoMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAPIKeyPgRepo)(nil).List), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyPgRepo) Revoke(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyPgRepoMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyPgRepo)(nil).Revoke), ctx, id)
+}
+
+// MockRoleAssignmentPgRepo is a mock of RoleAssignmentPgRepo interface.
+type MockRoleAssignmentPgRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleAssignmentPgRepoMockRecorder
+}
+
+// MockRoleAssignmentPgRepoMockRecorder is the mock recorder for MockRoleAssignmentPgRepo.
+type MockRoleAssignmentPgRepoMockRecorder struct {
+	mock *MockRoleAssignmentPgRepo
+}
+
+// NewMockRoleAssignmentPgRepo creates a new mock instance.
+func NewMockRoleAssignmentPgRepo(ctrl *gomock.Controller) *MockRoleAssignmentPgRepo {
+	mock := &MockRoleAssignmentPgRepo{ctrl: ctrl}
+	mock.recorder = &MockRoleAssignmentPgRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleAssignmentPgRepo) EXPECT() *MockRoleAssignmentPgRepoMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockRoleAssignmentPgRepo) Assign(ctx context.Context, assignment models.RoleAssignment) (models.RoleAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, assignment)
+	ret0, _ := ret[0].(models.RoleAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockRoleAssignmentPgRepoMockRecorder) Assign(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockRoleAssignmentPgRepo)(nil).Assign), ctx, assignment)
+}
+
+// GetBySubject mocks base method.
+func (m *MockRoleAssignmentPgRepo) GetBySubject(ctx context.Context, subject string) ([]models.RoleAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySubject", ctx, subject)
+	ret0, _ := ret[0].([]models.RoleAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySubject indicates an expected call of GetBySubject.
+func (mr *MockRoleAssignmentPgRepoMockRecorder) GetBySubject(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySubject", reflect.TypeOf((*MockRoleAssignmentPgRepo)(nil).GetBySubject), ctx, subject)
+}
+
+// List mocks base method.
+func (m *MockRoleAssignmentPgRepo) List(ctx context.Context) ([]models.RoleAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]models.RoleAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRoleAssignmentPgRepoMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRoleAssignmentPgRepo)(nil).List), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockRoleAssignmentPgRepo) Revoke(ctx context.Context, subject, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, subject, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockRoleAssignmentPgRepoMockRecorder) Revoke(ctx, subject, role any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockClassInfoPgRepo)(nil).Update), ctx, studentId, classInfoReq)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockRoleAssignmentPgRepo)(nil).Revoke), ctx, subject, role)
 }
@@ -59,7 +59,7 @@ func (d *TDB) TearDownDatabase(migrationPath string) {
 
 	defer db.Close()
 
-	if err := goose.Down(db, migrationPath); err != nil { // Specify the path to your migrations directory
+	if err := goose.Reset(db, migrationPath); err != nil { // Roll back every migration so each test starts empty
 		log.Printf("Error tearing down the database migrations: %v", err)
 		return
 	}
@@ -0,0 +1,146 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+func TestPrerequisite(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Replaced and read back", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		calculusID := addClass(ctx, t, db, "Calculus I", 30)
+		algebraID := addClass(ctx, t, db, "Linear Algebra", 30)
+		statisticsID := addClass(ctx, t, db, "Statistics", 30)
+		targetID := addClass(ctx, t, db, "Calculus II", 30)
+		prerequisiteRepo := NewPrerequisiteStorage(db.DB)
+		minScore := 70.0
+		//act
+		err := prerequisiteRepo.Replace(ctx, targetID, models.Prerequisites{AllOf: []models.PrerequisiteGroup{
+			{AnyOf: []models.Requirement{{ClassID: calculusID, MinScore: &minScore}}},
+			{AnyOf: []models.Requirement{{ClassID: statisticsID}, {ClassID: algebraID}}},
+		}})
+		require.NoError(t, err)
+		prerequisites, err := prerequisiteRepo.GetByClassName(ctx, "Calculus II")
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, targetID, prerequisites.ClassID)
+		require.Len(t, prerequisites.AllOf, 2)
+		assert.Equal(t, []models.Requirement{{ClassID: calculusID, ClassName: "Calculus I", MinScore: &minScore}}, prerequisites.AllOf[0].AnyOf)
+		assert.Equal(t, []models.Requirement{
+			{ClassID: algebraID, ClassName: "Linear Algebra"},
+			{ClassID: statisticsID, ClassName: "Statistics"},
+		}, prerequisites.AllOf[1].AnyOf)
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		firstID := addClass(ctx, t, db, "Calculus I", 30)
+		secondID := addClass(ctx, t, db, "Calculus II", 30)
+		prerequisiteRepo := NewPrerequisiteStorage(db.DB)
+		require.NoError(t, prerequisiteRepo.Replace(ctx, secondID, models.Prerequisites{AllOf: []models.PrerequisiteGroup{
+			{AnyOf: []models.Requirement{{ClassID: firstID}}},
+		}}))
+		//act
+		err := prerequisiteRepo.Replace(ctx, firstID, models.Prerequisites{AllOf: []models.PrerequisiteGroup{
+			{AnyOf: []models.Requirement{{ClassID: secondID}}},
+		}})
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrPrerequisiteCycle)
+		prerequisites, err := prerequisiteRepo.GetByClassID(ctx, firstID)
+		require.NoError(t, err)
+		assert.Empty(t, prerequisites.AllOf)
+	})
+	t.Run("Unknown classes", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		classID := addClass(ctx, t, db, "Calculus II", 30)
+		prerequisiteRepo := NewPrerequisiteStorage(db.DB)
+		requireMissing := models.Prerequisites{AllOf: []models.PrerequisiteGroup{{AnyOf: []models.Requirement{{ClassID: 999}}}}}
+		//act
+		referenceErr := prerequisiteRepo.Replace(ctx, classID, requireMissing)
+		notFoundErr := prerequisiteRepo.Replace(ctx, 999, models.Prerequisites{})
+		_, getErr := prerequisiteRepo.GetByClassName(ctx, "Alchemy")
+		//assert
+		assert.ErrorIs(t, referenceErr, pkgErrors.ErrReferenceNotFound)
+		assert.ErrorIs(t, notFoundErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, getErr, pkgErrors.ErrNotFound)
+	})
+	t.Run("Best scores", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		addCurrentTerm(ctx, t, db)
+		calculusID := addClass(ctx, t, db, "Calculus I", 30)
+		statisticsID := addClass(ctx, t, db, "Statistics", 30)
+		studentID := addStudents(ctx, t, db, 1)[0]
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		gradeRepo := NewGradeStorage(db.DB)
+		prerequisiteRepo := NewPrerequisiteStorage(db.DB)
+		for _, score := range []float64{55, 81.5} {
+			classInfoID, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Calculus I"})
+			require.NoError(t, err)
+			_, err = gradeRepo.Set(ctx, models.Grade{ClassInfoID: classInfoID, Score: score, Letter: "B", GradePoints: 3, Scale: "simple"})
+			require.NoError(t, err)
+		}
+		_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Statistics"})
+		require.NoError(t, err)
+		//act
+		scores, err := prerequisiteRepo.GetBestScores(ctx, studentID, []int64{calculusID, statisticsID})
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]float64{calculusID: 81.5}, scores)
+	})
+}
+
+func TestAudit(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Recorded", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		auditRepo := NewAuditStorage(db.DB)
+		studentID, className := int64(7), "Calculus II"
+		//act
+		id, err := auditRepo.Record(ctx, models.AuditEvent{
+			Actor:     "alice",
+			Action:    models.AuditActionPrerequisiteOverride,
+			StudentID: &studentID,
+			ClassName: &className,
+			Detail:    "missing Calculus I",
+		})
+		require.NoError(t, err)
+		events, err := auditRepo.List(ctx)
+		//assert
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, id, events[0].AuditID)
+		assert.Equal(t, "alice", events[0].Actor)
+		assert.Equal(t, &className, events[0].ClassName)
+		assert.NotZero(t, events[0].CreatedAt)
+	})
+}
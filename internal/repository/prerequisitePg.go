@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
+)
+
+// PrerequisiteStorage keeps the prerequisites of classes and the scores that satisfy them.
+type PrerequisiteStorage struct {
+	db connection.DBops
+}
+
+func NewPrerequisiteStorage(database connection.DBops) PrerequisiteStorage {
+	return PrerequisiteStorage{db: database}
+}
+
+// GetByClassID returns the prerequisites of a class. It returns ErrNotFound for an unknown class.
+func (r *PrerequisiteStorage) GetByClassID(ctx context.Context, classID int64) (models.Prerequisites, error) {
+	ctx, span := tracer.Start(ctx, "PrerequisiteStorage.GetByClassID")
+	defer span.End()
+
+	var className string
+
+	err := r.db.Get(ctx, &className, `SELECT class_name FROM class WHERE class_id = $1;`, classID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.Prerequisites{}, pkgErrors.ErrNotFound
+		}
+
+		return models.Prerequisites{}, err
+	}
+
+	return r.get(ctx, classID, className)
+}
+
+// GetByClassName returns the prerequisites of a class by name. It returns ErrNotFound for an unknown class.
+func (r *PrerequisiteStorage) GetByClassName(ctx context.Context, className string) (models.Prerequisites, error) {
+	ctx, span := tracer.Start(ctx, "PrerequisiteStorage.GetByClassName")
+	defer span.End()
+
+	var classID int64
+
+	err := r.db.Get(ctx, &classID, `SELECT class_id FROM class WHERE class_name = $1;`, className)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.Prerequisites{}, pkgErrors.ErrNotFound
+		}
+
+		return models.Prerequisites{}, err
+	}
+
+	return r.get(ctx, classID, className)
+}
+
+func (r *PrerequisiteStorage) get(ctx context.Context, classID int64, className string) (models.Prerequisites, error) {
+	var requirements []entities.PrerequisiteRequirement
+
+	err := r.db.Select(ctx, &requirements, `
+		SELECT p.group_no, p.required_class_id AS class_id, c.class_name, p.min_score
+		FROM class_prerequisite p
+		JOIN class c ON c.class_id = p.required_class_id
+		WHERE p.class_id = $1
+		ORDER BY p.group_no, c.class_name;
+	`, classID)
+	if err != nil {
+		return models.Prerequisites{}, err
+	}
+
+	prerequisites := models.Prerequisites{ClassID: classID, ClassName: className, AllOf: []models.PrerequisiteGroup{}}
+	for i, requirement := range requirements {
+		if i == 0 || requirement.GroupNo != requirements[i-1].GroupNo {
+			prerequisites.AllOf = append(prerequisites.AllOf, models.PrerequisiteGroup{})
+		}
+
+		group := &prerequisites.AllOf[len(prerequisites.AllOf)-1]
+		group.AnyOf = append(group.AnyOf, requirement.ToRequirementDomain())
+	}
+
+	return prerequisites, nil
+}
+
+// Replace sets the prerequisites of a class, replacing the previous ones. It returns ErrNotFound for
+// an unknown class, ErrReferenceNotFound for an unknown required class and ErrPrerequisiteCycle when
+// the class would become its own prerequisite.
+func (r *PrerequisiteStorage) Replace(ctx context.Context, classID int64, prerequisites models.Prerequisites) error {
+	ctx, span := tracer.Start(ctx, "PrerequisiteStorage.Replace")
+	defer span.End()
+
+	err := r.db.InTx(ctx, func(tx connection.DBops) error {
+		// Locking the class serializes replacements, so that two of them cannot close a cycle together.
+		command, err := tx.Exec(ctx, `SELECT class_id FROM class WHERE class_id = $1 FOR UPDATE;`, classID)
+		if err != nil {
+			return err
+		}
+
+		if command.RowsAffected() == 0 {
+			return pkgErrors.ErrNotFound
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM class_prerequisite WHERE class_id = $1`, classID); err != nil {
+			return err
+		}
+
+		for groupNo, group := range prerequisites.AllOf {
+			for _, requirement := range group.AnyOf {
+				_, err := tx.Exec(ctx, `
+					INSERT INTO class_prerequisite(class_id, group_no, required_class_id, min_score) VALUES($1, $2, $3, $4);
+				`, classID, groupNo, requirement.ClassID, requirement.MinScore)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		var cycle bool
+
+		err = tx.Get(ctx, &cycle, `
+			WITH RECURSIVE required AS (
+				SELECT required_class_id FROM class_prerequisite WHERE class_id = $1
+				UNION
+				SELECT p.required_class_id FROM class_prerequisite p JOIN required r ON p.class_id = r.required_class_id
+			)
+			SELECT EXISTS(SELECT 1 FROM required WHERE required_class_id = $1);
+		`, classID)
+		if err != nil {
+			return err
+		}
+
+		if cycle {
+			return pkgErrors.ErrPrerequisiteCycle
+		}
+
+		return nil
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return pkgErrors.ErrReferenceNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// GetBestScores returns the best score of a student in each of classIDs, keyed by class id.
+// Classes the student has no grade in have no entry.
+func (r *PrerequisiteStorage) GetBestScores(ctx context.Context, studentID int64, classIDs []int64) (map[int64]float64, error) {
+	ctx, span := tracer.Start(ctx, "PrerequisiteStorage.GetBestScores")
+	defer span.End()
+
+	var scores []entities.ClassScore
+
+	err := r.db.Select(ctx, &scores, `
+		SELECT c.class_id, MAX(g.score) AS score
+		FROM class_info ci
+		JOIN class c ON c.class_name = ci.class_name
+		JOIN grade g ON g.class_info_id = ci.id
+		WHERE ci.student_id = $1 AND c.class_id = ANY($2)
+		GROUP BY c.class_id;
+	`, studentID, classIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	best := make(map[int64]float64, len(scores))
+	for _, score := range scores {
+		best[score.ClassID] = score.Score
+	}
+
+	return best, nil
+}
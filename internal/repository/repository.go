@@ -3,18 +3,131 @@ package repository
 
 import (
 	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/tracing"
 	"context"
+	"time"
 )
 
+var tracer = tracing.Tracer("repository")
+
 type StudentPgRepo interface {
 	Add(ctx context.Context, studentReq models.StudentRequest) (int64, error)
 	GetByID(ctx context.Context, studentID int64) (models.StudentRequest, error)
 	Delete(ctx context.Context, studentID int64) error
 	Update(ctx context.Context, studentID int64, studentReq models.StudentRequest) error
+	UpdateProfile(ctx context.Context, studentID int64, studentReq models.StudentRequest) error
+	SetStatus(ctx context.Context, studentID int64, status string, reason string) (models.StudentStatusChange, error)
+	StatusHistory(ctx context.Context, studentID int64) ([]models.StudentStatusChange, error)
+	GetByIDWithFields(ctx context.Context, studentID int64, fields []string) (models.StudentRequest, error)
+	List(ctx context.Context, limit, offset int64, fields []string) ([]models.StudentRequest, error)
+	Count(ctx context.Context) (int64, error)
 }
 type ClassInfoPgRepo interface {
 	Add(ctx context.Context, classInfoReq models.ClassInfo) (int64, error)
+	LegacyTermID(ctx context.Context) (int64, error)
 	GetByStudentID(ctx context.Context, studentID int64) ([]models.ClassInfo, error)
-	DeleteClassByStudentID(ctx context.Context, studentID int64) error
+	GetByStudentIDInTerm(ctx context.Context, studentID int64, termID *int64) ([]models.ClassInfo, error)
+	GetByStudentIDs(ctx context.Context, studentIDs []int64) (map[int64][]models.ClassInfo, error)
+	DeleteClassByStudentID(ctx context.Context, studentID int64, termID *int64) error
 	Update(ctx context.Context, studentID int64, classInfoReq models.ClassInfo) error
+	GetByID(ctx context.Context, id int64) (models.ClassInfo, error)
+	UpdateByID(ctx context.Context, id int64, classInfoReq models.ClassInfo) error
+	DeleteByID(ctx context.Context, id int64) error
+}
+type TeacherPgRepo interface {
+	Add(ctx context.Context, teacherReq models.TeacherRequest) (int64, error)
+	GetByID(ctx context.Context, teacherID int64) (models.TeacherRequest, error)
+	Delete(ctx context.Context, teacherID int64) error
+	Update(ctx context.Context, teacherID int64, teacherReq models.TeacherRequest) error
+	AssignClass(ctx context.Context, assignment models.TeacherAssignment) (models.TeacherAssignment, error)
+	UnassignClass(ctx context.Context, teacherID, classID int64) error
+	GetClassesByTeacherID(ctx context.Context, teacherID int64) ([]models.TeacherAssignment, error)
+	GetTeachersByClassID(ctx context.Context, classID int64) ([]models.TeacherAssignment, error)
+}
+type AcademicTermPgRepo interface {
+	Add(ctx context.Context, term models.AcademicTerm) (int64, error)
+	GetByID(ctx context.Context, termID int64) (models.AcademicTerm, error)
+	GetCurrent(ctx context.Context) (models.AcademicTerm, error)
+	List(ctx context.Context) ([]models.AcademicTerm, error)
+	Update(ctx context.Context, termID int64, term models.AcademicTerm) error
+}
+type GradePgRepo interface {
+	Set(ctx context.Context, grade models.Grade) (models.Grade, error)
+	SetAll(ctx context.Context, grades []models.Grade) ([]models.Grade, error)
+	GetByClassInfoID(ctx context.Context, classInfoID int64) (models.Grade, error)
+	Delete(ctx context.Context, classInfoID int64) error
+	GetTranscript(ctx context.Context, studentID int64) ([]models.TranscriptEntry, error)
+}
+type ClassPgRepo interface {
+	List(ctx context.Context) ([]models.Class, error)
+	GetByID(ctx context.Context, classID int64) (models.Class, error)
+	Update(ctx context.Context, classID int64, class models.Class) error
+	ListEvents(ctx context.Context, classID int64) ([]models.EnrollmentEvent, error)
+}
+type PrerequisitePgRepo interface {
+	GetByClassID(ctx context.Context, classID int64) (models.Prerequisites, error)
+	GetByClassName(ctx context.Context, className string) (models.Prerequisites, error)
+	Replace(ctx context.Context, classID int64, prerequisites models.Prerequisites) error
+	GetBestScores(ctx context.Context, studentID int64, classIDs []int64) (map[int64]float64, error)
+}
+type AttendancePgRepo interface {
+	AddSession(ctx context.Context, session models.ClassSession) (int64, error)
+	GetSession(ctx context.Context, sessionID int64) (models.ClassSession, error)
+	ListSessions(ctx context.Context, classID int64, from, to *time.Time) ([]models.ClassSession, error)
+	DeleteSession(ctx context.Context, sessionID int64) error
+	GetAttendance(ctx context.Context, sessionID int64) ([]models.AttendanceRecord, error)
+	Mark(ctx context.Context, sessionID int64, marking models.AttendanceMarking) error
+	GetStudentRates(ctx context.Context, studentID int64, from, to *time.Time) ([]models.AttendanceRate, error)
+	GetClassRates(ctx context.Context, classID int64, from, to *time.Time) ([]models.AttendanceRate, error)
+}
+type RoomPgRepo interface {
+	Add(ctx context.Context, room models.Room) (int64, error)
+	GetByID(ctx context.Context, roomID int64) (models.Room, error)
+	List(ctx context.Context) ([]models.Room, error)
+	Update(ctx context.Context, roomID int64, room models.Room) error
+	Delete(ctx context.Context, roomID int64) error
+}
+type SchedulePgRepo interface {
+	AddSlot(ctx context.Context, slot models.ScheduleSlot) (int64, error)
+	GetSlot(ctx context.Context, slotID int64) (models.ScheduleSlot, error)
+	ListByClass(ctx context.Context, classID int64) ([]models.ScheduleSlot, error)
+	UpdateSlot(ctx context.Context, slotID int64, slot models.ScheduleSlot) error
+	DeleteSlot(ctx context.Context, slotID int64) error
+	GetStudentSchedule(ctx context.Context, studentID int64, termID *int64) (models.AcademicTerm, []models.ScheduleSlot, error)
+}
+type ContactPgRepo interface {
+	ListByStudent(ctx context.Context, studentID int64) ([]models.Contact, error)
+	GetByStudent(ctx context.Context, studentID, contactID int64) (models.Contact, error)
+	Add(ctx context.Context, studentID int64, contact models.Contact) (int64, error)
+	Link(ctx context.Context, studentID, contactID int64, relationship string) error
+	Update(ctx context.Context, studentID int64, contact models.Contact) error
+	Unlink(ctx context.Context, studentID, contactID int64) error
+}
+type AssignmentPgRepo interface {
+	Add(ctx context.Context, assignment models.Assignment) (int64, error)
+	GetByID(ctx context.Context, assignmentID int64) (models.Assignment, error)
+	ListByClass(ctx context.Context, classID int64, termID *int64) ([]models.Assignment, error)
+	Update(ctx context.Context, assignmentID int64, assignment models.Assignment) error
+	Delete(ctx context.Context, assignmentID int64) error
+	ListSubmissions(ctx context.Context, assignmentID int64) ([]models.Submission, error)
+	GetSubmission(ctx context.Context, assignmentID, studentID int64) (models.Submission, error)
+	SetSubmission(ctx context.Context, submission models.Submission) error
+	DeleteSubmission(ctx context.Context, assignmentID, studentID int64) error
+	GetGradebook(ctx context.Context, classID int64, termID *int64) (models.AcademicTerm, []models.Assignment, []models.Submission, error)
+}
+type AuditPgRepo interface {
+	Record(ctx context.Context, event models.AuditEvent) (int64, error)
+	List(ctx context.Context) ([]models.AuditEvent, error)
+}
+type APIKeyPgRepo interface {
+	Add(ctx context.Context, apiKey models.APIKey, keyHash []byte) (int64, error)
+	GetActiveByHash(ctx context.Context, keyHash []byte) (models.APIKey, error)
+	List(ctx context.Context) ([]models.APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+}
+type RoleAssignmentPgRepo interface {
+	Assign(ctx context.Context, assignment models.RoleAssignment) (models.RoleAssignment, error)
+	GetBySubject(ctx context.Context, subject string) ([]models.RoleAssignment, error)
+	List(ctx context.Context) ([]models.RoleAssignment, error)
+	Revoke(ctx context.Context, subject string, role string) error
 }
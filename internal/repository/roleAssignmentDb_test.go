@@ -0,0 +1,80 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+func TestRoleAssignment(t *testing.T) {
+
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Success", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentRepo := NewStudentStorage(db.DB)
+		roleRepo := NewRoleAssignmentStorage(db.DB)
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		//act
+		_, err = roleRepo.Assign(ctx, models.RoleAssignment{Subject: "alice", Role: "teacher"})
+		require.NoError(t, err)
+		assignment, err := roleRepo.Assign(ctx, models.RoleAssignment{Subject: "alice", Role: "student", StudentID: &studentID})
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, studentID, *assignment.StudentID)
+		assert.False(t, assignment.CreatedAt.IsZero())
+		//act
+		assignments, err := roleRepo.GetBySubject(ctx, "alice")
+		//assert
+		require.NoError(t, err)
+		require.Equal(t, 2, len(assignments))
+		assert.Equal(t, "student", assignments[0].Role)
+		assert.Equal(t, "teacher", assignments[1].Role)
+		//act
+		err = roleRepo.Revoke(ctx, "alice", "teacher")
+		//assert
+		require.NoError(t, err)
+		assignments, err = roleRepo.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(assignments))
+	})
+	t.Run("Fail", func(t *testing.T) {
+		t.Run("Unknown student", func(t *testing.T) {
+			db.SetUpDatabase(migrationPath)
+			defer db.TearDownDatabase(migrationPath)
+			//arrange
+			roleRepo := NewRoleAssignmentStorage(db.DB)
+			studentID := int64(404)
+			//act
+			_, err := roleRepo.Assign(ctx, models.RoleAssignment{Subject: "bob", Role: "student", StudentID: &studentID})
+			//assert
+			assert.ErrorIs(t, err, pkgErrors.ErrReferenceNotFound)
+		})
+		t.Run("Revoke missing assignment", func(t *testing.T) {
+			db.SetUpDatabase(migrationPath)
+			defer db.TearDownDatabase(migrationPath)
+			//arrange
+			roleRepo := NewRoleAssignmentStorage(db.DB)
+			//act
+			err := roleRepo.Revoke(ctx, "bob", "admin")
+			//assert
+			assert.ErrorIs(t, err, pkgErrors.ErrNotFound)
+		})
+	})
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/jackc/pgconn"
+)
+
+// foreignKeyViolation is the SQLSTATE raised when student_id does not reference an existing student.
+const foreignKeyViolation = "23503"
+
+type RoleAssignmentStorage struct {
+	db connection.DBops
+}
+
+func NewRoleAssignmentStorage(database connection.DBops) RoleAssignmentStorage {
+	return RoleAssignmentStorage{db: database}
+}
+
+// Assign creates the assignment, or updates the student it is scoped to when it already exists.
+func (r *RoleAssignmentStorage) Assign(ctx context.Context, assignment models.RoleAssignment) (models.RoleAssignment, error) {
+	ctx, span := tracer.Start(ctx, "RoleAssignmentStorage.Assign")
+	defer span.End()
+
+	var stored entities.RoleAssignment
+
+	err := r.db.Get(ctx, &stored, `
+		INSERT INTO role_assignment(subject, role, student_id) VALUES($1, $2, $3)
+		ON CONFLICT (subject, role) DO UPDATE SET student_id = EXCLUDED.student_id
+		RETURNING subject, role, student_id, created_at;
+	`, assignment.Subject, assignment.Role, assignment.StudentID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return models.RoleAssignment{}, pkgErrors.ErrReferenceNotFound
+		}
+
+		return models.RoleAssignment{}, err
+	}
+
+	return stored.ToRoleAssignmentDomain(), nil
+}
+
+func (r *RoleAssignmentStorage) GetBySubject(ctx context.Context, subject string) ([]models.RoleAssignment, error) {
+	ctx, span := tracer.Start(ctx, "RoleAssignmentStorage.GetBySubject")
+	defer span.End()
+
+	var assignments []entities.RoleAssignment
+
+	err := r.db.Select(ctx, &assignments, `
+		SELECT subject, role, student_id, created_at FROM role_assignment WHERE subject = $1 ORDER BY role;
+	`, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(assignments, func(a entities.RoleAssignment) models.RoleAssignment {
+		return a.ToRoleAssignmentDomain()
+	}), nil
+}
+
+func (r *RoleAssignmentStorage) List(ctx context.Context) ([]models.RoleAssignment, error) {
+	ctx, span := tracer.Start(ctx, "RoleAssignmentStorage.List")
+	defer span.End()
+
+	var assignments []entities.RoleAssignment
+
+	err := r.db.Select(ctx, &assignments, `
+		SELECT subject, role, student_id, created_at FROM role_assignment ORDER BY subject, role;
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(assignments, func(a entities.RoleAssignment) models.RoleAssignment {
+		return a.ToRoleAssignmentDomain()
+	}), nil
+}
+
+func (r *RoleAssignmentStorage) Revoke(ctx context.Context, subject string, role string) error {
+	ctx, span := tracer.Start(ctx, "RoleAssignmentStorage.Revoke")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, "DELETE FROM role_assignment WHERE subject = $1 AND role = $2", subject, role)
+	if err != nil {
+		return err
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
+)
+
+const roomColumns = `room_id, room_name, capacity, created_at`
+
+// RoomStorage keeps the rooms that classes are scheduled in.
+type RoomStorage struct {
+	db connection.DBops
+}
+
+func NewRoomStorage(database connection.DBops) RoomStorage {
+	return RoomStorage{db: database}
+}
+
+// roomError maps a taken room name, and the removal of a room that is still scheduled, to ErrConflict.
+func roomError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && (pgErr.Code == uniqueViolation || pgErr.Code == foreignKeyViolation) {
+		return pkgErrors.ErrConflict
+	}
+
+	return err
+}
+
+// Add creates a room. It returns ErrConflict when the name is taken.
+func (r *RoomStorage) Add(ctx context.Context, room models.Room) (int64, error) {
+	ctx, span := tracer.Start(ctx, "RoomStorage.Add")
+	defer span.End()
+
+	var roomID int64
+
+	err := r.db.ExecQueryRow(ctx, `
+		INSERT INTO room(room_name, capacity) VALUES($1, $2) RETURNING room_id;
+	`, room.RoomName, room.Capacity).Scan(&roomID)
+	if err != nil {
+		return -1, roomError(err)
+	}
+
+	return roomID, nil
+}
+
+func (r *RoomStorage) GetByID(ctx context.Context, roomID int64) (models.Room, error) {
+	ctx, span := tracer.Start(ctx, "RoomStorage.GetByID")
+	defer span.End()
+
+	var room entities.Room
+
+	if err := r.db.Get(ctx, &room, `SELECT `+roomColumns+` FROM room WHERE room_id = $1;`, roomID); err != nil {
+		if pgxscan.NotFound(err) {
+			return models.Room{}, pkgErrors.ErrNotFound
+		}
+
+		return models.Room{}, err
+	}
+
+	return room.ToRoomDomain(), nil
+}
+
+// List returns every room ordered by name.
+func (r *RoomStorage) List(ctx context.Context) ([]models.Room, error) {
+	ctx, span := tracer.Start(ctx, "RoomStorage.List")
+	defer span.End()
+
+	var rooms []entities.Room
+
+	if err := r.db.Select(ctx, &rooms, `SELECT `+roomColumns+` FROM room ORDER BY room_name;`); err != nil {
+		return nil, err
+	}
+
+	return utils.Map(rooms, func(room entities.Room) models.Room {
+		return room.ToRoomDomain()
+	}), nil
+}
+
+// Update renames a room and sets its capacity. It returns ErrConflict when the name is taken.
+func (r *RoomStorage) Update(ctx context.Context, roomID int64, room models.Room) error {
+	ctx, span := tracer.Start(ctx, "RoomStorage.Update")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, `
+		UPDATE room SET room_name = $2, capacity = $3 WHERE room_id = $1
+	`, roomID, room.RoomName, room.Capacity)
+	if err != nil {
+		return roomError(err)
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a room. It returns ErrConflict while classes are scheduled in it.
+func (r *RoomStorage) Delete(ctx context.Context, roomID int64) error {
+	ctx, span := tracer.Start(ctx, "RoomStorage.Delete")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, "DELETE FROM room WHERE room_id = $1", roomID)
+	if err != nil {
+		return roomError(err)
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
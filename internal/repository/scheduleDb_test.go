@@ -0,0 +1,171 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+// addRoom creates a room and returns its id.
+func addRoom(ctx context.Context, t *testing.T, db *postgres.TDB, roomName string) int64 {
+	t.Helper()
+
+	roomRepo := NewRoomStorage(db.DB)
+	roomID, err := roomRepo.Add(ctx, models.Room{RoomName: roomName})
+	require.NoError(t, err)
+
+	return roomID
+}
+
+func TestSchedule(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Room booked", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termID := addCurrentTerm(ctx, t, db)
+		mathID := addClass(ctx, t, db, "Math", 30)
+		artID := addClass(ctx, t, db, "Art", 30)
+		roomID := addRoom(ctx, t, db, "A101")
+		scheduleRepo := NewScheduleStorage(db.DB)
+		_, err := scheduleRepo.AddSlot(ctx, models.ScheduleSlot{
+			ClassID: mathID, TermID: termID, RoomID: roomID, DayOfWeek: 1, StartTime: "09:00", EndTime: "10:30",
+		})
+		require.NoError(t, err)
+		//act
+		_, overlapErr := scheduleRepo.AddSlot(ctx, models.ScheduleSlot{
+			ClassID: artID, TermID: termID, RoomID: roomID, DayOfWeek: 1, StartTime: "10:00", EndTime: "11:00",
+		})
+		_, adjacentErr := scheduleRepo.AddSlot(ctx, models.ScheduleSlot{
+			ClassID: artID, TermID: termID, RoomID: roomID, DayOfWeek: 1, StartTime: "10:30", EndTime: "11:30",
+		})
+		//assert
+		assert.ErrorIs(t, overlapErr, pkgErrors.ErrRoomBooked)
+		assert.NoError(t, adjacentErr)
+		slots, err := scheduleRepo.ListByClass(ctx, artID)
+		require.NoError(t, err)
+		require.Len(t, slots, 1)
+		assert.Equal(t, "10:30", slots[0].StartTime)
+		roomRepo := NewRoomStorage(db.DB)
+		assert.ErrorIs(t, roomRepo.Delete(ctx, roomID), pkgErrors.ErrConflict)
+	})
+	t.Run("Teacher booked", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termID := addCurrentTerm(ctx, t, db)
+		mathID := addClass(ctx, t, db, "Math", 30)
+		artID := addClass(ctx, t, db, "Art", 30)
+		scheduleRepo := NewScheduleStorage(db.DB)
+		for _, slot := range []models.ScheduleSlot{
+			{ClassID: mathID, RoomID: addRoom(ctx, t, db, "A101"), DayOfWeek: 2, StartTime: "09:00", EndTime: "10:00"},
+			{ClassID: artID, RoomID: addRoom(ctx, t, db, "B2"), DayOfWeek: 2, StartTime: "09:30", EndTime: "10:30"},
+		} {
+			slot.TermID = termID
+			_, err := scheduleRepo.AddSlot(ctx, slot)
+			require.NoError(t, err)
+		}
+		teacherRepo := NewTeacherStorage(db.DB)
+		teacherID, err := teacherRepo.Add(ctx, models.TeacherRequest{TeacherName: "Ada"})
+		require.NoError(t, err)
+		_, err = teacherRepo.AssignClass(ctx, models.TeacherAssignment{
+			TeacherID: teacherID, ClassName: "Math", Role: models.TeacherRoleLead,
+		})
+		require.NoError(t, err)
+		//act
+		_, err = teacherRepo.AssignClass(ctx, models.TeacherAssignment{
+			TeacherID: teacherID, ClassName: "Art", Role: models.TeacherRoleLead,
+		})
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrTeacherBooked)
+	})
+	t.Run("Student booked", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termID := addCurrentTerm(ctx, t, db)
+		mathID := addClass(ctx, t, db, "Math", 30)
+		artID := addClass(ctx, t, db, "Art", 30)
+		studentID := addStudents(ctx, t, db, 1)[0]
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+		require.NoError(t, err)
+		_, err = classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Art"})
+		require.NoError(t, err)
+		scheduleRepo := NewScheduleStorage(db.DB)
+		_, err = scheduleRepo.AddSlot(ctx, models.ScheduleSlot{
+			ClassID: mathID, TermID: termID, RoomID: addRoom(ctx, t, db, "A101"),
+			DayOfWeek: 3, StartTime: "13:00", EndTime: "14:00",
+		})
+		require.NoError(t, err)
+		//act
+		_, err = scheduleRepo.AddSlot(ctx, models.ScheduleSlot{
+			ClassID: artID, TermID: termID, RoomID: addRoom(ctx, t, db, "B2"),
+			DayOfWeek: 3, StartTime: "13:30", EndTime: "14:30",
+		})
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrStudentBooked)
+		term, slots, err := scheduleRepo.GetStudentSchedule(ctx, studentID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, termID, term.TermID)
+		require.Len(t, slots, 1)
+		assert.Equal(t, models.ScheduleSlot{
+			SlotID: slots[0].SlotID, ClassID: mathID, ClassName: "Math", TermID: termID, RoomID: slots[0].RoomID,
+			RoomName: "A101", DayOfWeek: 3, StartTime: "13:00", EndTime: "14:00", Status: "enrolled",
+		}, slots[0])
+	})
+	t.Run("Enrollment into a booked time", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		termID := addCurrentTerm(ctx, t, db)
+		scheduleRepo := NewScheduleStorage(db.DB)
+		for _, slot := range []models.ScheduleSlot{
+			{ClassID: addClass(ctx, t, db, "Math", 30), RoomID: addRoom(ctx, t, db, "A101")},
+			{ClassID: addClass(ctx, t, db, "Art", 30), RoomID: addRoom(ctx, t, db, "B2")},
+		} {
+			slot.TermID, slot.DayOfWeek, slot.StartTime, slot.EndTime = termID, 5, "08:00", "09:00"
+			_, err := scheduleRepo.AddSlot(ctx, slot)
+			require.NoError(t, err)
+		}
+		studentID := addStudents(ctx, t, db, 1)[0]
+		classInfoRepo := NewClassInfoStorage(db.DB)
+		_, err := classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Math"})
+		require.NoError(t, err)
+		//act
+		_, err = classInfoRepo.Add(ctx, models.ClassInfo{StudentID: studentID, ClassName: "Art"})
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrStudentBooked)
+	})
+	t.Run("Unknown term and student", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		scheduleRepo := NewScheduleStorage(db.DB)
+		termID := int64(999)
+		//act
+		_, _, currentErr := scheduleRepo.GetStudentSchedule(ctx, 1, nil)
+		_, _, termErr := scheduleRepo.GetStudentSchedule(ctx, 1, &termID)
+		_, addErr := scheduleRepo.AddSlot(ctx, models.ScheduleSlot{
+			ClassID: 999, TermID: termID, RoomID: 999, DayOfWeek: 1, StartTime: "09:00", EndTime: "10:00",
+		})
+		//assert
+		assert.ErrorIs(t, currentErr, pkgErrors.ErrNoCurrentTerm)
+		assert.ErrorIs(t, termErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, addErr, pkgErrors.ErrReferenceNotFound)
+	})
+}
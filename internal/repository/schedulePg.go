@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
+)
+
+const (
+	scheduleSlotColumns = `s.slot_id, s.class_id, c.class_name, s.term_id, s.room_id, r.room_name, s.day_of_week,
+		to_char(s.start_time, 'HH24:MI') AS start_time, to_char(s.end_time, 'HH24:MI') AS end_time`
+	scheduleSlotTables = `schedule_slot s JOIN class c ON c.class_id = s.class_id JOIN room r ON r.room_id = s.room_id`
+)
+
+// ScheduleStorage keeps the weekly slots of classes. Overlapping bookings of a room, a teacher or an
+// enrolled student are rejected by the exclusion constraints of the database.
+type ScheduleStorage struct {
+	db connection.DBops
+}
+
+func NewScheduleStorage(database connection.DBops) ScheduleStorage {
+	return ScheduleStorage{db: database}
+}
+
+// scheduleError maps the overlaps rejected by the exclusion constraints of schedules to pkgErrors.
+// They are raised by slots, and by the teacher assignments and enrollments that book their slots.
+func scheduleError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != exclusionViolation {
+		return err
+	}
+
+	switch pgErr.ConstraintName {
+	case "schedule_slot_room_overlap":
+		return pkgErrors.ErrRoomBooked
+	case "teacher_booking_overlap":
+		return pkgErrors.ErrTeacherBooked
+	case "student_booking_overlap":
+		return pkgErrors.ErrStudentBooked
+	}
+
+	return err
+}
+
+// slotError maps the errors of writing a slot: ErrReferenceNotFound for an unknown class, term or room,
+// and the overlaps of scheduleError.
+func slotError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return pkgErrors.ErrReferenceNotFound
+	}
+
+	return scheduleError(err)
+}
+
+// AddSlot schedules a class in a room every week of a term.
+func (r *ScheduleStorage) AddSlot(ctx context.Context, slot models.ScheduleSlot) (int64, error) {
+	ctx, span := tracer.Start(ctx, "ScheduleStorage.AddSlot")
+	defer span.End()
+
+	var slotID int64
+
+	err := r.db.ExecQueryRow(ctx, `
+		INSERT INTO schedule_slot(class_id, term_id, room_id, day_of_week, start_time, end_time)
+		VALUES($1, $2, $3, $4, $5::TEXT::TIME, $6::TEXT::TIME)
+		RETURNING slot_id;
+	`, slot.ClassID, slot.TermID, slot.RoomID, slot.DayOfWeek, slot.StartTime, slot.EndTime).Scan(&slotID)
+	if err != nil {
+		return -1, slotError(err)
+	}
+
+	return slotID, nil
+}
+
+func (r *ScheduleStorage) GetSlot(ctx context.Context, slotID int64) (models.ScheduleSlot, error) {
+	ctx, span := tracer.Start(ctx, "ScheduleStorage.GetSlot")
+	defer span.End()
+
+	var slot entities.ScheduleSlot
+
+	err := r.db.Get(ctx, &slot, `SELECT `+scheduleSlotColumns+` FROM `+scheduleSlotTables+` WHERE s.slot_id = $1;`, slotID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.ScheduleSlot{}, pkgErrors.ErrNotFound
+		}
+
+		return models.ScheduleSlot{}, err
+	}
+
+	return slot.ToScheduleSlotDomain(), nil
+}
+
+// ListByClass returns the slots of a class in every term, in weekly order. It returns ErrNotFound for an unknown class.
+func (r *ScheduleStorage) ListByClass(ctx context.Context, classID int64) ([]models.ScheduleSlot, error) {
+	ctx, span := tracer.Start(ctx, "ScheduleStorage.ListByClass")
+	defer span.End()
+
+	var slots []entities.ScheduleSlot
+
+	err := r.db.Select(ctx, &slots, `
+		SELECT `+scheduleSlotColumns+` FROM `+scheduleSlotTables+`
+		WHERE s.class_id = $1
+		ORDER BY s.term_id, s.day_of_week, s.start_time;
+	`, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(slots) == 0 {
+		var exists bool
+		if err := r.db.Get(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM class WHERE class_id = $1);`, classID); err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, pkgErrors.ErrNotFound
+		}
+	}
+
+	return utils.Map(slots, func(s entities.ScheduleSlot) models.ScheduleSlot {
+		return s.ToScheduleSlotDomain()
+	}), nil
+}
+
+// UpdateSlot moves a slot to another term, room, day or time. The class of a slot cannot be changed.
+func (r *ScheduleStorage) UpdateSlot(ctx context.Context, slotID int64, slot models.ScheduleSlot) error {
+	ctx, span := tracer.Start(ctx, "ScheduleStorage.UpdateSlot")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, `
+		UPDATE schedule_slot
+		SET term_id = $2, room_id = $3, day_of_week = $4, start_time = $5::TEXT::TIME, end_time = $6::TEXT::TIME
+		WHERE slot_id = $1
+	`, slotID, slot.TermID, slot.RoomID, slot.DayOfWeek, slot.StartTime, slot.EndTime)
+	if err != nil {
+		return slotError(err)
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *ScheduleStorage) DeleteSlot(ctx context.Context, slotID int64) error {
+	ctx, span := tracer.Start(ctx, "ScheduleStorage.DeleteSlot")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, "DELETE FROM schedule_slot WHERE slot_id = $1", slotID)
+	if err != nil {
+		return err
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetStudentSchedule returns a term and the slots of the classes the student is enrolled or waitlisted in
+// during it, in weekly order. A nil termID selects the current term. It returns ErrNoCurrentTerm when no
+// term covers today, and ErrNotFound for an unknown term or student.
+func (r *ScheduleStorage) GetStudentSchedule(
+	ctx context.Context,
+	studentID int64,
+	termID *int64,
+) (models.AcademicTerm, []models.ScheduleSlot, error) {
+	ctx, span := tracer.Start(ctx, "ScheduleStorage.GetStudentSchedule")
+	defer span.End()
+
+	term, err := selectTerm(ctx, r.db, termID)
+	if err != nil {
+		return models.AcademicTerm{}, nil, err
+	}
+
+	var exists bool
+	if err := r.db.Get(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM student WHERE student_id = $1);`, studentID); err != nil {
+		return models.AcademicTerm{}, nil, err
+	}
+
+	if !exists {
+		return models.AcademicTerm{}, nil, pkgErrors.ErrNotFound
+	}
+
+	var slots []entities.ScheduleSlot
+
+	err = r.db.Select(ctx, &slots, `
+		SELECT `+scheduleSlotColumns+`, ci.status
+		FROM `+scheduleSlotTables+`
+		JOIN class_info ci ON ci.class_name = c.class_name AND ci.term_id = s.term_id
+		WHERE ci.student_id = $1 AND ci.term_id = $2
+		ORDER BY s.day_of_week, s.start_time, c.class_name;
+	`, studentID, term.TermID)
+	if err != nil {
+		return models.AcademicTerm{}, nil, err
+	}
+
+	return term, utils.Map(slots, func(s entities.ScheduleSlot) models.ScheduleSlot {
+		return s.ToScheduleSlotDomain()
+	}), nil
+}
@@ -194,3 +194,164 @@ func TestDeleteStudent(t *testing.T) {
 		assert.ErrorIs(t, err, pkgErrors.ErrNotFound)
 	})
 }
+
+func TestListStudent(t *testing.T) {
+
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Success", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentRepo := NewStudentStorage(db.DB)
+		for _, name := range []string{"A", "B", "C"} {
+			_, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: name, Grade: 90})
+			require.NoError(t, err)
+		}
+		//act
+		students, err := studentRepo.List(ctx, 2, 1, nil)
+		//assert
+		require.NoError(t, err)
+		require.Len(t, students, 2)
+		assert.Equal(t, "B", students[0].StudentName)
+		assert.Equal(t, "C", students[1].StudentName)
+		//act
+		total, err := studentRepo.Count(ctx)
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentRepo := NewStudentStorage(db.DB)
+		//act
+		students, err := studentRepo.List(ctx, 20, 0, nil)
+		//assert
+		require.NoError(t, err)
+		assert.Empty(t, students)
+	})
+}
+
+func TestStudentFields(t *testing.T) {
+
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Success", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentRepo := NewStudentStorage(db.DB)
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Grade: 90})
+		require.NoError(t, err)
+		//act
+		student, err := studentRepo.GetByIDWithFields(ctx, studentID, []string{"student_name"})
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, models.StudentRequest{StudentID: studentID, StudentName: "Test"}, student)
+		//act
+		students, err := studentRepo.List(ctx, 20, 0, []string{"grade"})
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, []models.StudentRequest{{StudentID: studentID, Grade: 90}}, students)
+	})
+	t.Run("Fail Unknown Field", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentRepo := NewStudentStorage(db.DB)
+		//act
+		_, err := studentRepo.List(ctx, 20, 0, []string{"grade; DROP TABLE student"})
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrUnknownField)
+	})
+}
+
+func TestStudentProfile(t *testing.T) {
+
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Kept by name and grade updates", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentRepo := NewStudentStorage(db.DB)
+		profile := models.StudentRequest{
+			StudentName: "Jane Doe", Grade: 9, FirstName: "Jane", LastName: "Doe", PreferredName: "JD",
+			DateOfBirth: "2010-04-02", Email: "jane@example.com", StudentNumber: "S-1",
+		}
+		studentID, err := studentRepo.Add(ctx, profile)
+		require.NoError(t, err)
+		//act
+		err = studentRepo.Update(ctx, studentID, models.StudentRequest{StudentName: "Jane Roe", Grade: 10})
+		//assert
+		require.NoError(t, err)
+		student, err := studentRepo.GetByID(ctx, studentID)
+		require.NoError(t, err)
+		assert.Equal(t, "Jane Roe", student.StudentName)
+		assert.Equal(t, "2010-04-02", student.DateOfBirth)
+		assert.Equal(t, "S-1", student.StudentNumber)
+		assert.Equal(t, models.StudentActive, student.Status)
+		require.NotNil(t, student.CreatedAt)
+		require.NotNil(t, student.UpdatedAt)
+		assert.False(t, student.UpdatedAt.Before(*student.CreatedAt))
+	})
+	t.Run("Student number taken", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentRepo := NewStudentStorage(db.DB)
+		_, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "A", StudentNumber: "S-1"})
+		require.NoError(t, err)
+		otherID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "B"})
+		require.NoError(t, err)
+		//act
+		_, addErr := studentRepo.Add(ctx, models.StudentRequest{StudentName: "C", StudentNumber: "S-1"})
+		updateErr := studentRepo.UpdateProfile(ctx, otherID, models.StudentRequest{StudentName: "B", StudentNumber: "S-1"})
+		//assert
+		assert.ErrorIs(t, addErr, pkgErrors.ErrConflict)
+		assert.ErrorIs(t, updateErr, pkgErrors.ErrConflict)
+	})
+	t.Run("Status lifecycle", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		studentRepo := NewStudentStorage(db.DB)
+		studentID, err := studentRepo.Add(ctx, models.StudentRequest{StudentName: "Test", Status: models.StudentApplicant})
+		require.NoError(t, err)
+		//act
+		_, admitErr := studentRepo.SetStatus(ctx, studentID, models.StudentActive, "Admitted")
+		_, graduateErr := studentRepo.SetStatus(ctx, studentID, models.StudentGraduated, "")
+		_, reopenErr := studentRepo.SetStatus(ctx, studentID, models.StudentActive, "")
+		_, unknownErr := studentRepo.SetStatus(ctx, 999, models.StudentActive, "")
+		//assert
+		require.NoError(t, admitErr)
+		require.NoError(t, graduateErr)
+		assert.ErrorIs(t, reopenErr, pkgErrors.ErrStatusTransition)
+		assert.ErrorIs(t, unknownErr, pkgErrors.ErrNotFound)
+		history, err := studentRepo.StatusHistory(ctx, studentID)
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.Nil(t, history[0].FromStatus)
+		assert.Equal(t, models.StudentApplicant, history[0].ToStatus)
+		require.NotNil(t, history[1].FromStatus)
+		assert.Equal(t, models.StudentApplicant, *history[1].FromStatus)
+		assert.Equal(t, "Admitted", history[1].Reason)
+		assert.Equal(t, models.StudentGraduated, history[2].ToStatus)
+		_, err = studentRepo.StatusHistory(ctx, 999)
+		assert.ErrorIs(t, err, pkgErrors.ErrNotFound)
+	})
+}
@@ -3,15 +3,27 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"CRUD_Go_Backend/internal/handlers/models"
 	"CRUD_Go_Backend/internal/pkg/connection"
 	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
 	"CRUD_Go_Backend/internal/repository/entities"
 
-	"github.com/jackc/pgx"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
 )
 
+// StudentColumns are the columns of student that callers may select.
+var StudentColumns = []string{
+	"student_id", "student_name", "grade", "first_name", "last_name", "preferred_name", "date_of_birth",
+	"email", "student_number", "status", "created_at", "updated_at",
+}
+
+const studentStatusChangeColumns = `student_id, from_status, to_status, reason, changed_at`
+
 type StudentStorage struct {
 	db connection.DBops
 }
@@ -21,40 +33,111 @@ func NewStudentStorage(database connection.DBops) StudentStorage {
 }
 
 func ToStudentStorage(s models.StudentRequest) entities.Student {
-	return entities.Student{
-		StudentID:   s.StudentID,
-		StudentName: s.StudentName,
-		Grade:       s.Grade,
+	student := entities.Student{
+		StudentID:     s.StudentID,
+		StudentName:   s.StudentName,
+		Grade:         s.Grade,
+		FirstName:     s.FirstName,
+		LastName:      s.LastName,
+		PreferredName: s.PreferredName,
+		Status:        s.Status,
+	}
+
+	if s.Email != "" {
+		student.Email = &s.Email
+	}
+
+	if s.StudentNumber != "" {
+		student.StudentNumber = &s.StudentNumber
 	}
+
+	return student
 }
 
+// studentError maps a taken student number to ErrConflict.
+func studentError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return pkgErrors.ErrConflict
+	}
+
+	return err
+}
+
+// Add creates a student and records its first status, active unless set. It returns ErrConflict when
+// the student number is taken.
 func (r *StudentStorage) Add(ctx context.Context, studentReq models.StudentRequest) (int64, error) {
+	ctx, span := tracer.Start(ctx, "StudentStorage.Add")
+	defer span.End()
+
 	student := ToStudentStorage(studentReq)
 	var studentID int64
-	err := r.db.ExecQueryRow(ctx,
-		`INSERT INTO student(student_name, grade) VALUES($1, $2) RETURNING student_id;`,
-		student.StudentName,
-		student.Grade,
-	).Scan(&studentID)
+	err := r.db.InTx(ctx, func(tx connection.DBops) error {
+		err := tx.ExecQueryRow(ctx, `
+			INSERT INTO student(
+				student_name, grade, first_name, last_name, preferred_name, date_of_birth, email, student_number, status
+			)
+			VALUES($1, $2, $3, $4, $5, NULLIF($6, '')::DATE, $7, $8, COALESCE(NULLIF($9, ''), 'active'))
+			RETURNING student_id;
+		`,
+			student.StudentName,
+			student.Grade,
+			student.FirstName,
+			student.LastName,
+			student.PreferredName,
+			studentReq.DateOfBirth,
+			student.Email,
+			student.StudentNumber,
+			student.Status,
+		).Scan(&studentID)
+		if err != nil {
+			return err
+		}
 
+		_, err = tx.Exec(ctx, `
+			INSERT INTO student_status_history(student_id, to_status)
+			SELECT student_id, status FROM student WHERE student_id = $1;
+		`, studentID)
+
+		return err
+	})
 	if err != nil {
-		return -1, err
+		return -1, studentError(err)
 	}
 
-	return studentID, err
+	return studentID, nil
 }
 
 func (r *StudentStorage) GetByID(ctx context.Context, studentID int64) (models.StudentRequest, error) {
+	ctx, span := tracer.Start(ctx, "StudentStorage.GetByID")
+	defer span.End()
+
+	return r.getByID(ctx, studentID, strings.Join(StudentColumns, ", "))
+}
+
+// GetByIDWithFields reads only the given columns of a student, plus student_id. Empty fields select every column.
+func (r *StudentStorage) GetByIDWithFields(
+	ctx context.Context,
+	studentID int64,
+	fields []string,
+) (models.StudentRequest, error) {
+	ctx, span := tracer.Start(ctx, "StudentStorage.GetByIDWithFields")
+	defer span.End()
+
+	columns, err := studentSelectList(fields)
+	if err != nil {
+		return models.StudentRequest{}, err
+	}
+
+	return r.getByID(ctx, studentID, columns)
+}
+
+func (r *StudentStorage) getByID(ctx context.Context, studentID int64, columns string) (models.StudentRequest, error) {
 	var student entities.Student
 
-	err := r.db.Get(
-		ctx,
-		&student,
-		`SELECT student_id, student_name, grade, created_at FROM student WHERE student_id=$1;`,
-		studentID,
-	)
+	err := r.db.Get(ctx, &student, `SELECT `+columns+` FROM student WHERE student_id=$1;`, studentID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
+		if pgxscan.NotFound(err) {
 			return models.StudentRequest{}, pkgErrors.ErrNotFound
 		}
 
@@ -65,6 +148,9 @@ func (r *StudentStorage) GetByID(ctx context.Context, studentID int64) (models.S
 }
 
 func (r *StudentStorage) Delete(ctx context.Context, studentID int64) error {
+	ctx, span := tracer.Start(ctx, "StudentStorage.Delete")
+	defer span.End()
+
 	command, err := r.db.Exec(ctx, "DELETE FROM student WHERE student_id = $1", studentID)
 	if err != nil {
 		return err
@@ -77,12 +163,16 @@ func (r *StudentStorage) Delete(ctx context.Context, studentID int64) error {
 	return nil
 }
 
+// Update sets the name and grade of a student, and keeps its profile.
 func (r *StudentStorage) Update(ctx context.Context, studentID int64, studentReq models.StudentRequest) error {
+	ctx, span := tracer.Start(ctx, "StudentStorage.Update")
+	defer span.End()
+
 	student := ToStudentStorage(studentReq)
 
 	command, err := r.db.Exec(ctx, `
 		UPDATE student
-		SET student_name = $2, grade = $3
+		SET student_name = $2, grade = $3, updated_at = NOW()
 		WHERE student_id = $1
 	`, studentID, student.StudentName, student.Grade)
 
@@ -96,3 +186,176 @@ func (r *StudentStorage) Update(ctx context.Context, studentID int64, studentReq
 
 	return nil
 }
+
+// UpdateProfile replaces the name, grade and profile of a student. Its status is kept, since it only
+// changes through SetStatus. It returns ErrConflict when the student number is taken.
+func (r *StudentStorage) UpdateProfile(ctx context.Context, studentID int64, studentReq models.StudentRequest) error {
+	ctx, span := tracer.Start(ctx, "StudentStorage.UpdateProfile")
+	defer span.End()
+
+	student := ToStudentStorage(studentReq)
+
+	command, err := r.db.Exec(ctx, `
+		UPDATE student
+		SET student_name = $2, grade = $3, first_name = $4, last_name = $5, preferred_name = $6,
+			date_of_birth = NULLIF($7, '')::DATE, email = $8, student_number = $9, updated_at = NOW()
+		WHERE student_id = $1
+	`,
+		studentID,
+		student.StudentName,
+		student.Grade,
+		student.FirstName,
+		student.LastName,
+		student.PreferredName,
+		studentReq.DateOfBirth,
+		student.Email,
+		student.StudentNumber,
+	)
+	if err != nil {
+		return studentError(err)
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetStatus changes the status of a student and records the change with reason. It returns ErrNotFound for
+// an unknown student, and ErrStatusTransition when the student cannot change from its status to status.
+func (r *StudentStorage) SetStatus(
+	ctx context.Context,
+	studentID int64,
+	status string,
+	reason string,
+) (models.StudentStatusChange, error) {
+	ctx, span := tracer.Start(ctx, "StudentStorage.SetStatus")
+	defer span.End()
+
+	var change entities.StudentStatusChange
+
+	err := r.db.InTx(ctx, func(tx connection.DBops) error {
+		var current string
+
+		err := tx.Get(ctx, &current, `SELECT status FROM student WHERE student_id = $1 FOR UPDATE;`, studentID)
+		if err != nil {
+			if pgxscan.NotFound(err) {
+				return pkgErrors.ErrNotFound
+			}
+
+			return err
+		}
+
+		if !models.CanChangeStudentStatus(current, status) {
+			return fmt.Errorf("%w: from %s to %s", pkgErrors.ErrStatusTransition, current, status)
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE student SET status = $2, updated_at = NOW() WHERE student_id = $1;`, studentID, status)
+		if err != nil {
+			return err
+		}
+
+		return tx.Get(ctx, &change, `
+			INSERT INTO student_status_history(student_id, from_status, to_status, reason)
+			VALUES($1, $2, $3, $4)
+			RETURNING `+studentStatusChangeColumns+`;
+		`, studentID, current, status, reason)
+	})
+	if err != nil {
+		return models.StudentStatusChange{}, err
+	}
+
+	return change.ToStudentStatusChangeDomain(), nil
+}
+
+// StatusHistory returns the statuses of a student, oldest first. It returns ErrNotFound for an unknown student.
+func (r *StudentStorage) StatusHistory(ctx context.Context, studentID int64) ([]models.StudentStatusChange, error) {
+	ctx, span := tracer.Start(ctx, "StudentStorage.StatusHistory")
+	defer span.End()
+
+	var changes []entities.StudentStatusChange
+
+	err := r.db.Select(ctx, &changes, `
+		SELECT `+studentStatusChangeColumns+`
+		FROM student_status_history
+		WHERE student_id = $1
+		ORDER BY changed_at, id;
+	`, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every student has the status it was created with, so an empty history means an unknown student.
+	if len(changes) == 0 {
+		return nil, pkgErrors.ErrNotFound
+	}
+
+	return utils.Map(changes, func(c entities.StudentStatusChange) models.StudentStatusChange {
+		return c.ToStudentStatusChangeDomain()
+	}), nil
+}
+
+// List returns a page of students ordered by student_id, reading only the given columns plus student_id.
+// Empty fields select every column.
+func (r *StudentStorage) List(ctx context.Context, limit, offset int64, fields []string) ([]models.StudentRequest, error) {
+	ctx, span := tracer.Start(ctx, "StudentStorage.List")
+	defer span.End()
+
+	columns, err := studentSelectList(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var students []entities.Student
+
+	err = r.db.Select(
+		ctx,
+		&students,
+		`SELECT `+columns+` FROM student ORDER BY student_id LIMIT $1 OFFSET $2;`,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.Map(students, func(s entities.Student) models.StudentRequest {
+		return s.ToStudentDomain()
+	}), nil
+}
+
+func (r *StudentStorage) Count(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "StudentStorage.Count")
+	defer span.End()
+
+	var count int64
+
+	if err := r.db.Get(ctx, &count, `SELECT COUNT(*) FROM student;`); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// studentSelectList builds the select list for fields from StudentColumns only, so that it is safe to
+// interpolate. student_id is always selected, since callers key related data by it.
+func studentSelectList(fields []string) (string, error) {
+	if len(fields) == 0 {
+		return strings.Join(StudentColumns, ", "), nil
+	}
+
+	columns := []string{"student_id"}
+
+	for _, field := range fields {
+		if !utils.Contains(StudentColumns, field) {
+			return "", fmt.Errorf("%w: %q", pkgErrors.ErrUnknownField, field)
+		}
+
+		if !utils.Contains(columns, field) {
+			columns = append(columns, field)
+		}
+	}
+
+	return strings.Join(columns, ", "), nil
+}
@@ -0,0 +1,130 @@
+//go:build integration
+// +build integration
+
+package repository
+
+import (
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/repository/postgres"
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"testing"
+)
+
+func TestCreateTeacher(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Success", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		teacherRepo := NewTeacherStorage(db.DB)
+		//act
+		teacherID, err := teacherRepo.Add(ctx, models.TeacherRequest{TeacherName: "Smith"})
+		require.NoError(t, err)
+		err = teacherRepo.Update(ctx, teacherID, models.TeacherRequest{TeacherName: "Jones"})
+		require.NoError(t, err)
+		teacher, err := teacherRepo.GetByID(ctx, teacherID)
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, models.TeacherRequest{TeacherID: teacherID, TeacherName: "Jones"}, teacher)
+	})
+	t.Run("Not Found", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		teacherRepo := NewTeacherStorage(db.DB)
+		//act
+		_, getErr := teacherRepo.GetByID(ctx, 1)
+		deleteErr := teacherRepo.Delete(ctx, 1)
+		//assert
+		assert.ErrorIs(t, getErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, deleteErr, pkgErrors.ErrNotFound)
+	})
+}
+
+func TestAssignClassTeacher(t *testing.T) {
+	db := postgres.NewFromEnv()
+	defer db.DB.GetPool(context.Background()).Close()
+	var (
+		ctx           = context.Background()
+		migrationPath = "./migrations"
+	)
+	t.Run("Success", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		teacherRepo := NewTeacherStorage(db.DB)
+		leadID, err := teacherRepo.Add(ctx, models.TeacherRequest{TeacherName: "Smith"})
+		require.NoError(t, err)
+		assistantID, err := teacherRepo.Add(ctx, models.TeacherRequest{TeacherName: "Jones"})
+		require.NoError(t, err)
+		//act
+		lead, err := teacherRepo.AssignClass(ctx, models.TeacherAssignment{
+			ClassName: "Math", TeacherID: leadID, Role: models.TeacherRoleLead,
+		})
+		require.NoError(t, err)
+		assistant, err := teacherRepo.AssignClass(ctx, models.TeacherAssignment{
+			ClassName: "Math", TeacherID: assistantID, Role: models.TeacherRoleAssistant,
+		})
+		require.NoError(t, err)
+		teachers, err := teacherRepo.GetTeachersByClassID(ctx, lead.ClassID)
+		require.NoError(t, err)
+		classes, err := teacherRepo.GetClassesByTeacherID(ctx, assistantID)
+		//assert
+		require.NoError(t, err)
+		assert.Equal(t, lead.ClassID, assistant.ClassID)
+		assert.Equal(t, "Smith", lead.TeacherName)
+		require.Len(t, teachers, 2)
+		assert.Equal(t, models.TeacherRoleLead, teachers[0].Role)
+		assert.Equal(t, models.TeacherRoleAssistant, teachers[1].Role)
+		require.Len(t, classes, 1)
+		assert.Equal(t, "Math", classes[0].ClassName)
+	})
+	t.Run("Second lead", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		teacherRepo := NewTeacherStorage(db.DB)
+		firstID, err := teacherRepo.Add(ctx, models.TeacherRequest{TeacherName: "Smith"})
+		require.NoError(t, err)
+		secondID, err := teacherRepo.Add(ctx, models.TeacherRequest{TeacherName: "Jones"})
+		require.NoError(t, err)
+		_, err = teacherRepo.AssignClass(ctx, models.TeacherAssignment{
+			ClassName: "Math", TeacherID: firstID, Role: models.TeacherRoleLead,
+		})
+		require.NoError(t, err)
+		//act
+		_, err = teacherRepo.AssignClass(ctx, models.TeacherAssignment{
+			ClassName: "Math", TeacherID: secondID, Role: models.TeacherRoleLead,
+		})
+		//assert
+		assert.ErrorIs(t, err, pkgErrors.ErrConflict)
+	})
+	t.Run("Teacher not found", func(t *testing.T) {
+		db.SetUpDatabase(migrationPath)
+		defer db.TearDownDatabase(migrationPath)
+		//arrange
+		teacherRepo := NewTeacherStorage(db.DB)
+		//act
+		_, assignErr := teacherRepo.AssignClass(ctx, models.TeacherAssignment{
+			ClassName: "Math", TeacherID: 1, Role: models.TeacherRoleLead,
+		})
+		_, classesErr := teacherRepo.GetClassesByTeacherID(ctx, 1)
+		_, teachersErr := teacherRepo.GetTeachersByClassID(ctx, 1)
+		unassignErr := teacherRepo.UnassignClass(ctx, 1, 1)
+		//assert
+		assert.ErrorIs(t, assignErr, pkgErrors.ErrReferenceNotFound)
+		assert.ErrorIs(t, classesErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, teachersErr, pkgErrors.ErrNotFound)
+		assert.ErrorIs(t, unassignErr, pkgErrors.ErrNotFound)
+	})
+}
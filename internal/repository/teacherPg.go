@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"CRUD_Go_Backend/internal/handlers/models"
+	"CRUD_Go_Backend/internal/pkg/connection"
+	"CRUD_Go_Backend/internal/pkg/pkgErrors"
+	"CRUD_Go_Backend/internal/pkg/utils"
+	"CRUD_Go_Backend/internal/repository/entities"
+
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgconn"
+)
+
+// uniqueViolation is the SQLSTATE raised when an assignment would give a class a second lead teacher.
+const uniqueViolation = "23505"
+
+type TeacherStorage struct {
+	db connection.DBops
+}
+
+func NewTeacherStorage(database connection.DBops) TeacherStorage {
+	return TeacherStorage{db: database}
+}
+
+func ToTeacherStorage(t models.TeacherRequest) entities.Teacher {
+	return entities.Teacher{
+		TeacherID:   t.TeacherID,
+		TeacherName: t.TeacherName,
+	}
+}
+
+func (r *TeacherStorage) Add(ctx context.Context, teacherReq models.TeacherRequest) (int64, error) {
+	ctx, span := tracer.Start(ctx, "TeacherStorage.Add")
+	defer span.End()
+
+	teacher := ToTeacherStorage(teacherReq)
+	var teacherID int64
+
+	err := r.db.ExecQueryRow(ctx,
+		`INSERT INTO teacher(teacher_name) VALUES($1) RETURNING teacher_id;`,
+		teacher.TeacherName,
+	).Scan(&teacherID)
+	if err != nil {
+		return -1, err
+	}
+
+	return teacherID, nil
+}
+
+func (r *TeacherStorage) GetByID(ctx context.Context, teacherID int64) (models.TeacherRequest, error) {
+	ctx, span := tracer.Start(ctx, "TeacherStorage.GetByID")
+	defer span.End()
+
+	var teacher entities.Teacher
+
+	err := r.db.Get(ctx, &teacher, `SELECT teacher_id, teacher_name, created_at FROM teacher WHERE teacher_id=$1;`, teacherID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return models.TeacherRequest{}, pkgErrors.ErrNotFound
+		}
+
+		return models.TeacherRequest{}, err
+	}
+
+	return teacher.ToTeacherDomain(), nil
+}
+
+func (r *TeacherStorage) Delete(ctx context.Context, teacherID int64) error {
+	ctx, span := tracer.Start(ctx, "TeacherStorage.Delete")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, "DELETE FROM teacher WHERE teacher_id = $1", teacherID)
+	if err != nil {
+		return err
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *TeacherStorage) Update(ctx context.Context, teacherID int64, teacherReq models.TeacherRequest) error {
+	ctx, span := tracer.Start(ctx, "TeacherStorage.Update")
+	defer span.End()
+
+	teacher := ToTeacherStorage(teacherReq)
+
+	command, err := r.db.Exec(ctx, `
+		UPDATE teacher
+		SET teacher_name = $2
+		WHERE teacher_id = $1
+	`, teacherID, teacher.TeacherName)
+	if err != nil {
+		return err
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// AssignClass gives the teacher a role in the class named by assignment.ClassName, creating the class
+// if it does not exist yet, or changes the role when the teacher is already assigned to it.
+func (r *TeacherStorage) AssignClass(ctx context.Context, assignment models.TeacherAssignment) (models.TeacherAssignment, error) {
+	ctx, span := tracer.Start(ctx, "TeacherStorage.AssignClass")
+	defer span.End()
+
+	var stored entities.TeacherAssignment
+
+	err := r.db.Get(ctx, &stored, `
+		WITH class_row AS (
+			INSERT INTO class(class_name) VALUES($2)
+			ON CONFLICT (class_name) DO UPDATE SET class_name = EXCLUDED.class_name
+			RETURNING class_id, class_name
+		), assignment AS (
+			INSERT INTO class_teacher(class_id, teacher_id, role) SELECT class_id, $1, $3 FROM class_row
+			ON CONFLICT (class_id, teacher_id) DO UPDATE SET role = EXCLUDED.role
+			RETURNING class_id, teacher_id, role
+		)
+		SELECT a.class_id, c.class_name, a.teacher_id, t.teacher_name, a.role
+		FROM assignment a
+		JOIN class_row c ON c.class_id = a.class_id
+		JOIN teacher t ON t.teacher_id = a.teacher_id;
+	`, assignment.TeacherID, assignment.ClassName, assignment.Role)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case foreignKeyViolation:
+				return models.TeacherAssignment{}, pkgErrors.ErrReferenceNotFound
+			case uniqueViolation:
+				return models.TeacherAssignment{}, pkgErrors.ErrConflict
+			case exclusionViolation:
+				return models.TeacherAssignment{}, scheduleError(err)
+			}
+		}
+
+		return models.TeacherAssignment{}, err
+	}
+
+	return stored.ToTeacherAssignmentDomain(), nil
+}
+
+func (r *TeacherStorage) UnassignClass(ctx context.Context, teacherID, classID int64) error {
+	ctx, span := tracer.Start(ctx, "TeacherStorage.UnassignClass")
+	defer span.End()
+
+	command, err := r.db.Exec(ctx, "DELETE FROM class_teacher WHERE teacher_id = $1 AND class_id = $2", teacherID, classID)
+	if err != nil {
+		return err
+	}
+
+	if command.RowsAffected() == 0 {
+		return pkgErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetClassesByTeacherID lists the classes of a teacher with the teacher's role in each.
+// It returns ErrNotFound when the teacher does not exist, and an empty list when it has no classes.
+func (r *TeacherStorage) GetClassesByTeacherID(ctx context.Context, teacherID int64) ([]models.TeacherAssignment, error) {
+	ctx, span := tracer.Start(ctx, "TeacherStorage.GetClassesByTeacherID")
+	defer span.End()
+
+	return r.listAssignments(
+		ctx,
+		`ct.teacher_id = $1 ORDER BY c.class_name`,
+		`SELECT EXISTS(SELECT 1 FROM teacher WHERE teacher_id = $1);`,
+		teacherID,
+	)
+}
+
+// GetTeachersByClassID lists the teachers of a class, lead first.
+// It returns ErrNotFound when the class does not exist, and an empty list when it has no teachers.
+func (r *TeacherStorage) GetTeachersByClassID(ctx context.Context, classID int64) ([]models.TeacherAssignment, error) {
+	ctx, span := tracer.Start(ctx, "TeacherStorage.GetTeachersByClassID")
+	defer span.End()
+
+	return r.listAssignments(
+		ctx,
+		`ct.class_id = $1 ORDER BY ct.role <> 'lead', t.teacher_name`,
+		`SELECT EXISTS(SELECT 1 FROM class WHERE class_id = $1);`,
+		classID,
+	)
+}
+
+// listAssignments selects the assignments matching condition. When there are none, existsQuery tells
+// an unknown id, which is ErrNotFound, from one without assignments.
+func (r *TeacherStorage) listAssignments(
+	ctx context.Context,
+	condition string,
+	existsQuery string,
+	id int64,
+) ([]models.TeacherAssignment, error) {
+	var assignments []entities.TeacherAssignment
+
+	err := r.db.Select(ctx, &assignments, `
+		SELECT ct.class_id, c.class_name, ct.teacher_id, t.teacher_name, ct.role
+		FROM class_teacher ct
+		JOIN class c ON c.class_id = ct.class_id
+		JOIN teacher t ON t.teacher_id = ct.teacher_id
+		WHERE `+condition+`;
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(assignments) == 0 {
+		var exists bool
+		if err := r.db.Get(ctx, &exists, existsQuery, id); err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return nil, pkgErrors.ErrNotFound
+		}
+	}
+
+	return utils.Map(assignments, func(a entities.TeacherAssignment) models.TeacherAssignment {
+		return a.ToTeacherAssignmentDomain()
+	}), nil
+}